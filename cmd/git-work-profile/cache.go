@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/cache"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// 查看AI分析结果缓存的子命令
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Show the cache of AI analysis results",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(_ *cobra.Command, _ []string) error {
+		msg := i18n.T()
+		analysisCache, err := openCache()
+		if err != nil {
+			return err
+		}
+		stats, err := analysisCache.Stats()
+		if err != nil {
+			return fmt.Errorf(msg.ErrorCacheFailed, err)
+		}
+		fmt.Printf(msg.InfoCacheDir+"\n", analysisCache.Dir)
+		fmt.Printf(msg.InfoCacheEntries+"\n", stats.Entries, formatBytes(stats.Size))
+		return nil
+	},
+}
+
+// 清空AI分析结果缓存的子命令
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached AI analysis results",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(_ *cobra.Command, _ []string) error {
+		msg := i18n.T()
+		analysisCache, err := openCache()
+		if err != nil {
+			return err
+		}
+		removed, err := analysisCache.Clear()
+		if err != nil {
+			return fmt.Errorf(msg.ErrorCacheFailed, err)
+		}
+		fmt.Printf(msg.InfoCacheCleared+"\n", removed)
+		return nil
+	},
+}
+
+// openCache 打开默认目录中的缓存
+func openCache() (*cache.Cache, error) {
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T().ErrorCacheFailed, err)
+	}
+	return cache.New(dir), nil
+}
+
+// formatBytes 将字节数格式化为 KB、MB 等单位
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, exp := float64(size)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGT"[exp])
+}
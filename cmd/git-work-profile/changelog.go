@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/ai"
+	"github.com/MyceliumGrid/git-work-profile/internal/cache"
+	"github.com/MyceliumGrid/git-work-profile/internal/changelog"
+	"github.com/MyceliumGrid/git-work-profile/internal/cite"
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/MyceliumGrid/git-work-profile/internal/term"
+	"github.com/MyceliumGrid/git-work-profile/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseName   string // 版本名称，为空时使用范围终点的引用名，终点为 HEAD 时为 Unreleased
+	changelogOnly bool   // 只输出更新日志，不调用AI撰写发布说明
+)
+
+// 生成两个标签之间的发布说明和更新日志的子命令
+var changelogCmd = &cobra.Command{
+	Use:   "changelog [range]",
+	Short: "Generate release notes and a changelog between tags",
+	Args:  usageArgs(cobra.MaximumNArgs(1)),
+	RunE: func(_ *cobra.Command, args []string) error {
+		expr := ""
+		if len(args) > 0 {
+			expr = args[0]
+		}
+		return runChangelog(expr)
+	},
+}
+
+// addChangelogFlags 添加 changelog 子命令的参数
+func addChangelogFlags(cmd *cobra.Command) {
+	msg := i18n.T()
+	cmd.Flags().StringVar(&repoPath, "repo", "", msg.FlagRepo)
+	cmd.Flags().StringVar(&outputFile, "output", "", msg.FlagOutput)
+	cmd.Flags().StringVar(&releaseName, "release", "", msg.FlagRelease)
+	cmd.Flags().BoolVar(&changelogOnly, "no-ai", false, msg.FlagChangelogOnly)
+	cmd.Flags().StringVar(&modelName, "model", "", msg.FlagModel)
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, msg.FlagNoCache)
+	cmd.Flags().IntVar(&maxToolCalls, "max-tool-calls", ai.DefaultMaxToolCalls, msg.FlagMaxToolCalls)
+	cmd.Flags().StringArrayVar(&issuePatterns, "issue-pattern", nil, msg.FlagIssuePattern)
+	cmd.Flags().StringArrayVar(&issueURLs, "issue-url", nil, msg.FlagIssueURL)
+}
+
+// runChangelog 收集版本范围内所有作者的提交，生成 Keep a Changelog 格式的更新日志，
+// 并由AI在其之前撰写发布说明
+func runChangelog(expr string) error {
+	msg := i18n.T()
+	opts := &git.Options{RepoPath: repoPath}
+	rng, err := git.ResolveRefRange(expr, opts)
+	if err != nil {
+		return usageError(fmt.Errorf(msg.ErrorInvalidRefRange, err))
+	}
+	ticketExtractor, err := newTicketExtractor()
+	if err != nil {
+		return usageError(fmt.Errorf(msg.ErrorInvalidIssueFlag, err))
+	}
+
+	// 进度信息输出到标准错误，避免混入发布说明
+	content, err := buildReleaseNotes(rng, opts, ticketExtractor, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case outputFile != "":
+		file, err := createOutput(outputFile)
+		if err != nil {
+			return outputError(fmt.Errorf(msg.ErrorCreateOutputFile, err))
+		}
+		_, err = file.WriteString(content)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return outputError(fmt.Errorf(msg.ErrorOutputFailed, err))
+		}
+		fmt.Fprintf(os.Stderr, msg.InfoReportSaved+"\n", outputFile)
+	case term.IsTerminal(os.Stdout):
+		if err := pageMarkdown(content); err != nil {
+			return outputError(fmt.Errorf(msg.ErrorOutputFailed, err))
+		}
+	default:
+		fmt.Print(content)
+	}
+	return nil
+}
+
+// buildReleaseNotes 返回发布说明和更新日志的 Markdown，进度信息写到 progress
+func buildReleaseNotes(rng git.RefRange, opts *git.Options, extractor *tickets.Extractor, progress io.Writer) (string, error) {
+	msg := i18n.T()
+	commits, err := git.GetCommitsInRange(rng, opts)
+	if err != nil {
+		return "", gitError(err)
+	}
+	if len(commits) == 0 {
+		return "", gitError(fmt.Errorf(msg.ErrorNoCommitsInRefRange, rng))
+	}
+	fmt.Fprintf(progress, msg.InfoChangelogCommits+"\n", len(commits), rng)
+
+	// 版本名称和发布日期
+	version, date := releaseName, time.Now()
+	if rng.To != "HEAD" {
+		if version == "" {
+			version = rng.To
+		}
+		if refDate, err := git.RefDate(rng.To, opts); err == nil {
+			date = refDate
+		}
+	}
+	if version == "" {
+		version = changelog.Unreleased
+	}
+
+	// #N 形式的 issue 链接到仓库自己的 issue 页面
+	remote := git.RemoteWebURL(opts)
+	if remote != "" {
+		extractor.SetRemotes(map[string]string{commits[0].RepoPath: remote})
+	}
+	log := changelog.Build(version, date, commits, extractor)
+	log.Remote = remote
+	if log.Empty() {
+		fmt.Fprintln(progress, msg.WarningChangelogEmpty)
+	}
+	if changelogOnly {
+		return log.Markdown(), nil
+	}
+
+	// 由AI根据提交记录和更新日志撰写发布说明
+	apiKey := os.Getenv("GEMINI_API_KEY")
+	if apiKey == "" {
+		return "", aiError(errors.New(msg.ErrorAPIKeyNotSet))
+	}
+	geminiClient, err := ai.NewGeminiClientWithModel(modelName)
+	if err != nil {
+		return "", aiError(fmt.Errorf(msg.ErrorCreateClient, err))
+	}
+	defer geminiClient.Close()
+
+	geminiClient.SetPromptContext(ai.PromptContext{Tickets: tickets.Group(commits, extractor), Changelog: log.Markdown()})
+	geminiClient.EnableTools(ai.ToolOptions{MaxCalls: maxToolCalls, MaxOutput: ai.DefaultMaxToolOutput, Progress: progress})
+	if !noCache {
+		if dir, err := cache.DefaultDir(); err == nil {
+			geminiClient.SetCache(cache.New(dir))
+		}
+	}
+
+	fmt.Fprintln(progress, msg.InfoAIAnalyzing)
+	narrative, err := geminiClient.SummarizeCommitsWithPrompt(commits, ai.ReleaseNotesPrompt)
+	if err != nil {
+		return "", aiError(fmt.Errorf(msg.ErrorAIAnalysisFailed, err))
+	}
+
+	// 校验AI引用的提交哈希，删除找不到的引用，其余转换为提交链接
+	citations := cite.Resolve(narrative, commits, map[string]string{commits[0].RepoPath: remote})
+	narrative = cite.Clean(narrative, citations)
+	if len(citations.Invalid) > 0 {
+		fmt.Fprintf(progress, msg.WarningInvalidCitations+"\n", strings.Join(citations.Invalid, ", "))
+	}
+	narrative = cite.Link(narrative, citations)
+
+	title := fmt.Sprintf(msg.ReportTitleRelease, version)
+	return fmt.Sprintf("# %s\n\n%s\n\n%s", title, strings.TrimSpace(narrative), log.Markdown()), nil
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/ai"
+	"github.com/MyceliumGrid/git-work-profile/internal/cache"
+	"github.com/MyceliumGrid/git-work-profile/internal/config"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	configPath string // 用户配置文件路径，为空表示使用默认位置
+	presetName string // 要应用的预设名称
+
+	explicitFlags  int               // 命令行中显式指定的参数个数，不含配置文件应用的参数
+	settingSources map[string]string // 每个参数的值的来源
+	configFiles    [2]string         // 用户配置和仓库配置文件的路径
+)
+
+// 显示生效的配置的子命令
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show the effective configuration",
+	Args:  usageArgs(cobra.NoArgs),
+	Run: func(cmd *cobra.Command, _ []string) {
+		showConfig(cmd)
+	},
+}
+
+// 显示每个选项的值及其来源的子命令
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the effective value and source of every option",
+	Args:  usageArgs(cobra.NoArgs),
+	Run: func(cmd *cobra.Command, _ []string) {
+		showConfig(cmd)
+	},
+}
+
+// addConfigFlags 添加选择配置文件和预设的参数，对所有子命令生效
+func addConfigFlags(cmd *cobra.Command) {
+	msg := i18n.T()
+	cmd.PersistentFlags().StringVar(&configPath, "config", "", msg.FlagConfig)
+	cmd.PersistentFlags().StringVar(&presetName, "preset", "", msg.FlagPreset)
+}
+
+// applyConfig 将环境变量、配置文件和预设中的设置应用到命令未显式指定的参数上，
+// 优先级为 参数 > 环境变量 > 预设 > 仓库配置 > 用户配置
+func applyConfig(cmd *cobra.Command) error {
+	msg := i18n.T()
+	flags := cmd.Flags()
+	explicitFlags = flags.NFlag()
+
+	userPath := configPath
+	if userPath == "" {
+		var err error
+		if userPath, err = config.UserFile(); err != nil {
+			return usageError(fmt.Errorf(msg.ErrorConfigFailed, err))
+		}
+	}
+	repoPath := config.FindRepoFile(".")
+	configFiles = [2]string{userPath, repoPath}
+
+	keys := configKeys()
+	known := func(command, key string) bool { return keys[command][key] }
+	user, err := config.Load(userPath)
+	if err == nil {
+		err = user.Validate(known)
+	}
+	if err != nil {
+		return usageError(fmt.Errorf(msg.ErrorConfigFailed, err))
+	}
+	repo, err := config.Load(repoPath)
+	if err == nil {
+		err = repo.Validate(known)
+	}
+	if err != nil {
+		return usageError(fmt.Errorf(msg.ErrorConfigFailed, err))
+	}
+
+	if presetName == "" {
+		presetName = os.Getenv(config.PresetEnvVar)
+	}
+	layers, err := config.Layers(user, repo, configSection(cmd), presetName)
+	if err != nil {
+		available := strings.Join(config.PresetNames(user, repo), ", ")
+		if available == "" {
+			available = msg.LabelConfigNoPresets
+		}
+		return usageError(fmt.Errorf(msg.ErrorUnknownPreset, presetName, available))
+	}
+
+	names := make([]string, 0, len(keys[""]))
+	for name := range keys[""] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	layers = append(layers, config.EnvLayer(names, os.LookupEnv))
+	settings := config.Merge(layers...)
+
+	settingSources = make(map[string]string)
+	var applyErr error
+	flags.VisitAll(func(f *pflag.Flag) {
+		if applyErr != nil || !keys[""][f.Name] {
+			return
+		}
+		if f.Changed {
+			settingSources[f.Name] = config.SourceFlag
+			return
+		}
+		setting, ok := settings[f.Name]
+		if !ok {
+			settingSources[f.Name] = config.SourceDefault
+			return
+		}
+		if err := setFlag(flags, f, setting.Values); err != nil {
+			applyErr = usageError(fmt.Errorf(msg.ErrorConfigValue, strings.Join(setting.Values, ","), f.Name, setting.Source, err))
+			return
+		}
+		settingSources[f.Name] = setting.Source
+	})
+	return applyErr
+}
+
+// setFlag 设置参数的值。列表参数逐项设置，其他参数的多个值以逗号连接
+func setFlag(flags *pflag.FlagSet, f *pflag.Flag, values []string) error {
+	if _, ok := f.Value.(pflag.SliceValue); !ok {
+		return flags.Set(f.Name, strings.Join(values, ","))
+	}
+	for _, value := range values {
+		if err := flags.Set(f.Name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configSection 返回子命令在配置文件中的小节名称。根命令与 analyze 相同，
+// config 显示的也是 analyze 的选项
+func configSection(cmd *cobra.Command) string {
+	if cmd == rootCmd || cmd == configCmd || cmd.Parent() == configCmd {
+		return analyzeCmd.Name()
+	}
+	return strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+}
+
+// configKeys 返回配置文件中可以使用的键：空字符串对应所有子命令参数的名称，
+// 其他为各子命令小节中可以使用的参数名称
+func configKeys() map[string]map[string]bool {
+	keys := map[string]map[string]bool{"": {}}
+	var visit func(cmd *cobra.Command)
+	visit = func(cmd *cobra.Command) {
+		section := configSection(cmd)
+		if keys[section] == nil {
+			keys[section] = make(map[string]bool)
+		}
+		cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
+			if f.Name != "help" {
+				keys[""][f.Name] = true
+				keys[section][f.Name] = true
+			}
+		})
+		for _, sub := range cmd.Commands() {
+			visit(sub)
+		}
+	}
+	visit(rootCmd)
+	return keys
+}
+
+// showConfig 输出配置文件位置、环境设置，以及每个参数生效的值和来源，格式与配置文件相同
+func showConfig(cmd *cobra.Command) {
+	msg := i18n.T()
+
+	for i, label := range []string{config.SourceUser, config.SourceRepo} {
+		path := configFiles[i]
+		if path == "" {
+			path = msg.LabelConfigNotFound
+		} else if _, err := os.Stat(path); err != nil {
+			path += " " + msg.LabelConfigNotFound
+		}
+		fmt.Printf("# %s: %s\n", label, path)
+	}
+	if presetName != "" {
+		fmt.Printf("# preset: %s\n", presetName)
+	}
+
+	apiKey := "(not set)"
+	if os.Getenv("GEMINI_API_KEY") != "" {
+		apiKey = "(set)"
+	}
+	cacheDir, err := cache.DefaultDir()
+	if err != nil {
+		cacheDir = "(" + err.Error() + ")"
+	}
+	fmt.Printf("# language: %s\n", i18n.GetLanguage())
+	fmt.Printf("# gemini_api_key: %s\n", apiKey)
+	fmt.Printf("# cache_dir: %s\n", cacheDir)
+	fmt.Println()
+
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		source, ok := settingSources[f.Name]
+		if !ok {
+			return
+		}
+		value := f.Value.String()
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			value = "[" + strings.Join(slice.GetSlice(), ", ") + "]"
+		} else if f.Name == "model" && value == "" {
+			value = ai.DefaultModelName
+		} else if value == "" || strings.ContainsAny(value, "{}[]:#,&*!|>'\"%@`") {
+			value = strconv.Quote(value)
+		}
+		fmt.Printf("%s: %s  # %s\n", f.Name, value, source)
+	})
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+// 退出码，脚本和 CI 可以据此区分失败的原因
+const (
+	exitOK     = 0
+	exitError  = 1 // 其他错误
+	exitUsage  = 2 // 命令行参数错误
+	exitGit    = 3 // 发现仓库或读取提交记录失败，或没有找到提交
+	exitAI     = 4 // 创建AI客户端或AI分析失败
+	exitOutput = 5 // 写出报告、图表或导出文件失败
+)
+
+// cliError 带退出码的错误
+type cliError struct {
+	code int
+	err  error
+}
+
+func (e *cliError) Error() string { return e.err.Error() }
+func (e *cliError) Unwrap() error { return e.err }
+
+// usageError 命令行参数错误
+func usageError(err error) error { return &cliError{code: exitUsage, err: err} }
+
+// gitError 仓库或提交记录相关的错误
+func gitError(err error) error { return &cliError{code: exitGit, err: err} }
+
+// aiError AI分析相关的错误
+func aiError(err error) error { return &cliError{code: exitAI, err: err} }
+
+// outputError 写出文件相关的错误
+func outputError(err error) error { return &cliError{code: exitOutput, err: err} }
+
+// exitCode 返回错误对应的退出码，没有分类的错误为 exitError
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var cliErr *cliError
+	if errors.As(err, &cliErr) {
+		return cliErr.code
+	}
+	return exitError
+}
+
+// usageArgs 将位置参数的校验错误标记为参数错误
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return usageError(err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/MyceliumGrid/git-work-profile/internal/ownership"
+	"github.com/MyceliumGrid/git-work-profile/internal/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	expertsHalfLife int // 权重半衰期（天）
+	expertsLimit    int // 最多列出的人数
+)
+
+// maxKnowledgeAreas 开发者画像的知识地图最多列出的代码区域数
+const maxKnowledgeAreas = 15
+
+// 按熟悉程度列出某个路径的专家的子命令，不需要AI
+var expertsCmd = &cobra.Command{
+	Use:   "experts <path>",
+	Short: "Rank who knows a file or directory best",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runExperts(args[0])
+	},
+}
+
+// addExpertsFlags 添加 experts 子命令的参数，统计所有作者的提交
+func addExpertsFlags(cmd *cobra.Command) {
+	msg := i18n.T()
+	cmd.Flags().StringVar(&outputFile, "output", "", msg.FlagOutput)
+	cmd.Flags().IntVar(&expertsHalfLife, "half-life", ownership.DefaultHalfLife, msg.FlagHalfLife)
+	cmd.Flags().IntVar(&expertsLimit, "limit", 10, msg.FlagExpertsLimit)
+	_ = cmd.Flags().MarkHidden("author")
+}
+
+// runExperts 统计时间范围内所有作者对路径的提交和代码行，按时间衰减加权后排名
+func runExperts(path string) error {
+	msg := i18n.T()
+
+	// 进度信息输出到标准错误，避免混入排名
+	collected, err := collect(false, true, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	area := ownership.Experts(collected.Commits, path, ownershipOptions(collected, expertsHalfLife))
+	if area.Commits == 0 {
+		return gitError(fmt.Errorf(msg.ErrorNoCommitsForPath, path))
+	}
+	content := expertsMarkdown(collected, area)
+
+	switch {
+	case outputFile != "":
+		file, err := createOutput(outputFile)
+		if err != nil {
+			return outputError(fmt.Errorf(msg.ErrorCreateOutputFile, err))
+		}
+		_, err = file.WriteString(content)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return outputError(fmt.Errorf(msg.ErrorOutputFailed, err))
+		}
+		fmt.Fprintf(os.Stderr, msg.InfoReportSaved+"\n", outputFile)
+	case term.IsTerminal(os.Stdout):
+		if err := pageMarkdown(content); err != nil {
+			return outputError(fmt.Errorf(msg.ErrorOutputFailed, err))
+		}
+	default:
+		fmt.Print(content)
+	}
+	return nil
+}
+
+// expertsMarkdown 生成路径专家排名的 Markdown：总线系数、CODEOWNERS 和按熟悉程度排列的表
+func expertsMarkdown(collected *collection, area ownership.Area) string {
+	msg := i18n.T()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", fmt.Sprintf(msg.ReportTitleExperts, area.Path))
+	fmt.Fprintf(&b, "**%s**: %s %s %s\n\n", msg.ReportTimeRange, collected.From.Format("2006-01-02"), msg.ReportTo, collected.To.Format("2006-01-02"))
+	fmt.Fprintf(&b, "- **%s**: %d\n", msg.ReportTotalCommits, area.Commits)
+	fmt.Fprintf(&b, "- **%s**: %d\n", msg.ReportBusFactor, area.BusFactor)
+	if len(area.Declared) > 0 {
+		fmt.Fprintf(&b, "- **CODEOWNERS**: %s", strings.Join(area.Declared, ", "))
+		if area.Mismatch {
+			fmt.Fprintf(&b, " ⚠️ %s", msg.ReportCodeOwnersMismatch)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "\n| # | %s | %s | %s | %s | %s |\n|---:|---|---:|---:|---:|---|\n",
+		msg.ReportExpertName, msg.ReportFamiliarity, msg.ReportTotalCommits, msg.ReportExpertLines, msg.ReportLastCommit)
+	for i, owner := range area.Owners {
+		if expertsLimit > 0 && i >= expertsLimit {
+			break
+		}
+		fmt.Fprintf(&b, "| %d | %s | %s | %d (%s) | %d (%s) | %s |\n", i+1, owner.Name, percent(owner.Score),
+			owner.Commits, percent(owner.CommitShare), owner.Lines, percent(owner.LineShare), owner.Last.Format("2006-01-02"))
+	}
+	return b.String()
+}
+
+// percent 将 0 到 1 的比例格式化为百分比
+func percent(v float64) string {
+	return fmt.Sprintf("%d%%", int(math.Round(v*100)))
+}
+
+// ownershipOptions 返回计算代码归属的选项，权重按时间范围的结束时间衰减
+func ownershipOptions(collected *collection, halfLife int) ownership.Options {
+	return ownership.Options{Depth: ownership.DefaultDepth, HalfLife: halfLife, Now: collected.To, CodeOwners: collected.CodeOwners}
+}
+
+// knowledgeMap 返回开发者参与过的代码区域及各作者的熟悉程度
+func knowledgeMap(collected *collection) []ownership.Area {
+	var names []string
+	seen := make(map[string]bool)
+	for _, commit := range collected.Commits {
+		if !seen[commit.Author] {
+			seen[commit.Author] = true
+			names = append(names, commit.Author)
+		}
+	}
+
+	areas := ownership.Filter(ownership.Map(allAuthorCommits(collected), ownershipOptions(collected, ownership.DefaultHalfLife)), names...)
+	if len(areas) > maxKnowledgeAreas {
+		areas = areas[:maxKnowledgeAreas]
+	}
+	return areas
+}
+
+// allAuthorCommits 返回相同仓库和时间范围内所有作者的提交，已收集时直接返回
+func allAuthorCommits(collected *collection) []git.CommitInfo {
+	if collected.All != nil {
+		return collected.All
+	}
+
+	msg := i18n.T()
+	var all []git.CommitInfo
+	seen := make(map[string]bool)
+	for _, commit := range collected.Commits {
+		if seen[commit.RepoPath] {
+			continue
+		}
+		seen[commit.RepoPath] = true
+
+		gitOpts := git.NewGitOptions(commit.RepoPath)
+		gitOpts.Author = ""
+		commits, err := git.GetCommitsBetween(collected.From, collected.To, gitOpts)
+		if err != nil {
+			fmt.Printf(msg.ErrorRepoWarning+"\n", commit.RepoPath, err)
+			continue
+		}
+		for i := range commits {
+			commits[i].RepoPath = commit.RepoPath
+		}
+		all = append(all, commits...)
+	}
+	collected.All = all
+	return all
+}
@@ -1,17 +1,33 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/MyceliumGrid/git-work-profile/internal/ai"
+	"github.com/MyceliumGrid/git-work-profile/internal/cache"
+	"github.com/MyceliumGrid/git-work-profile/internal/cite"
+	"github.com/MyceliumGrid/git-work-profile/internal/cluster"
+	"github.com/MyceliumGrid/git-work-profile/internal/daterange"
+	"github.com/MyceliumGrid/git-work-profile/internal/export"
 	"github.com/MyceliumGrid/git-work-profile/internal/git"
 	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
 	"github.com/MyceliumGrid/git-work-profile/internal/interactive"
+	"github.com/MyceliumGrid/git-work-profile/internal/ownership"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+	"github.com/MyceliumGrid/git-work-profile/internal/render"
 	"github.com/MyceliumGrid/git-work-profile/internal/report"
+	"github.com/MyceliumGrid/git-work-profile/internal/rubric"
+	"github.com/MyceliumGrid/git-work-profile/internal/skillmatch"
+	"github.com/MyceliumGrid/git-work-profile/internal/term"
+	"github.com/MyceliumGrid/git-work-profile/internal/tickets"
+	"github.com/MyceliumGrid/git-work-profile/internal/verify"
 	"github.com/spf13/cobra"
 )
 
@@ -32,21 +48,57 @@ var (
 	reposPath    string // 仓库目录路径，分析该目录下的所有Git仓库
 	modelName    string // Gemini模型名称
 	authorName   string // Git作者名称
-	timeRange    string // 时间范围类型：3m(3个月)、6m(6个月)、1y(1年)、2y(2年)
+	timeRange    string // 时间范围表达式，如 6m、2025-Q3、last-month，见 daterange 包
 	analysisType string // 分析类型：profile(开发者画像)、experience(项目经验)、techstack(技术栈)
+	maxToolCalls int    // AI 最大工具调用次数，0 表示禁用
+	verifyClaims bool   // 是否核查AI分析中的声明
+	useEmbedding bool   // 聚类工作流时是否使用向量模型
+
+	issuePatterns []string // 自定义工单匹配规则，格式为 名称=正则表达式
+	issueURLs     []string // 工单链接模板，格式为 名称=链接
+
+	renderDir    string   // SVG图表的输出目录，为空表示不生成
+	renderThemes []string // SVG图表的主题
+
+	templatePath string // 自定义报告模板文件，为空表示使用内置模板
+	mergeResume  string // 合并到已有的 resume.json，为空表示直接输出
+	resumeStyle  string // LaTeX 和 DOCX 简历的样式
+	rubricPath   string // 绩效自评使用的能力模型文件
+
+	includeCommits bool // JSON 报告是否包含每个提交的详细数据
+
+	aggregateDir string // CSV 和 NDJSON 汇总表的输出目录，为空表示不生成
+
+	outDir           string // 输出目录，指定后按文件名模板为每种格式生成一个文件
+	fileNameTemplate string // 输出目录中的文件名模板，不含扩展名
+
+	noCache bool // 是否忽略缓存的AI分析结果
+
+	fiscalStart int // 财年开始的月份，用于 FY2025 等时间范围
 )
 
 // rootCmd 表示根命令
 var rootCmd = &cobra.Command{
-	Use: "git-work-profile",
-	Run: func(_ *cobra.Command, _ []string) {
-		// 如果没有指定任何参数，启动交互式模式
-		if !hasAnyFlags() {
-			runInteractiveMode()
-		} else {
-			// 执行生成报告的操作
-			generateReport()
+	Use:           "git-work-profile",
+	Args:          usageArgs(cobra.NoArgs),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		// 没有指定任何参数时启动交互式模式，配置文件中的设置作为默认选项，否则与 analyze 子命令相同
+		if explicitFlags == 0 {
+			return runInteractiveMode()
 		}
+		return runAnalyze(cmd)
+	},
+}
+
+// 使用AI分析提交记录并生成报告的子命令
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Analyze commits with AI and generate reports",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runAnalyze(cmd)
 	},
 }
 
@@ -56,12 +108,29 @@ func updateCommandDescriptions() {
 	rootCmd.Short = msg.CmdShortDesc
 	rootCmd.Long = msg.CmdLongDesc
 	versionCmd.Short = msg.CmdVersionShort
+	renderCmd.Short = msg.CmdRenderShort
+	templateCmd.Short = msg.CmdTemplateShort
+	schemaCmd.Short = msg.CmdSchemaShort
+	analyzeCmd.Short = msg.CmdAnalyzeShort
+	statsCmd.Short = msg.CmdStatsShort
+	scanCmd.Short = msg.CmdScanShort
+	promptsCmd.Short = msg.CmdPromptsShort
+	promptsShowCmd.Short = msg.CmdPromptsShowShort
+	cacheCmd.Short = msg.CmdCacheShort
+	cacheClearCmd.Short = msg.CmdCacheClearShort
+	configCmd.Short = msg.CmdConfigShort
+	configShowCmd.Short = msg.CmdConfigShowShort
+	matchCmd.Short = msg.CmdMatchShort
+	teamCmd.Short = msg.CmdTeamShort
+	expertsCmd.Short = msg.CmdExpertsShort
+	changelogCmd.Short = msg.CmdChangelogShort
 }
 
 // 版本子命令
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version information",
+	Args:  usageArgs(cobra.NoArgs),
 	Run: func(_ *cobra.Command, _ []string) {
 		msg := i18n.T()
 		fmt.Printf(msg.VersionInfo+"\n", version)
@@ -70,6 +139,56 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+// 生成SVG图表的子命令，不需要AI
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render SVG charts and badges",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(_ *cobra.Command, _ []string) error {
+		collected, err := collectCommits(false)
+		if err != nil {
+			return err
+		}
+		dir := renderDir
+		if dir == "" {
+			dir = "."
+		}
+		return renderCharts(collected, dir, os.Stdout)
+	},
+}
+
+// 输出 JSON 报告 schema 的子命令
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the json report format",
+	Args:  usageArgs(cobra.NoArgs),
+	Run: func(_ *cobra.Command, _ []string) {
+		fmt.Print(report.JSONSchema())
+	},
+}
+
+// 输出内置报告模板的子命令，可作为自定义模板的起点
+var templateCmd = &cobra.Command{
+	Use:   "template [format]",
+	Short: "Print the built-in report template",
+	Args:  usageArgs(cobra.MaximumNArgs(1)),
+	RunE: func(_ *cobra.Command, args []string) error {
+		format := outputFormat
+		if len(args) > 0 {
+			format = args[0]
+		}
+		if parsed, ok := report.ParseFormat(format); ok {
+			format = string(parsed)
+		}
+		content, err := report.DefaultTemplate(report.Format(format))
+		if err != nil {
+			return usageError(fmt.Errorf(i18n.T().ErrorTemplateNotFound, format))
+		}
+		fmt.Print(content)
+		return nil
+	},
+}
+
 func init() {
 	// 从环境变量加载语言设置
 	lang := i18n.LoadLanguageFromEnv()
@@ -78,149 +197,325 @@ func init() {
 	// 更新命令描述
 	updateCommandDescriptions()
 
-	// 添加版本子命令
+	// 添加子命令
+	rootCmd.AddCommand(analyzeCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(matchCmd)
+	rootCmd.AddCommand(teamCmd)
+	rootCmd.AddCommand(expertsCmd)
+	rootCmd.AddCommand(changelogCmd)
+	rootCmd.AddCommand(scanCmd)
+	rootCmd.AddCommand(renderCmd)
+	rootCmd.AddCommand(promptsCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(schemaCmd)
 	rootCmd.AddCommand(versionCmd)
+	promptsCmd.AddCommand(promptsShowCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	configCmd.AddCommand(configShowCmd)
+
+	// 参数解析错误作为命令行参数错误
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return usageError(err)
+	})
+
+	// 执行任何子命令前应用配置文件和环境变量中的设置
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+		return applyConfig(cmd)
+	}
 
-	// 获取多语言消息
+	// 不带子命令时与 analyze 相同，保留原有的用法；config 显示的是 analyze 的选项
+	addConfigFlags(rootCmd)
+	for _, cmd := range []*cobra.Command{rootCmd, analyzeCmd, configCmd, configShowCmd} {
+		addSourceFlags(cmd)
+		addReportFlags(cmd)
+		addRenderFlags(cmd)
+	}
+	addSourceFlags(matchCmd)
+	addMatchFlags(matchCmd)
+	addSourceFlags(teamCmd)
+	addTeamFlags(teamCmd)
+	addSourceFlags(expertsCmd)
+	addExpertsFlags(expertsCmd)
+	addChangelogFlags(changelogCmd)
+	addSourceFlags(statsCmd)
+	addStatsFlags(statsCmd)
+	addSourceFlags(renderCmd)
+	addRenderFlags(renderCmd)
+	addRepoFlags(scanCmd)
+	templateCmd.Flags().StringVar(&outputFormat, "format", "markdown", i18n.T().FlagFormat)
+}
+
+// addRepoFlags 添加选择仓库的参数
+func addRepoFlags(cmd *cobra.Command) {
 	msg := i18n.T()
+	cmd.Flags().StringVar(&repoPath, "repo", "", msg.FlagRepo)
+	cmd.Flags().StringVar(&reposPath, "repos", "", msg.FlagRepos)
+}
 
-	// 添加命令行参数
-	rootCmd.PersistentFlags().StringVar(&analysisType, "analysis", "profile", msg.FlagAnalysis)
-	rootCmd.PersistentFlags().StringVar(&fromDate, "from", "", msg.FlagFrom)
-	rootCmd.PersistentFlags().StringVar(&toDate, "to", "", msg.FlagTo)
-	rootCmd.PersistentFlags().StringVar(&timeRange, "range", "6m", msg.FlagRange)
-	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "markdown", msg.FlagFormat)
-	rootCmd.PersistentFlags().StringVar(&outputFile, "output", "", msg.FlagOutput)
-	rootCmd.PersistentFlags().StringVar(&repoPath, "repo", "", msg.FlagRepo)
-	rootCmd.PersistentFlags().StringVar(&reposPath, "repos", "", msg.FlagRepos)
-	rootCmd.PersistentFlags().StringVar(&modelName, "model", "", msg.FlagModel)
-	rootCmd.PersistentFlags().StringVar(&authorName, "author", "", msg.FlagAuthor)
+// addSourceFlags 添加选择仓库、作者和时间范围的参数
+func addSourceFlags(cmd *cobra.Command) {
+	msg := i18n.T()
+	addRepoFlags(cmd)
+	cmd.Flags().StringVar(&fromDate, "from", "", msg.FlagFrom)
+	cmd.Flags().StringVar(&toDate, "to", "", msg.FlagTo)
+	cmd.Flags().StringVar(&timeRange, "range", "6m", msg.FlagRange)
+	cmd.Flags().StringVar(&authorName, "author", "", msg.FlagAuthor)
+	cmd.Flags().IntVar(&fiscalStart, "fiscal-start", 1, msg.FlagFiscalStart)
+}
+
+// addOutputFlags 添加输出文件相关的参数
+func addOutputFlags(cmd *cobra.Command) {
+	msg := i18n.T()
+	cmd.Flags().StringVar(&outputFile, "output", "", msg.FlagOutput)
+	cmd.Flags().StringVar(&aggregateDir, "aggregate-dir", "", msg.FlagAggregateDir)
+	cmd.Flags().StringVar(&outDir, "out-dir", "", msg.FlagOutDir)
+	cmd.Flags().StringVar(&fileNameTemplate, "filename-template", report.DefaultFileNameTemplate, msg.FlagFileNameTemplate)
+}
+
+// addReportFlags 添加AI分析和报告相关的参数
+func addReportFlags(cmd *cobra.Command) {
+	msg := i18n.T()
+	addOutputFlags(cmd)
+	cmd.Flags().StringVar(&analysisType, "analysis", "profile", msg.FlagAnalysis)
+	cmd.Flags().StringVar(&outputFormat, "format", "markdown", msg.FlagFormat)
+	cmd.Flags().StringVar(&modelName, "model", "", msg.FlagModel)
+	cmd.Flags().BoolVar(&useEmbedding, "embeddings", false, msg.FlagEmbeddings)
+	cmd.Flags().BoolVar(&verifyClaims, "verify", true, msg.FlagVerify)
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, msg.FlagNoCache)
+	cmd.Flags().IntVar(&maxToolCalls, "max-tool-calls", ai.DefaultMaxToolCalls, msg.FlagMaxToolCalls)
+	cmd.Flags().StringArrayVar(&issuePatterns, "issue-pattern", nil, msg.FlagIssuePattern)
+	cmd.Flags().StringArrayVar(&issueURLs, "issue-url", nil, msg.FlagIssueURL)
+	cmd.Flags().StringVar(&templatePath, "template", "", msg.FlagTemplate)
+	cmd.Flags().StringVar(&mergeResume, "merge-resume", "", msg.FlagMergeResume)
+	cmd.Flags().StringVar(&resumeStyle, "resume-style", report.DefaultResumeStyle, msg.FlagResumeStyle)
+	cmd.Flags().BoolVar(&includeCommits, "include-commits", false, msg.FlagIncludeCommits)
+	cmd.Flags().StringVar(&rubricPath, "rubric", "", msg.FlagRubric)
+	addSurvivalFlags(cmd)
+}
+
+// addRenderFlags 添加SVG图表相关的参数
+func addRenderFlags(cmd *cobra.Command) {
+	msg := i18n.T()
+	cmd.Flags().StringVar(&renderDir, "render-dir", "", msg.FlagRenderDir)
+	cmd.Flags().StringSliceVar(&renderThemes, "render-theme", []string{render.Light.Name, render.Dark.Name}, msg.FlagRenderTheme)
 }
 
 func main() {
-	// 执行根命令
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-}
-
-// calculateTimeRange 根据时间范围类型计算开始和结束日期
-func calculateTimeRange(rangeType string) (time.Time, time.Time) {
-	now := time.Now()
-	var from, to time.Time
-
-	switch rangeType {
-	case "3m":
-		// 最近3个月
-		from = now.AddDate(0, -3, 0)
-		to = now
-	case "6m":
-		// 最近6个月
-		from = now.AddDate(0, -6, 0)
-		to = now
-	case "1y":
-		// 最近1年
-		from = now.AddDate(-1, 0, 0)
-		to = now
-	case "2y":
-		// 最近2年
-		from = now.AddDate(-2, 0, 0)
-		to = now
-	default:
-		// 默认使用最近6个月
-		from = now.AddDate(0, -6, 0)
-		to = now
+	// 执行命令，按错误类型设置退出码
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if exitCode(err) == exitUsage {
+			fmt.Fprintf(os.Stderr, i18n.T().HintUsage+"\n", cmd.CommandPath())
+		}
+		os.Exit(exitCode(err))
 	}
+}
 
-	return from, to
+// runAnalyze 按 --format 生成报告；只有 CSV 和 NDJSON 时直接导出提交记录，不需要AI
+func runAnalyze(cmd *cobra.Command) error {
+	formats, err := parseOutputFormats()
+	if err != nil {
+		return err
+	}
+	reportFormats, _ := splitFormats(formats)
+	if len(reportFormats) == 0 {
+		return exportCommits(formats)
+	}
+	// 只输出简历格式时默认使用项目经验分析
+	if allResumeFormats(reportFormats) && !cmd.Flags().Changed("analysis") {
+		analysisType = "experience"
+	}
+	// 周报和日报默认分析本周和今天
+	if !cmd.Flags().Changed("range") && fromDate == "" && toDate == "" {
+		if reportRange, ok := ai.DefaultTimeRanges[ai.PromptType(analysisType)]; ok {
+			timeRange = reportRange
+		}
+	}
+	return generateReport(formats)
 }
 
-// generateReport 生成分析报告（支持开发者画像、项目经验、技术栈等类型）
-func generateReport() {
+// inProgressBranches 返回提交所在的各仓库中，时间范围内有提交且尚未合并的分支，警告写到 progress
+func inProgressBranches(collected *collection, progress io.Writer) []git.Branch {
 	msg := i18n.T()
+	seen := make(map[string]bool)
+	var branches []git.Branch
+	for _, commit := range collected.Commits {
+		if seen[commit.RepoPath] {
+			continue
+		}
+		seen[commit.RepoPath] = true
 
-	// 检查环境变量
-	apiKey := os.Getenv("GEMINI_API_KEY")
-	if apiKey == "" {
-		fmt.Println(msg.ErrorAPIKeyNotSet)
-		os.Exit(1)
+		gitOpts := git.NewGitOptions(commit.RepoPath)
+		if authorName != "" {
+			gitOpts.Author = authorName
+		}
+		list, err := git.InProgressBranches(collected.From, gitOpts)
+		if err != nil {
+			fmt.Fprintf(progress, msg.ErrorRepoWarning+"\n", commit.RepoPath, err)
+			continue
+		}
+		branches = append(branches, list...)
 	}
+	return branches
+}
 
-	// 创建Gemini客户端
-	geminiClient, err := ai.NewGeminiClientWithModel(modelName)
-	if err != nil {
-		fmt.Printf(msg.ErrorCreateClient+"\n", err)
-		os.Exit(1)
+// newTicketExtractor 根据命令行参数创建工单引用提取器
+func newTicketExtractor() (*tickets.Extractor, error) {
+	var patterns []tickets.Pattern
+	for _, spec := range issuePatterns {
+		pattern, err := tickets.ParsePattern(spec)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
 	}
 
-	// 判断使用何种时间范围
-	var from, to time.Time
-	var err1, err2 error
+	urlTemplates := make(map[string]string)
+	for _, spec := range issueURLs {
+		name, tmpl, ok := strings.Cut(spec, "=")
+		if !ok || name == "" || tmpl == "" {
+			return nil, fmt.Errorf("invalid issue url %q, expected name=url", spec)
+		}
+		urlTemplates[strings.TrimSpace(name)] = strings.TrimSpace(tmpl)
+	}
 
-	switch {
-	case fromDate != "" && toDate != "":
-		// 使用自定义时间范围
-		from, err1 = time.Parse("2006-01-02", fromDate)
-		to, err2 = time.Parse("2006-01-02", toDate)
-		if err1 != nil || err2 != nil {
-			fmt.Println(msg.ErrorDateFormat)
-			os.Exit(1)
-		}
-		to = to.Add(24*time.Hour - time.Second)
-		fmt.Printf(msg.InfoCustomTimeRange+"\n", fromDate, toDate)
-	default:
-		// 使用预定义的时间范围
-		from, to = calculateTimeRange(timeRange)
-		fmt.Printf(msg.InfoTimeRange+"\n", timeRange, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	return tickets.NewExtractor(patterns, urlTemplates), nil
+}
+
+// parseTimeRange 根据 --from/--to 或 --range 计算时间范围，以及显示的说明
+func parseTimeRange() (daterange.Range, error) {
+	msg := i18n.T()
+	if fiscalStart < 1 || fiscalStart > 12 {
+		return daterange.Range{}, usageError(fmt.Errorf(msg.ErrorFiscalStart, fiscalStart))
 	}
+	opts := daterange.Options{FiscalStart: time.Month(fiscalStart)}
 
-	// 判断使用何种分析模式：单仓库还是多仓库
-	var repoPaths []string
-	var discoveryErr error
+	var rng daterange.Range
+	var err error
+	if fromDate != "" || toDate != "" {
+		rng, err = daterange.Between(fromDate, toDate, opts)
+	} else {
+		rng, err = daterange.Parse(timeRange, opts)
+	}
+	if err != nil {
+		return daterange.Range{}, usageError(fmt.Errorf(msg.ErrorInvalidTimeRange, err, daterange.Examples))
+	}
+	return rng, nil
+}
+
+// formatRangeStart 格式化时间范围的开始日期，不限开始时间时显示为最早
+func formatRangeStart(from time.Time) string {
+	if from.IsZero() {
+		return i18n.T().LabelRangeStart
+	}
+	return from.Format(daterange.DayLayout)
+}
+
+// collection 收集到的提交记录及相关数据
+type collection struct {
+	From       time.Time
+	To         time.Time
+	Commits    []git.CommitInfo
+	Manifests  map[string]map[string]string    // 仓库路径到依赖清单的映射
+	Remotes    map[string]string               // 仓库路径到远程仓库网页地址的映射
+	CodeOwners map[string]ownership.CodeOwners // 仓库路径到 CODEOWNERS 规则的映射
+	All        []git.CommitInfo                // 所有作者的提交，用于计算代码归属，为空时按需收集
+}
+
+// findRepos 根据命令行参数返回要分析的仓库：--repos 目录下的所有仓库、--repo 指定的仓库或当前目录，
+// 扫描进度写到 progress
+func findRepos(progress io.Writer) ([]string, error) {
+	msg := i18n.T()
 
 	switch {
 	case reposPath != "":
 		// 多仓库模式：发现指定目录下的所有Git仓库
-		repoPaths, discoveryErr = git.DiscoverGitRepos(reposPath)
-		if discoveryErr != nil {
-			fmt.Printf(msg.ErrorDiscoverRepos+"\n", discoveryErr)
-			return
+		repoPaths, err := git.DiscoverGitRepos(reposPath, progress)
+		if err != nil {
+			return nil, gitError(fmt.Errorf(msg.ErrorDiscoverRepos, err))
 		}
-
 		if len(repoPaths) == 0 {
-			fmt.Printf(msg.ErrorNoReposFound+"\n", reposPath)
-			return
+			return nil, gitError(fmt.Errorf(msg.ErrorNoReposFound, reposPath))
 		}
+		return repoPaths, nil
 	case repoPath != "":
 		// 单仓库模式：使用指定的仓库路径
-		repoPaths = []string{repoPath}
+		return []string{repoPath}, nil
 	default:
 		// 默认模式：使用当前目录
-		repoPaths = []string{"."}
+		return []string{"."}, nil
+	}
+}
+
+// displayRepoPath 返回仓库的显示路径，多仓库模式下为相对于 --repos 的路径
+func displayRepoPath(path string) string {
+	if reposPath != "" {
+		if rel, err := filepath.Rel(reposPath, path); err == nil {
+			return rel
+		}
+	}
+	return path
+}
+
+// collectCommits 根据命令行参数确定时间范围并收集所有仓库的提交记录，进度输出到标准输出
+func collectCommits(withManifests bool) (*collection, error) {
+	return collect(withManifests, false, os.Stdout)
+}
+
+// collect 收集时间范围内的提交记录，allAuthors 为 true 时忽略 --author，收集所有作者的提交。
+// 进度信息写到 progress，结果输出到标准输出的命令传入标准错误，避免混入结果
+func collect(withManifests, allAuthors bool, progress io.Writer) (*collection, error) {
+	msg := i18n.T()
+
+	// 解析时间范围，--from/--to 优先于 --range
+	rng, err := parseTimeRange()
+	if err != nil {
+		return nil, err
+	}
+	from, to := rng.From, rng.To
+	if fromDate != "" || toDate != "" {
+		fmt.Fprintf(progress, msg.InfoCustomTimeRange+"\n", formatRangeStart(from), to.Format(daterange.DayLayout))
+	} else {
+		fmt.Fprintf(progress, msg.InfoTimeRange+"\n", timeRange, formatRangeStart(from), to.Format(daterange.DayLayout))
+	}
+
+	// 判断使用何种分析模式：单仓库还是多仓库
+	repoPaths, err := findRepos(progress)
+	if err != nil {
+		return nil, err
 	}
 
 	// 收集所有仓库的提交记录
 	var allCommits []git.CommitInfo
 	repoCommitCounts := make(map[string]int)
+	manifests := make(map[string]map[string]string)
+	remotes := make(map[string]string)
+	codeOwners := make(map[string]ownership.CodeOwners)
 
-	fmt.Printf(msg.InfoProcessingRepos+"\n", len(repoPaths))
+	fmt.Fprintf(progress, msg.InfoProcessingRepos+"\n", len(repoPaths))
 
 	for _, currentRepoPath := range repoPaths {
-		fmt.Printf(msg.InfoAnalyzingRepo+"\n", currentRepoPath)
+		fmt.Fprintf(progress, msg.InfoAnalyzingRepo+"\n", currentRepoPath)
 
 		// 创建Git选项
 		gitOpts := git.NewGitOptions(currentRepoPath)
 
 		// 如果命令行指定了作者名称，覆盖自动检测的用户名
-		if authorName != "" {
+		switch {
+		case allAuthors:
+			gitOpts.Author = ""
+		case authorName != "":
 			gitOpts.Author = authorName
 		}
 
 		// 获取提交记录
 		commits, commitErr := git.GetCommitsBetween(from, to, gitOpts)
 		if commitErr != nil {
-			fmt.Printf(msg.ErrorRepoWarning+"\n", currentRepoPath, commitErr)
+			fmt.Fprintf(progress, msg.ErrorRepoWarning+"\n", currentRepoPath, commitErr)
 			continue
 		}
 
@@ -235,115 +530,597 @@ func generateReport() {
 		// 合并到总的提交列表
 		allCommits = append(allCommits, commits...)
 
-		fmt.Printf(msg.InfoFoundCommits+"\n", len(commits))
+		// 读取依赖清单，用于核查AI分析中的技术声明
+		if withManifests && len(commits) > 0 {
+			manifests[currentRepoPath] = git.ReadManifests(gitOpts)
+		}
+
+		// 读取 CODEOWNERS，用于比较代码归属
+		if len(commits) > 0 {
+			if text := git.ReadCodeOwners(gitOpts); text != "" {
+				codeOwners[currentRepoPath] = ownership.ParseCodeOwners(text)
+			}
+		}
+
+		// 远程仓库地址，用于生成引用提交的链接
+		if remote := git.RemoteWebURL(gitOpts); remote != "" {
+			remotes[currentRepoPath] = remote
+		}
+
+		fmt.Fprintf(progress, msg.InfoFoundCommits+"\n", len(commits))
 	}
 
 	// 显示汇总统计信息
-	fmt.Println(msg.InfoCommitStats)
+	fmt.Fprintln(progress, msg.InfoCommitStats)
 	totalCommits := 0
 	for repoPath, count := range repoCommitCounts {
 		// 显示相对路径，更清晰
-		displayPath := repoPath
-		if reposPath != "" {
-			if rel, err := filepath.Rel(reposPath, repoPath); err == nil {
-				displayPath = rel
-			}
-		}
-		fmt.Printf("  %s: %d\n", displayPath, count)
+		fmt.Fprintf(progress, "  %s: %d\n", displayRepoPath(repoPath), count)
 		totalCommits += count
 	}
-	fmt.Printf(msg.InfoTotalCommits, totalCommits)
+	fmt.Fprintf(progress, msg.InfoTotalCommits, totalCommits)
 
 	if len(allCommits) == 0 {
-		fmt.Printf(msg.ErrorNoCommitsFound+"\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
-		return
+		return nil, gitError(fmt.Errorf(msg.ErrorNoCommitsFound, formatRangeStart(from), to.Format(daterange.DayLayout)))
+	}
+
+	// 不限开始时间时，从最早的提交所在的那天开始
+	if rng.OpenStart() {
+		from = to
+		for _, commit := range allCommits {
+			if commit.Date.Before(from) {
+				from = commit.Date
+			}
+		}
+		from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
 	}
 
 	// 显示作者信息
-	if authorName != "" {
-		fmt.Printf(msg.InfoFilterAuthor+"\n", authorName)
+	if authorName != "" && !allAuthors {
+		fmt.Fprintf(progress, msg.InfoFilterAuthor+"\n", authorName)
 	} else {
-		fmt.Println(msg.InfoAllAuthors)
+		fmt.Fprintln(progress, msg.InfoAllAuthors)
+	}
+
+	collected := &collection{From: from, To: to, Commits: allCommits, Manifests: manifests, Remotes: remotes, CodeOwners: codeOwners}
+	if allAuthors {
+		collected.All = allCommits
+	}
+	return collected, nil
+}
+
+// generateReport 生成分析报告（支持开发者画像、项目经验、技术栈等类型），
+// 所有格式共用一次提交收集和一次AI分析
+func generateReport(formats []string) error {
+	return generateReportFor(formats, func(withManifests bool, progress io.Writer) (*collection, error) {
+		return collect(withManifests, false, progress)
+	})
+}
+
+// generateReportFor 使用 collect 收集的提交生成分析报告，团队模式据此为每个成员复用已收集的提交。
+// 报告输出到标准输出时，进度信息输出到标准错误
+func generateReportFor(formats []string, collect func(withManifests bool, progress io.Writer) (*collection, error)) error {
+	msg := i18n.T()
+	reportFormats, exportFormats := splitFormats(formats)
+	single := len(formats) == 1
+	progress := progressWriter(single)
+
+	// 参数检查在创建客户端之前完成，用法错误不受 API 密钥影响
+	if err := checkAnalysisType(analysisType); err != nil {
+		return err
+	}
+
+	if _, err := report.ResumeStyleByName(resumeStyle); err != nil {
+		return usageError(fmt.Errorf(msg.ErrorResumeStyle, err))
+	}
+
+	// 同一个自定义模板无法用于多种格式
+	if templatePath != "" && len(reportFormats) > 1 {
+		return usageError(errors.New(msg.ErrorTemplateMultipleFormats))
+	}
+
+	// JSON Resume 由项目经验分析生成，只输出这一种格式时合并结果默认写回原文件
+	var resumeBase []byte
+	if containsFormat(reportFormats, report.FormatJSONResume) && mergeResume != "" {
+		var err error
+		resumeBase, err = os.ReadFile(mergeResume)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf(msg.ErrorReadResume, err)
+		}
+		if single && outDir == "" && outputFile == "" {
+			outputFile = mergeResume
+		}
 	}
 
-	fmt.Println(msg.InfoAIAnalyzing)
+	// 技能匹配需要由 match 子命令读取目标描述
+	if ai.GetPromptTypeFromString(analysisType) == ai.SkillMatchPrompt && matchTarget == "" {
+		return usageError(errors.New(msg.ErrorMatchTargetRequired))
+	}
 
-	// 重用之前创建的客户端变量
-	if geminiClient == nil {
-		geminiClient, err = ai.NewGeminiClientWithModel(modelName)
+	// 发布说明按版本范围而不是时间范围收集提交，由 changelog 子命令生成
+	if ai.GetPromptTypeFromString(analysisType) == ai.ReleaseNotesPrompt {
+		return usageError(errors.New(msg.ErrorReleaseAnalysis))
+	}
+
+	// 绩效自评需要能力模型文件
+	var competencies *rubric.Rubric
+	if ai.GetPromptTypeFromString(analysisType) == ai.SelfReviewPrompt {
+		if rubricPath == "" {
+			return usageError(errors.New(msg.ErrorRubricRequired))
+		}
+		var err error
+		competencies, err = rubric.Load(rubricPath)
 		if err != nil {
-			fmt.Printf(msg.ErrorCreateClient+"\n", err)
-			return
+			return usageError(fmt.Errorf(msg.ErrorRubricLoad, err))
 		}
 	}
+
+	// 解析工单匹配规则
+	ticketExtractor, err := newTicketExtractor()
+	if err != nil {
+		return usageError(fmt.Errorf(msg.ErrorInvalidIssueFlag, err))
+	}
+
+	// 检查环境变量
+	apiKey := os.Getenv("GEMINI_API_KEY")
+	if apiKey == "" {
+		return aiError(errors.New(msg.ErrorAPIKeyNotSet))
+	}
+
+	// 创建Gemini客户端
+	geminiClient, err := ai.NewGeminiClientWithModel(modelName)
+	if err != nil {
+		return aiError(fmt.Errorf(msg.ErrorCreateClient, err))
+	}
 	defer geminiClient.Close()
 
+	// 收集提交记录
+	collected, err := collect(verifyClaims, progress)
+	if err != nil {
+		return err
+	}
+	from, to, allCommits := collected.From, collected.To, collected.Commits
+
+	// 将提交聚类为工作流，作为项目划分的依据
+	fmt.Fprintln(progress, msg.InfoClusteringCommits)
+	clusterOpts := cluster.DefaultOptions()
+	if useEmbedding {
+		embeddings, err := geminiClient.EmbedCommits(allCommits)
+		if err != nil {
+			fmt.Fprintf(progress, msg.WarningEmbeddingFailed+"\n", err)
+		} else {
+			clusterOpts.Embeddings = embeddings
+		}
+	}
+	workstreams := cluster.Cluster(allCommits, clusterOpts)
+	fmt.Fprintf(progress, msg.InfoFoundWorkstreams+"\n", len(workstreams))
+
+	// 提取提交中引用的工单，#N 形式的 issue 链接到所属仓库
+	ticketExtractor.SetRemotes(collected.Remotes)
+	ticketGroups := tickets.Group(allCommits, ticketExtractor)
+	fmt.Fprintf(progress, msg.InfoFoundTickets+"\n", len(ticketGroups))
+
+	fmt.Fprintln(progress, msg.InfoAIAnalyzing)
+
+	// 周报和日报需要列出进行中的分支
+	var branches []git.Branch
+	if aiPromptType := ai.GetPromptTypeFromString(analysisType); aiPromptType == ai.WeeklyReportPrompt || aiPromptType == ai.DailyReportPrompt {
+		branches = inProgressBranches(collected, progress)
+		fmt.Fprintf(progress, msg.InfoFoundInProgressBranches+"\n", len(branches))
+	}
+
+	// 绩效自评按能力项查找本地证据
+	var evidence []rubric.Evidence
+	if competencies != nil {
+		evidence = rubric.Match(competencies, allCommits, workstreams)
+		weak := 0
+		for _, item := range evidence {
+			if item.Weak() {
+				weak++
+			}
+		}
+		fmt.Fprintf(progress, msg.InfoRubricEvidence+"\n", len(evidence), weak)
+	}
+
+	// 技能匹配根据技术栈、专业领域和工作流为目标所需的技能查找证据
+	var skillResult *skillmatch.Result
+	if matchTarget != "" {
+		dev := profile.AnalyzeProfile(allCommits, from, to, authorName)
+		required := skillmatch.Extract(matchTarget)
+		if len(required) == 0 {
+			fmt.Fprintln(progress, msg.WarningNoSkillsFound)
+		}
+		result := skillmatch.Score(required, dev, allCommits, workstreams)
+		skillResult = &result
+		fmt.Fprintf(progress, msg.InfoSkillMatch+"\n", result.Score, len(result.Strengths), len(required))
+	}
+
+	// 开发者画像附带知识地图：参与过的代码区域中各作者的熟悉程度
+	var knowledge []ownership.Area
+	if ai.GetPromptTypeFromString(analysisType) == ai.DeveloperProfilePrompt {
+		knowledge = knowledgeMap(collected)
+		fmt.Fprintf(progress, msg.InfoKnowledgeAreas+"\n", len(knowledge))
+	}
+
+	// 开发者画像和绩效自评附带代码存活统计，作为比提交数更有意义的影响指标
+	var survival *profile.Survival
+	if promptType := ai.GetPromptTypeFromString(analysisType); promptType == ai.DeveloperProfilePrompt || promptType == ai.SelfReviewPrompt {
+		survival = codeSurvival(collected, progress)
+	}
+
+	// 本地分析结果作为提示词的补充信息
+	geminiClient.SetPromptContext(ai.PromptContext{
+		Workstreams: workstreams,
+		Tickets:     ticketGroups,
+		Branches:    branches,
+		Rubric:      competencies,
+		Evidence:    evidence,
+		Target:      matchTarget,
+		SkillMatch:  skillResult,
+		Survival:    survival,
+	})
+
+	// 允许AI按需查看提交详情和代码差异
+	geminiClient.EnableTools(ai.ToolOptions{MaxCalls: maxToolCalls, MaxOutput: ai.DefaultMaxToolOutput, Progress: progress})
+
+	// 相同的输入复用之前的分析结果
+	if !noCache {
+		if dir, err := cache.DefaultDir(); err == nil {
+			geminiClient.SetCache(cache.New(dir))
+		}
+	}
+
 	// 根据分析类型确定使用哪种提示词
 	aiPromptType := ai.GetPromptTypeFromString(analysisType)
 
 	switch aiPromptType {
+	case ai.WeeklyReportPrompt:
+		fmt.Fprintln(progress, msg.LabelAnalysisTypeWeekly)
+	case ai.DailyReportPrompt:
+		fmt.Fprintln(progress, msg.LabelAnalysisTypeDaily)
+	case ai.SelfReviewPrompt:
+		fmt.Fprintln(progress, msg.LabelAnalysisTypeReview)
+	case ai.SkillMatchPrompt:
+		fmt.Fprintln(progress, msg.LabelAnalysisTypeMatch)
 	case ai.DeveloperProfilePrompt:
-		fmt.Println(msg.LabelAnalysisTypeProfile)
+		fmt.Fprintln(progress, msg.LabelAnalysisTypeProfile)
 	case ai.ProjectExperiencePrompt:
-		fmt.Println(msg.LabelAnalysisTypeExperience)
+		fmt.Fprintln(progress, msg.LabelAnalysisTypeExperience)
 	case ai.TechStackPrompt:
-		fmt.Println(msg.LabelAnalysisTypeTechStack)
+		fmt.Fprintln(progress, msg.LabelAnalysisTypeTechStack)
 	default:
-		fmt.Println(msg.LabelAnalysisTypeDefault)
+		fmt.Fprintln(progress, msg.LabelAnalysisTypeDefault)
 	}
 
 	// 使用AI生成分析报告
 	analysisResult, err := geminiClient.SummarizeCommitsWithPrompt(allCommits, aiPromptType)
 	if err != nil {
-		fmt.Printf(msg.ErrorAIAnalysisFailed+"\n", err)
-		return
+		return aiError(fmt.Errorf(msg.ErrorAIAnalysisFailed, err))
+	}
+
+	// 校验AI引用的提交哈希，删除找不到的引用
+	citations := cite.Resolve(analysisResult, allCommits, collected.Remotes)
+	analysisResult = cite.Clean(analysisResult, citations)
+	if len(citations.Invalid) > 0 {
+		fmt.Fprintf(progress, msg.WarningInvalidCitations+"\n", strings.Join(citations.Invalid, ", "))
+	}
+
+	// 核查AI分析中的声明，引用的哈希不参与核查
+	var verification *verify.Result
+	if verifyClaims {
+		fmt.Fprintln(progress, msg.InfoVerifyingClaims)
+		verification = verify.Verify(cite.Mask(analysisResult), verify.Evidence{Commits: allCommits, Manifests: collected.Manifests})
+		analysisResult = verify.Annotate(analysisResult, verification, msg.ReportUnverifiedMarker)
+	}
+
+	// 依次输出每种格式
+	output := &analysisOutput{
+		Collected:    collected,
+		Analysis:     analysisResult,
+		Verification: verification,
+		Citations:    citations,
+		Workstreams:  workstreams,
+		Tickets:      ticketGroups,
+		Knowledge:    knowledge,
+		Survival:     survival,
+		ResumeBase:   resumeBase,
+	}
+	if err := writeReports(reportFormats, single, output, os.Stdout, progress); err != nil {
+		return err
+	}
+
+	// 同时导出 CSV 和 NDJSON
+	if err := writeExports(collected, exportFormats, single, os.Stdout, progress); err != nil {
+		return err
 	}
 
-	// 决定输出目标
-	var output io.Writer = os.Stdout
-	if outputFile != "" {
-		file, err := os.Create(outputFile)
+	fmt.Fprintln(progress, msg.InfoAnalysisComplete)
+
+	// 同时生成SVG图表
+	if renderDir != "" {
+		return renderCharts(collected, renderDir, progress)
+	}
+	return nil
+}
+
+// analysisOutput AI分析结果及生成报告所需的本地分析结果
+type analysisOutput struct {
+	Collected    *collection
+	Analysis     string
+	Verification *verify.Result
+	Citations    *cite.Result
+	Workstreams  []cluster.Workstream
+	Tickets      []tickets.Ticket
+	Knowledge    []ownership.Area
+	Survival     *profile.Survival
+	ResumeBase   []byte
+}
+
+// writeReports 以每种报告格式输出分析结果，未指定文件时写到 stdout，进度信息写到 progress
+func writeReports(formats []report.Format, single bool, out *analysisOutput, stdout, progress io.Writer) error {
+	msg := i18n.T()
+	for _, format := range formats {
+		path := outputPath(single, fileNameFields(out.Collected, analysisType, string(format)), format.Extension())
+		output := stdout
+		var file *os.File
+		var terminal *bytes.Buffer
+		switch {
+		case path != "":
+			var err error
+			file, err = createOutput(path)
+			if err != nil {
+				return outputError(fmt.Errorf(msg.ErrorCreateOutputFile, err))
+			}
+			output = file
+		case (format == report.FormatMarkdown || format == report.FormatText) && isTerminal(stdout):
+			// 输出到终端时先生成完整的报告，再渲染样式
+			terminal = &bytes.Buffer{}
+			output = terminal
+		}
+
+		// 创建报告生成器
+		reportGenerator := report.NewGenerator(format, output)
+		reportGenerator.Verification = out.Verification
+		reportGenerator.Citations = out.Citations
+		reportGenerator.Workstreams = out.Workstreams
+		reportGenerator.Tickets = out.Tickets
+		reportGenerator.Knowledge = out.Knowledge
+		reportGenerator.Survival = out.Survival
+		reportGenerator.Template = templatePath
+		reportGenerator.Author = authorName
+		reportGenerator.Version = version
+		reportGenerator.ResumeBase = out.ResumeBase
+		reportGenerator.ResumeStyle = resumeStyle
+		reportGenerator.IncludeCommits = includeCommits
+		reportGenerator.Model = modelName
+		if reportGenerator.Model == "" {
+			reportGenerator.Model = ai.DefaultModelName
+		}
+
+		// 生成并输出报告
+		err := reportGenerator.GenerateProfileReport(out.Analysis, out.Collected.Commits, out.Collected.From, out.Collected.To, analysisType)
+		if file != nil {
+			file.Close()
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, msg.ErrorCreateOutputFile+"\n", err)
-			return
+			return outputError(fmt.Errorf(msg.ErrorOutputFailed, err))
+		}
+		if terminal != nil {
+			if err := pageMarkdown(terminal.String()); err != nil {
+				return outputError(fmt.Errorf(msg.ErrorOutputFailed, err))
+			}
+		}
+		if path != "" {
+			fmt.Fprintf(progress, msg.InfoReportSaved+"\n", path)
 		}
-		defer file.Close()
-		output = file
 	}
+	return nil
+}
 
-	// 创建报告生成器
-	reportFormat := report.Format(outputFormat)
-	reportGenerator := report.NewGenerator(reportFormat, output)
+// isTerminal 判断输出是否为终端
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	return ok && term.IsTerminal(file)
+}
+
+// pageMarkdown 将 Markdown 渲染为终端样式并分页显示
+func pageMarkdown(markdown string) error {
+	width, _ := term.Size(os.Stdout)
+	rendered := term.Render(markdown, term.Options{Width: width, Color: term.ColorEnabled()})
+	return term.Page(rendered, os.Stdout)
+}
+
+// exportCommits 将收集的提交记录导出为 CSV 或 NDJSON，并按需写出汇总表
+func exportCommits(formats []string) error {
+	single := len(formats) == 1
+
+	// 导出到标准输出时，进度信息输出到标准错误，避免混入数据
+	progress := progressWriter(single)
 
-	// 生成并输出报告
-	err = reportGenerator.GenerateProfileReport(analysisResult, allCommits, from, to, analysisType)
+	collected, err := collect(false, false, progress)
 	if err != nil {
-		fmt.Printf(msg.ErrorOutputFailed+"\n", err)
-		return
+		return err
+	}
+	if err := writeExports(collected, formats, single, os.Stdout, progress); err != nil {
+		return err
+	}
+
+	if renderDir != "" {
+		return renderCharts(collected, renderDir, progress)
+	}
+	return nil
+}
+
+// writeExports 以每种导出格式写出提交记录和汇总表，未指定文件时写到 stdout，进度信息写到 progress
+func writeExports(collected *collection, formats []string, single bool, stdout, progress io.Writer) error {
+	msg := i18n.T()
+	commits := export.Commits(collected.Commits)
+
+	for _, format := range formats {
+		path := outputPath(single, fileNameFields(collected, "commits", format), format)
+		output := stdout
+		var file *os.File
+		if path != "" {
+			var err error
+			file, err = createOutput(path)
+			if err != nil {
+				return outputError(fmt.Errorf(msg.ErrorCreateOutputFile, err))
+			}
+			output = file
+		}
+
+		err := export.Write(output, commits, format)
+		if file != nil {
+			file.Close()
+		}
+		if err != nil {
+			return outputError(fmt.Errorf(msg.ErrorExportFailed, err))
+		}
+		fmt.Fprintf(progress, msg.InfoExportedCommits+"\n", len(collected.Commits))
+		if path != "" {
+			fmt.Fprintf(progress, msg.InfoReportSaved+"\n", path)
+		}
+
+		if aggregateDir != "" {
+			paths, err := export.WriteFiles(aggregateDir, export.Aggregates(collected.Commits), format)
+			if err != nil {
+				return outputError(fmt.Errorf(msg.ErrorExportFailed, err))
+			}
+			fmt.Fprintf(progress, msg.InfoAggregatesWritten+"\n", aggregateDir, len(paths))
+		}
+	}
+	return nil
+}
+
+// parseOutputFormats 解析 --format 中逗号分隔的格式列表并去除重复项
+func parseOutputFormats() ([]string, error) {
+	msg := i18n.T()
+
+	var formats []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(outputFormat, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if format, ok := report.ParseFormat(name); ok {
+			name = string(format)
+		} else if !export.IsFormat(name) {
+			return nil, usageError(fmt.Errorf(msg.ErrorUnknownFormat, name))
+		}
+		if !seen[name] {
+			seen[name] = true
+			formats = append(formats, name)
+		}
+	}
+
+	if len(formats) == 0 {
+		return nil, usageError(fmt.Errorf(msg.ErrorUnknownFormat, outputFormat))
+	}
+	if len(formats) > 1 && outputFile != "" {
+		return nil, usageError(errors.New(msg.ErrorOutputMultipleFormats))
+	}
+	return formats, nil
+}
+
+// splitFormats 将格式列表分为报告格式和导出格式
+func splitFormats(formats []string) ([]report.Format, []string) {
+	var reports []report.Format
+	var exports []string
+	for _, format := range formats {
+		if export.IsFormat(format) {
+			exports = append(exports, format)
+		} else {
+			reports = append(reports, report.Format(format))
+		}
+	}
+	return reports, exports
+}
+
+// allResumeFormats 判断是否只输出简历格式
+func allResumeFormats(formats []report.Format) bool {
+	for _, format := range formats {
+		if !format.IsResume() {
+			return false
+		}
+	}
+	return len(formats) > 0
+}
+
+// containsFormat 判断格式列表中是否包含指定格式
+func containsFormat(formats []report.Format, target report.Format) bool {
+	for _, format := range formats {
+		if format == target {
+			return true
+		}
+	}
+	return false
+}
+
+// outputPath 返回输出文件的路径，为空表示输出到标准输出。
+// 只输出一种格式且未指定 --out-dir 时使用 --output，否则按文件名模板在输出目录中生成
+func outputPath(single bool, fields report.FileNameFields, ext string) string {
+	if single && outDir == "" {
+		return outputFile
+	}
+	dir := outDir
+	if dir == "" {
+		dir = "."
+	}
+	return filepath.Join(dir, report.FileName(fileNameTemplate, fields)+"."+ext)
+}
+
+// progressWriter 返回进度信息的输出：结果写到标准输出时使用标准错误，避免进度信息混入报告或导出数据
+func progressWriter(single bool) io.Writer {
+	if outputPath(single, report.FileNameFields{}, "") == "" {
+		return os.Stderr
 	}
+	return os.Stdout
+}
+
+// fileNameFields 返回文件名模板使用的字段
+func fileNameFields(collected *collection, analysis, format string) report.FileNameFields {
+	return report.FileNameFields{
+		Author:   authorName,
+		Analysis: analysis,
+		Format:   format,
+		From:     collected.From,
+		To:       collected.To,
+	}
+}
 
-	fmt.Println(msg.InfoAnalysisComplete)
-	if outputFile != "" {
-		fmt.Printf(msg.InfoReportSaved+"\n", outputFile)
+// createOutput 创建输出文件，文件名模板中包含目录时一并创建
+func createOutput(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
 	}
+	return os.Create(path)
 }
 
-// hasAnyFlags 检查是否指定了任何参数
-func hasAnyFlags() bool {
-	return fromDate != "" ||
-		toDate != "" ||
-		timeRange != "6m" ||
-		analysisType != "profile" ||
-		outputFormat != "markdown" ||
-		outputFile != "" ||
-		repoPath != "" ||
-		reposPath != "" ||
-		authorName != "" ||
-		modelName != ""
+// renderCharts 根据收集的提交生成SVG图表和徽章，完成信息写到 progress
+func renderCharts(collected *collection, dir string, progress io.Writer) error {
+	msg := i18n.T()
+
+	var themes []render.Theme
+	for _, name := range renderThemes {
+		theme, err := render.ThemeByName(name)
+		if err != nil {
+			return usageError(fmt.Errorf(msg.ErrorRenderFailed, err))
+		}
+		themes = append(themes, theme)
+	}
+
+	files := render.Render(collected.Commits, collected.From, collected.To, themes)
+	if err := render.WriteFiles(dir, files); err != nil {
+		return outputError(fmt.Errorf(msg.ErrorRenderFailed, err))
+	}
+
+	fmt.Fprintf(progress, msg.InfoRenderedCharts+"\n", dir, len(files))
+	return nil
 }
 
 // runInteractiveMode 运行交互式模式
-func runInteractiveMode() {
+func runInteractiveMode() error {
 	fmt.Println()
 
 	// 检查API密钥
@@ -353,8 +1130,7 @@ func runInteractiveMode() {
 		var err error
 		apiKey, err = interactive.PromptForAPIKey()
 		if err != nil {
-			fmt.Printf("%s: %v\n", msg.ErrorCancelled, err)
-			os.Exit(1)
+			return fmt.Errorf("%s: %w", msg.ErrorCancelled, err)
 		}
 		// 设置环境变量供后续使用
 		os.Setenv("GEMINI_API_KEY", apiKey)
@@ -367,18 +1143,35 @@ func runInteractiveMode() {
 		}
 	}
 
-	// 运行交互式配置
-	config, err := interactive.RunInteractive()
+	// 运行交互式配置，配置文件中的设置作为默认选项
+	defaults := interactive.Config{
+		AnalysisType: analysisType,
+		TimeRange:    timeRange,
+		OutputFormat: outputFormat,
+		OutputFile:   outputFile,
+		AuthorName:   authorName,
+		RubricPath:   rubricPath,
+	}
+	if fromDate != "" || toDate != "" {
+		defaults.TimeRange = "custom"
+		defaults.CustomFromDate = fromDate
+		defaults.CustomToDate = toDate
+	}
+	if reposPath != "" {
+		defaults.RepoMode, defaults.RepoPath = "multiple", reposPath
+	} else if repoPath != "" {
+		defaults.RepoMode, defaults.RepoPath = "single", repoPath
+	}
+	config, err := interactive.RunInteractive(defaults)
 	if err != nil {
 		msg := i18n.T()
 		// 检查是否是取消操作
 		errMsg := err.Error()
 		if errMsg == msg.Canceled || errMsg == "已取消" || errMsg == "Canceled" {
 			fmt.Println(msg.ErrorCancelled)
-			os.Exit(0)
+			return nil
 		}
-		fmt.Printf("%s: %v\n", msg.ErrorCancelled, err)
-		os.Exit(1)
+		return fmt.Errorf("%s: %w", msg.ErrorCancelled, err)
 	}
 
 	// 应用配置到全局变量
@@ -386,6 +1179,7 @@ func runInteractiveMode() {
 	outputFormat = config.OutputFormat
 	outputFile = config.OutputFile
 	authorName = config.AuthorName
+	rubricPath = config.RubricPath
 
 	// 处理时间范围
 	if config.TimeRange == "custom" {
@@ -393,6 +1187,7 @@ func runInteractiveMode() {
 		toDate = config.CustomToDate
 		timeRange = "" // 清空timeRange，使用fromDate和toDate
 	} else {
+		fromDate, toDate = "", ""
 		timeRange = config.TimeRange
 	}
 
@@ -409,5 +1204,9 @@ func runInteractiveMode() {
 	fmt.Println()
 	fmt.Println(msg.AnalysisStarting)
 	fmt.Println()
-	generateReport()
+	formats, err := parseOutputFormats()
+	if err != nil {
+		return err
+	}
+	return generateReport(formats)
 }
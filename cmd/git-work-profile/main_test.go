@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/report"
+)
+
+// TestWriteReportsJSON 测试 JSON 报告输出到标准输出时只包含 JSON，进度信息不会混入
+func TestWriteReportsJSON(t *testing.T) {
+	defer func(file, dir, analysis string) {
+		outputFile, outDir, analysisType = file, dir, analysis
+	}(outputFile, outDir, analysisType)
+	outputFile, outDir, analysisType = "", "", "profile"
+
+	if progressWriter(true) != os.Stderr {
+		t.Error("报告输出到标准输出时，进度信息应输出到标准错误")
+	}
+
+	day := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	output := &analysisOutput{
+		Collected: &collection{
+			From: day.AddDate(0, -1, 0),
+			To:   day,
+			Commits: []git.CommitInfo{
+				{Hash: "abc1234567", Author: "Alice", Date: day, Message: "feat: add export", RepoPath: "/work/app",
+					ChangedFiles: []string{"export.go"}, FileStats: []git.FileStat{{Path: "export.go", Added: 12}}},
+			},
+		},
+		Analysis: "## Summary\n\nBuilt the export pipeline.",
+	}
+
+	var stdout, progress bytes.Buffer
+	if err := writeReports([]report.Format{report.FormatJSON}, true, output, &stdout, &progress); err != nil {
+		t.Fatalf("输出报告失败: %v", err)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &parsed); err != nil {
+		t.Fatalf("标准输出应为合法的 JSON: %v\n%s", err, stdout.String())
+	}
+	if parsed["analysis_type"] != "profile" || parsed["ai_analysis"] != output.Analysis {
+		t.Errorf("JSON 报告内容不正确: %v", parsed)
+	}
+	if progress.Len() != 0 {
+		t.Errorf("输出到标准输出时不应有保存文件的提示: %q", progress.String())
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/ai"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/MyceliumGrid/git-work-profile/internal/skillmatch"
+	"github.com/spf13/cobra"
+)
+
+var (
+	matchLevel  string // 从包含多个职级的目标文件中选出的职级，为空表示使用整个文件
+	matchTarget string // 技能匹配的目标描述，由 match 子命令读取
+)
+
+// 与职级要求或职位描述比较的子命令
+var matchCmd = &cobra.Command{
+	Use:   "match <target-file>",
+	Short: "Compare the profile with a career ladder level or job description",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runMatch(args[0])
+	},
+}
+
+// addMatchFlags 添加 match 子命令的参数，分析类型固定为技能匹配
+func addMatchFlags(cmd *cobra.Command) {
+	addReportFlags(cmd)
+	cmd.Flags().StringVar(&matchLevel, "level", "", i18n.T().FlagMatchLevel)
+	for _, name := range []string{"analysis", "rubric"} {
+		_ = cmd.Flags().MarkHidden(name)
+	}
+}
+
+// runMatch 读取目标描述，按目标所需的技能查找证据并生成匹配分析
+func runMatch(path string) error {
+	msg := i18n.T()
+	target, err := skillmatch.LoadTarget(path, matchLevel)
+	if err != nil {
+		return usageError(fmt.Errorf(msg.ErrorMatchTarget, err))
+	}
+	matchTarget = target
+	analysisType = string(ai.SkillMatchPrompt)
+
+	formats, err := parseOutputFormats()
+	if err != nil {
+		return err
+	}
+	return generateReport(formats)
+}
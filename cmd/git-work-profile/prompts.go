@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/ai"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// 列出各分析类型使用的提示词模板的子命令
+var promptsCmd = &cobra.Command{
+	Use:   "prompts",
+	Short: "List the prompt templates used by each analysis type",
+	Args:  usageArgs(cobra.NoArgs),
+	Run: func(_ *cobra.Command, _ []string) {
+		msg := i18n.T()
+		for _, promptType := range ai.PromptTypes {
+			_, source := ai.PromptTemplate(promptType)
+			if source == "" {
+				source = msg.LabelBuiltinPrompt
+			}
+			fmt.Printf("%-12s %s\n", promptType, source)
+		}
+	},
+}
+
+// 输出提示词模板的子命令
+var promptsShowCmd = &cobra.Command{
+	Use:   "show <analysis>",
+	Short: "Print the prompt template for an analysis type",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if err := checkAnalysisType(args[0]); err != nil {
+			return err
+		}
+		content, _ := ai.PromptTemplate(ai.PromptType(args[0]))
+		fmt.Print(content)
+		if !strings.HasSuffix(content, "\n") {
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+// checkAnalysisType 检查分析类型是否存在，不存在时返回列出所有可用类型的用法错误
+func checkAnalysisType(name string) error {
+	if slices.Contains(ai.PromptTypes, ai.PromptType(name)) {
+		return nil
+	}
+	names := make([]string, len(ai.PromptTypes))
+	for i, t := range ai.PromptTypes {
+		names[i] = string(t)
+	}
+	return usageError(fmt.Errorf(i18n.T().ErrorUnknownPromptType, name, strings.Join(names, ", ")))
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// 列出将要分析的仓库的子命令
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "List the Git repositories that would be analyzed",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runScan()
+	},
+}
+
+// runScan 每行输出一个仓库的路径和远程仓库网页地址，以制表符分隔，便于脚本处理
+func runScan() error {
+	msg := i18n.T()
+
+	// 扫描进度输出到标准错误，标准输出只包含仓库列表
+	repoPaths, err := findRepos(os.Stderr)
+	if err != nil {
+		return err
+	}
+	for _, path := range repoPaths {
+		fmt.Printf("%s\t%s\n", displayRepoPath(path), git.RemoteWebURL(git.NewGitOptions(path)))
+	}
+	fmt.Fprintf(os.Stderr, msg.InfoFoundRepos+"\n", len(repoPaths))
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/export"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+	"github.com/MyceliumGrid/git-work-profile/internal/term"
+	"github.com/spf13/cobra"
+)
+
+// statsFormat stats 子命令的输出格式：markdown、csv 或 ndjson
+var statsFormat string
+
+// 输出提交统计的子命令，不需要AI
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show commit statistics per repository, month and language",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runStats()
+	},
+}
+
+// addStatsFlags 添加 stats 子命令的参数
+func addStatsFlags(cmd *cobra.Command) {
+	addOutputFlags(cmd)
+	cmd.Flags().StringVar(&statsFormat, "format", "markdown", i18n.T().FlagStatsFormat)
+	addSurvivalFlags(cmd)
+}
+
+// runStats 按仓库、月份和语言统计提交记录。markdown 格式输出汇总表，
+// csv 和 ndjson 格式与 analyze 相同，导出提交记录并按需写出汇总表
+func runStats() error {
+	msg := i18n.T()
+
+	format := strings.ToLower(strings.TrimSpace(statsFormat))
+	switch {
+	case export.IsFormat(format):
+		return exportCommits([]string{format})
+	case format != "markdown" && format != "md":
+		return usageError(fmt.Errorf(msg.ErrorUnknownFormat, statsFormat))
+	}
+
+	// 进度信息输出到标准错误，避免混入统计结果
+	collected, err := collect(false, false, os.Stderr)
+	if err != nil {
+		return err
+	}
+	survival := codeSurvival(collected, os.Stderr)
+
+	content := statsMarkdown(collected, msg.ReportCommitStatistics, survival)
+	path := outputPath(true, fileNameFields(collected, "stats", "markdown"), "md")
+	switch {
+	case path != "":
+		file, err := createOutput(path)
+		if err != nil {
+			return outputError(fmt.Errorf(msg.ErrorCreateOutputFile, err))
+		}
+		_, err = file.WriteString(content)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return outputError(fmt.Errorf(msg.ErrorOutputFailed, err))
+		}
+		fmt.Fprintf(os.Stderr, msg.InfoReportSaved+"\n", path)
+	case term.IsTerminal(os.Stdout):
+		if err := pageMarkdown(content); err != nil {
+			return outputError(fmt.Errorf(msg.ErrorOutputFailed, err))
+		}
+	default:
+		fmt.Print(content)
+	}
+	return nil
+}
+
+// statsMarkdown 生成统计结果的 Markdown：标题、汇总数据、按仓库、月份和语言的表，以及代码存活统计
+func statsMarkdown(collected *collection, title string, survival *profile.Survival) string {
+	msg := i18n.T()
+
+	repos := make(map[string]bool)
+	days := make(map[string]bool)
+	added, deleted := 0, 0
+	for _, commit := range collected.Commits {
+		repos[commit.RepoPath] = true
+		days[commit.Date.Format("2006-01-02")] = true
+		added += commit.LinesAdded
+		deleted += commit.LinesDeleted
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "**%s**: %s %s %s\n\n", msg.ReportTimeRange, collected.From.Format("2006-01-02"), msg.ReportTo, collected.To.Format("2006-01-02"))
+	fmt.Fprintf(&b, "- **%s**: %d\n", msg.ReportTotalCommits, len(collected.Commits))
+	fmt.Fprintf(&b, "- **%s**: %d\n", msg.ReportTotalRepos, len(repos))
+	fmt.Fprintf(&b, "- **%s**: +%d / -%d\n", msg.ReportLinesChanged, added, deleted)
+	fmt.Fprintf(&b, "- **%s**: %d\n", msg.ReportActiveDays, len(days))
+
+	titles := map[string]string{
+		"repos":     msg.ReportRepositories,
+		"months":    msg.ReportMonthlyActivity,
+		"languages": msg.ReportLanguages,
+	}
+	for _, table := range export.Aggregates(collected.Commits) {
+		fmt.Fprintf(&b, "\n## %s\n\n", titles[table.Name])
+		export.WriteMarkdown(&b, table)
+	}
+	b.WriteString(survivalMarkdown(survival))
+	return b.String()
+}
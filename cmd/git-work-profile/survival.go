@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/export"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+var (
+	blameSample int // 每个仓库最多执行 blame 的文件数，0 表示全部，负数表示跳过代码存活分析
+	churnDays   int // 返工窗口（天）
+)
+
+// addSurvivalFlags 添加代码存活分析的参数
+func addSurvivalFlags(cmd *cobra.Command) {
+	msg := i18n.T()
+	cmd.Flags().IntVar(&blameSample, "blame-sample", profile.DefaultBlameSample, msg.FlagBlameSample)
+	cmd.Flags().IntVar(&churnDays, "churn-days", profile.DefaultChurnDays, msg.FlagChurnDays)
+}
+
+// codeSurvival 在时间范围结束时执行 git blame，统计开发者新增的代码行的存活和返工情况。
+// 跳过或失败时返回空，失败只给出警告，进度和警告写到 progress
+func codeSurvival(collected *collection, progress io.Writer) *profile.Survival {
+	if blameSample < 0 {
+		return nil
+	}
+
+	msg := i18n.T()
+	fmt.Fprintln(progress, msg.InfoAnalyzingSurvival)
+	survival, err := profile.AnalyzeSurvival(collected.Commits, collected.To, profile.SurvivalOptions{Sample: blameSample, ChurnDays: churnDays})
+	if err != nil {
+		fmt.Fprintf(progress, msg.WarningSurvivalFailed+"\n", err)
+		return nil
+	}
+	fmt.Fprintf(progress, msg.InfoSurvival+"\n", survival.Total.SurvivalRate()*100, survival.SampledFiles)
+	return survival
+}
+
+// survivalMarkdown 生成代码存活统计的 Markdown：总体存活率和返工率，以及按仓库和语言的表
+func survivalMarkdown(survival *profile.Survival) string {
+	if survival == nil || survival.Total.Added == 0 {
+		return ""
+	}
+
+	msg := i18n.T()
+	total := survival.Total
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", msg.ReportCodeSurvival)
+	fmt.Fprintf(&b, "- **%s**: %s (%d / %d)\n", msg.ReportSurvivalRate, percent(total.SurvivalRate()), total.Surviving, total.Added)
+	if total.Settled > 0 {
+		fmt.Fprintf(&b, "- **%s** (%s): %s (%d / %d)\n", msg.ReportChurnRate, fmt.Sprintf(msg.ReportChurnWindow, survival.ChurnDays),
+			percent(total.ChurnRate()), total.Churned, total.Settled)
+	}
+	fmt.Fprintf(&b, "- **%s**: %d / %d\n", msg.ReportSampledFiles, survival.SampledFiles, survival.Files)
+
+	groups := []struct {
+		title string
+		stats map[string]profile.LineSurvival
+		name  func(string) string
+	}{
+		{msg.ReportRepositories, survival.ByRepo, filepath.Base},
+		{msg.ReportLanguages, survival.ByLanguage, func(s string) string { return s }},
+	}
+	for _, group := range groups {
+		keys := profile.Ranked(group.stats)
+		if len(keys) == 0 {
+			continue
+		}
+
+		table := export.Table{Columns: []string{group.title, msg.ReportLinesAdded, msg.ReportSurvivingLines, msg.ReportSurvivalRate, msg.ReportChurnRate}}
+		for _, key := range keys {
+			stats := group.stats[key]
+			churn := "-"
+			if stats.Settled > 0 {
+				churn = percent(stats.ChurnRate())
+			}
+			table.Rows = append(table.Rows, []any{group.name(key), stats.Added, stats.Surviving, percent(stats.SurvivalRate()), churn})
+		}
+		b.WriteString("\n")
+		export.WriteMarkdown(&b, table)
+	}
+	return b.String()
+}
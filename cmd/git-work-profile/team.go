@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/MyceliumGrid/git-work-profile/internal/ownership"
+	"github.com/MyceliumGrid/git-work-profile/internal/team"
+	"github.com/spf13/cobra"
+)
+
+var (
+	teamMinCommits int  // 计入团队的贡献者最少的提交数
+	teamLocalOnly  bool // 只生成本地统计，不调用AI分析每个成员
+)
+
+// defaultTeamDir 团队报告的默认输出目录
+const defaultTeamDir = "team"
+
+// teamIndexFile 团队索引的文件名，位于输出目录中
+const teamIndexFile = "index.md"
+
+// 为仓库中的每个贡献者生成画像，并汇总团队情况的子命令
+var teamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Profile every contributor and summarize the team",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runTeam()
+	},
+}
+
+// addTeamFlags 添加 team 子命令的参数，作者由提交记录决定，报告写入输出目录
+func addTeamFlags(cmd *cobra.Command) {
+	msg := i18n.T()
+	addReportFlags(cmd)
+	cmd.Flags().IntVar(&teamMinCommits, "min-commits", 1, msg.FlagMinCommits)
+	cmd.Flags().BoolVar(&teamLocalOnly, "no-ai", false, msg.FlagTeamLocalOnly)
+	for _, name := range []string{"author", "output"} {
+		_ = cmd.Flags().MarkHidden(name)
+	}
+}
+
+// runTeam 收集所有作者的提交，为每个贡献者生成报告，最后写出链接所有报告的团队索引
+func runTeam() error {
+	msg := i18n.T()
+	if teamMinCommits < 1 {
+		return usageError(fmt.Errorf(msg.ErrorMinCommits, teamMinCommits))
+	}
+	formats, err := parseOutputFormats()
+	if err != nil {
+		return err
+	}
+	reportFormats, _ := splitFormats(formats)
+	if outDir == "" {
+		outDir = defaultTeamDir
+	}
+
+	collected, err := collect(verifyClaims && !teamLocalOnly, true, os.Stdout)
+	if err != nil {
+		return err
+	}
+	members := team.Members(collected.Commits, collected.From, collected.To, teamMinCommits)
+	if len(members) == 0 {
+		return gitError(fmt.Errorf(msg.ErrorNoTeamMembers, teamMinCommits))
+	}
+	fmt.Printf(msg.InfoTeamMembers+"\n", len(members))
+
+	// 文件名和报告中的作者按成员设置，完成后恢复
+	defer func(author string) { authorName = author }(authorName)
+
+	links := make(map[string][]team.Link)
+	for i, member := range members {
+		fmt.Printf(msg.InfoTeamMember+"\n", i+1, len(members), member.Name)
+		authorName = member.Name
+		memberCollected := &collection{From: collected.From, To: collected.To, Commits: member.Commits, Manifests: collected.Manifests, Remotes: collected.Remotes, CodeOwners: collected.CodeOwners, All: collected.Commits}
+
+		if teamLocalOnly {
+			path := outputPath(false, fileNameFields(memberCollected, "stats", "markdown"), "md")
+			if err := writeTeamFile(path, statsMarkdown(memberCollected, fmt.Sprintf(msg.ReportTitleMemberStats, member.Name), codeSurvival(memberCollected, os.Stdout))); err != nil {
+				return err
+			}
+			fmt.Printf(msg.InfoReportSaved+"\n", path)
+			links[member.Name] = append(links[member.Name], teamLink("markdown", path))
+			continue
+		}
+
+		err := generateReportFor(formats, func(bool, io.Writer) (*collection, error) {
+			return memberCollected, nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, format := range reportFormats {
+			path := outputPath(false, fileNameFields(memberCollected, analysisType, string(format)), format.Extension())
+			links[member.Name] = append(links[member.Name], teamLink(string(format), path))
+		}
+	}
+
+	// 团队索引：成员及其报告、代码区域覆盖情况、知识地图和协作关系
+	summary := team.Summarize(members, collected.From, collected.To)
+	summary.Knowledge = ownership.Map(collected.Commits, ownershipOptions(collected, ownership.DefaultHalfLife))
+	index := team.Index(summary, links, teamLabels())
+	path := filepath.Join(outDir, teamIndexFile)
+	if err := writeTeamFile(path, index); err != nil {
+		return err
+	}
+	fmt.Printf(msg.InfoTeamIndexSaved+"\n", path)
+	return nil
+}
+
+// teamLink 返回相对于团队索引的报告链接
+func teamLink(label, path string) team.Link {
+	if rel, err := filepath.Rel(outDir, path); err == nil {
+		path = rel
+	}
+	return team.Link{Label: label, Path: path}
+}
+
+// writeTeamFile 写出团队索引或成员的统计页
+func writeTeamFile(path, content string) error {
+	msg := i18n.T()
+	file, err := createOutput(path)
+	if err != nil {
+		return outputError(fmt.Errorf(msg.ErrorCreateOutputFile, err))
+	}
+	_, err = file.WriteString(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return outputError(fmt.Errorf(msg.ErrorOutputFailed, err))
+	}
+	return nil
+}
+
+// teamLabels 返回当前语言的团队索引标题和表头
+func teamLabels() team.Labels {
+	msg := i18n.T()
+	return team.Labels{
+		Title:           msg.ReportTitleTeam,
+		Period:          msg.ReportTimeRange,
+		Members:         msg.ReportTeamMembers,
+		Name:            msg.ReportTeamName,
+		Commits:         msg.ReportTeamCommits,
+		Lines:           msg.ReportLinesChanged,
+		Domain:          msg.ReportTeamDomain,
+		Languages:       msg.ReportLanguages,
+		Reports:         msg.ReportTeamReports,
+		Coverage:        msg.ReportTeamCoverage,
+		Area:            msg.ReportTeamArea,
+		Contributors:    msg.ReportTeamContributors,
+		BusFactor:       msg.ReportTeamBusFactor,
+		Knowledge:       msg.ReportKnowledgeMap,
+		Experts:         msg.ReportKnowledgeExperts,
+		Mismatch:        msg.ReportCodeOwnersMismatch,
+		Collaboration:   msg.ReportTeamCollaboration,
+		Pair:            msg.ReportTeamPair,
+		SharedFiles:     msg.ReportTeamSharedFiles,
+		CoAuthored:      msg.ReportTeamCoAuthored,
+		NoCollaboration: msg.ReportTeamNoCollaboration,
+	}
+}
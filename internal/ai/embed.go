@@ -0,0 +1,47 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/google/generative-ai-go/genai"
+)
+
+// DefaultEmbeddingModel 默认的向量模型名称
+const DefaultEmbeddingModel = "text-embedding-004"
+
+// maxEmbeddingBatch 单次批量请求的最大内容数
+const maxEmbeddingBatch = 100
+
+// EmbedCommits 为提交消息生成向量，返回提交哈希到向量的映射
+func (g *GeminiClient) EmbedCommits(commits []git.CommitInfo) (map[string][]float32, error) {
+	ctx := context.Background()
+	model := g.client.EmbeddingModel(DefaultEmbeddingModel)
+	model.TaskType = genai.TaskTypeClustering
+
+	embeddings := make(map[string][]float32, len(commits))
+	for start := 0; start < len(commits); start += maxEmbeddingBatch {
+		end := min(start+maxEmbeddingBatch, len(commits))
+
+		batch := model.NewBatch()
+		for _, commit := range commits[start:end] {
+			batch.AddContent(genai.Text(commit.Message))
+		}
+
+		resp, err := model.BatchEmbedContents(ctx, batch)
+		if err != nil {
+			msg := i18n.T()
+			return nil, fmt.Errorf("%s: %w", msg.ErrorEmbeddingFailed, err)
+		}
+
+		for i, embedding := range resp.Embeddings {
+			if start+i < end && embedding != nil {
+				embeddings[commits[start+i].Hash] = embedding.Values
+			}
+		}
+	}
+
+	return embeddings, nil
+}
@@ -4,11 +4,19 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/MyceliumGrid/git-work-profile/internal/cache"
+	"github.com/MyceliumGrid/git-work-profile/internal/classify"
+	"github.com/MyceliumGrid/git-work-profile/internal/cluster"
 	"github.com/MyceliumGrid/git-work-profile/internal/git"
 	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+	"github.com/MyceliumGrid/git-work-profile/internal/rubric"
+	"github.com/MyceliumGrid/git-work-profile/internal/skillmatch"
+	"github.com/MyceliumGrid/git-work-profile/internal/tickets"
 	"github.com/google/generative-ai-go/genai"
 	"google.golang.org/api/option"
 )
@@ -18,8 +26,30 @@ const DefaultModelName = "gemini-2.5-pro"
 
 // GeminiClient 是Gemini AI API的客户端
 type GeminiClient struct {
-	client *genai.Client
-	model  *genai.GenerativeModel
+	client    *genai.Client
+	model     *genai.GenerativeModel
+	modelName string
+	tools     *ToolOptions // 工具调用配置，为空表示不启用
+	extra     PromptContext
+	cache     *cache.Cache // 分析结果缓存，为空表示不使用缓存
+}
+
+// PromptContext 提示词中除提交记录外的补充信息，由本地分析预先计算
+type PromptContext struct {
+	Workstreams []cluster.Workstream // 本地聚类得到的工作流
+	Tickets     []tickets.Ticket     // 从提交中提取的工单引用
+	Branches    []git.Branch         // 尚未合并的进行中分支，用于周报和日报
+	Rubric      *rubric.Rubric       // 能力模型，用于绩效自评
+	Evidence    []rubric.Evidence    // 能力模型中各能力项的本地证据
+	Target      string               // 职级要求或职位描述，用于技能匹配
+	SkillMatch  *skillmatch.Result   // 目标所需技能的本地证据和匹配度
+	Changelog   string               // 本地按提交类型生成的更新日志，用于发布说明
+	Survival    *profile.Survival    // 开发者新增代码的存活和返工统计
+}
+
+// SetPromptContext 设置构建提示词时使用的补充信息
+func (g *GeminiClient) SetPromptContext(extra PromptContext) {
+	g.extra = extra
 }
 
 // NewGeminiClient 创建一个新的Gemini客户端
@@ -52,11 +82,26 @@ func NewGeminiClientWithModel(modelName string) (*GeminiClient, error) {
 	model := client.GenerativeModel(modelName)
 
 	return &GeminiClient{
-		client: client,
-		model:  model,
+		client:    client,
+		model:     model,
+		modelName: modelName,
 	}, nil
 }
 
+// SetCache 设置分析结果缓存，相同模型、提示词和工具配置的分析直接使用缓存的结果
+func (g *GeminiClient) SetCache(c *cache.Cache) {
+	g.cache = c
+}
+
+// EnableTools 启用工具调用，使模型可以按需查看提交详情和代码差异
+func (g *GeminiClient) EnableTools(opts ToolOptions) {
+	if opts.MaxCalls <= 0 {
+		g.tools = nil
+		return
+	}
+	g.tools = &opts
+}
+
 // SummarizeCommits 使用AI总结提交记录
 func (g *GeminiClient) SummarizeCommits(commits []git.CommitInfo) (string, error) {
 	return g.SummarizeCommitsWithPrompt(commits, DeveloperProfilePrompt)
@@ -86,35 +131,69 @@ func (g *GeminiClient) SummarizeCommitsWithPrompt(commits []git.CommitInfo, prom
 	}
 
 	// 构建提示词
-	prompt := buildPromptWithTemplate(commits, earliestDate, latestDate, promptType)
+	prompt := buildPromptWithTemplate(commits, earliestDate, latestDate, promptType, g.extra)
 
-	// 调用Gemini API
+	// 相同的输入直接使用缓存的结果，进度和警告输出到标准错误
+	msg := i18n.T()
+	key := cache.Key(g.modelName, prompt, g.tools.key())
+	if g.cache != nil {
+		if data, ok := g.cache.Get(key); ok {
+			fmt.Fprintln(os.Stderr, msg.InfoCachedAnalysis)
+			return string(data), nil
+		}
+	}
+
+	result, err := g.generate(prompt, commits)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", msg.ErrorGeminiAPIFailed, err)
+	}
+
+	if g.cache != nil && strings.TrimSpace(result) != "" {
+		if err := g.cache.Put(key, []byte(result)); err != nil {
+			fmt.Fprintf(os.Stderr, msg.WarningCacheWriteFailed+"\n", err)
+		}
+	}
+	return result, nil
+}
+
+// generate 调用Gemini API生成分析结果，启用工具调用时以多轮对话的方式生成
+func (g *GeminiClient) generate(prompt string, commits []git.CommitInfo) (string, error) {
 	ctx := context.Background()
+	if g.tools != nil {
+		return g.generateWithTools(ctx, prompt, commits)
+	}
+
 	resp, err := g.model.GenerateContent(ctx, genai.Text(prompt))
 	if err != nil {
-		msg := i18n.T()
-		return "", fmt.Errorf("%s: %w", msg.ErrorGeminiAPIFailed, err)
+		return "", err
 	}
+	return extractText(resp), nil
+}
 
-	// 提取回复
+// extractText 提取回复中的文本内容
+func extractText(resp *genai.GenerateContentResponse) string {
 	var result strings.Builder
 	for _, candidate := range resp.Candidates {
+		if candidate.Content == nil {
+			continue
+		}
 		for _, part := range candidate.Content.Parts {
-			result.WriteString(fmt.Sprintf("%v", part))
+			if text, ok := part.(genai.Text); ok {
+				result.WriteString(string(text))
+			}
 		}
 	}
-
-	return result.String(), nil
+	return result.String()
 }
 
 // buildPromptWithTemplate 使用指定的提示词模板构建提示词
-func buildPromptWithTemplate(commits []git.CommitInfo, fromDate, toDate time.Time, promptType PromptType) string {
+func buildPromptWithTemplate(commits []git.CommitInfo, fromDate, toDate time.Time, promptType PromptType, extra PromptContext) string {
 	// 获取提示词模板
 	template, err := loadPromptTemplate(promptType)
 	if err != nil {
 		// 如果加载模板失败，使用默认的提示词
 		msg := i18n.T()
-		fmt.Printf(msg.WarningPromptLoadFailed+"\n", err)
+		fmt.Fprintf(os.Stderr, msg.WarningPromptLoadFailed+"\n", err)
 		template = defaultPromptTemplate
 	}
 
@@ -125,17 +204,23 @@ func buildPromptWithTemplate(commits []git.CommitInfo, fromDate, toDate time.Tim
 	totalCommits := len(commits)
 	repoSet := make(map[string]bool)
 	fileTypeMap := make(map[string]int)
+	linesAdded, linesDeleted := 0, 0
+	ticketRefs := tickets.ByCommit(extra.Tickets)
 
 	for i, commit := range commits {
+		linesAdded += commit.LinesAdded
+		linesDeleted += commit.LinesDeleted
+
 		// 添加提交记录
 		fmt.Fprintf(&commitMessages, "提交 %d:\n", i+1)
-		fmt.Fprintf(&commitMessages, "- 哈希值: %s\n", commit.Hash[:8])
+		fmt.Fprintf(&commitMessages, "- 哈希值: %s\n", shortHash(commit.Hash))
 		fmt.Fprintf(&commitMessages, "- 作者: %s\n", commit.Author)
 		fmt.Fprintf(&commitMessages, "- 日期: %s\n", commit.Date.Format("2006-01-02 15:04:05"))
 
 		// 统计仓库
 		if commit.RepoPath != "" {
 			repoSet[commit.RepoPath] = true
+			fmt.Fprintf(&commitMessages, "- 仓库: %s\n", filepath.Base(commit.RepoPath))
 		}
 
 		// 添加分支信息
@@ -143,8 +228,12 @@ func buildPromptWithTemplate(commits []git.CommitInfo, fromDate, toDate time.Tim
 			fmt.Fprintf(&commitMessages, "- 分支: %s\n", strings.Join(commit.Branches, ", "))
 		}
 
-		// 添加提交消息
+		// 添加提交消息和意图
 		fmt.Fprintf(&commitMessages, "- 消息: %s\n", commit.Message)
+		fmt.Fprintf(&commitMessages, "- 意图: %s\n", formatClassification(classify.Classify(commit)))
+		if refs := ticketRefs[commit.Hash]; len(refs) > 0 {
+			fmt.Fprintf(&commitMessages, "- 关联工单: %s\n", strings.Join(refs, ", "))
+		}
 
 		// 添加变更文件
 		if len(commit.ChangedFiles) > 0 {
@@ -185,13 +274,261 @@ func buildPromptWithTemplate(commits []git.CommitInfo, fromDate, toDate time.Tim
 	prompt = strings.ReplaceAll(prompt, "{{.TotalCommits}}", fmt.Sprintf("%d", totalCommits))
 	prompt = strings.ReplaceAll(prompt, "{{.TimeRange}}", fmt.Sprintf("%s 至 %s", fromDate.Format("2006-01-02"), toDate.Format("2006-01-02")))
 	prompt = strings.ReplaceAll(prompt, "{{.RepoCount}}", fmt.Sprintf("%d", len(repoSet)))
-	prompt = strings.ReplaceAll(prompt, "{{.LinesAdded}}", "N/A")
-	prompt = strings.ReplaceAll(prompt, "{{.LinesDeleted}}", "N/A")
+	prompt = strings.ReplaceAll(prompt, "{{.LinesAdded}}", fmt.Sprintf("%d", linesAdded))
+	prompt = strings.ReplaceAll(prompt, "{{.LinesDeleted}}", fmt.Sprintf("%d", linesDeleted))
 	prompt = strings.ReplaceAll(prompt, "{{.FileTypes}}", fileTypes.String())
+	prompt = strings.ReplaceAll(prompt, "{{.Workstreams}}", formatWorkstreams(extra.Workstreams))
+	prompt = strings.ReplaceAll(prompt, "{{.CommitIntents}}", formatIntentBreakdown(classify.Summarize(commits)))
+	prompt = strings.ReplaceAll(prompt, "{{.Tickets}}", formatTickets(extra.Tickets))
+	prompt = strings.ReplaceAll(prompt, "{{.Branches}}", formatBranches(extra.Branches))
+	prompt = strings.ReplaceAll(prompt, "{{.Rubric}}", formatRubric(extra.Rubric))
+	prompt = strings.ReplaceAll(prompt, "{{.RubricEvidence}}", formatRubricEvidence(extra.Evidence))
+	prompt = strings.ReplaceAll(prompt, "{{.Target}}", strings.TrimSpace(extra.Target))
+	prompt = strings.ReplaceAll(prompt, "{{.SkillMatch}}", formatSkillMatch(extra.SkillMatch))
+	prompt = strings.ReplaceAll(prompt, "{{.Changelog}}", strings.TrimSpace(extra.Changelog))
+	prompt = strings.ReplaceAll(prompt, "{{.Survival}}", formatSurvival(extra.Survival))
 
 	return prompt
 }
 
+// formatClassification 格式化单个提交的分类，如 "feature (feat(api)!)"
+func formatClassification(c classify.Classification) string {
+	if !c.Conventional {
+		return string(c.Intent)
+	}
+	prefix := c.Type
+	if c.Scope != "" {
+		prefix += "(" + c.Scope + ")"
+	}
+	if c.Breaking {
+		prefix += "!"
+	}
+	return fmt.Sprintf("%s (%s)", c.Intent, prefix)
+}
+
+// formatIntentBreakdown 将提交意图分布格式化为提示词中的文本
+func formatIntentBreakdown(breakdown classify.Breakdown) string {
+	return fmt.Sprintf("%s；约定式提交占比 %.1f%%，破坏性变更 %d 个",
+		breakdown.String(), breakdown.ConventionalRatio()*100, breakdown.Breaking)
+}
+
+// formatWorkstreams 将工作流格式化为提示词中的文本
+func formatWorkstreams(workstreams []cluster.Workstream) string {
+	if len(workstreams) == 0 {
+		return "未识别出明显的工作流"
+	}
+
+	var builder strings.Builder
+	for i, ws := range workstreams {
+		fmt.Fprintf(&builder, "工作流 %d: %s（%s 至 %s，%d 个提交，仓库: %s）\n",
+			i+1, ws.Label, ws.From.Format("2006-01-02"), ws.To.Format("2006-01-02"),
+			ws.CommitCount, strings.Join(ws.Repos, ", "))
+		if len(ws.Keywords) > 0 {
+			fmt.Fprintf(&builder, "  关键词: %s\n", strings.Join(ws.Keywords, ", "))
+		}
+		for _, commit := range ws.Representatives {
+			fmt.Fprintf(&builder, "  代表性提交: %s %s\n", shortHash(commit.Hash), commit.Message)
+		}
+	}
+	return builder.String()
+}
+
+// formatTickets 将工单分组格式化为提示词中的文本
+func formatTickets(list []tickets.Ticket) string {
+	if len(list) == 0 {
+		return "未发现工单引用"
+	}
+
+	var builder strings.Builder
+	for _, ticket := range list {
+		status := "进行中"
+		if ticket.Closed {
+			status = "已关闭"
+		}
+		fmt.Fprintf(&builder, "- %s（%s，%s 至 %s，%d 个提交，%s）", ticket.ID, ticket.Tracker,
+			ticket.From.Format("2006-01-02"), ticket.To.Format("2006-01-02"), len(ticket.Hashes), status)
+		if len(ticket.Subjects) > 0 {
+			fmt.Fprintf(&builder, ": %s", ticket.Subjects[0])
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// formatBranches 将进行中的分支格式化为提示词中的文本
+func formatBranches(list []git.Branch) string {
+	if len(list) == 0 {
+		return "没有进行中的分支"
+	}
+
+	var builder strings.Builder
+	for _, branch := range list {
+		fmt.Fprintf(&builder, "- %s: %s（%d 个未合并的提交，最后提交于 %s）: %s\n", filepath.Base(branch.RepoPath),
+			branch.Name, branch.Ahead, branch.LastCommit.Format("2006-01-02"), branch.Subject)
+	}
+	return builder.String()
+}
+
+// formatRubric 将能力模型格式化为提示词中的文本
+func formatRubric(r *rubric.Rubric) string {
+	if r == nil {
+		return "未提供能力模型"
+	}
+
+	var builder strings.Builder
+	if r.Name != "" {
+		fmt.Fprintf(&builder, "%s\n", r.Name)
+	}
+	writeLevels := func(indent string, levels []rubric.Level) {
+		for _, level := range levels {
+			fmt.Fprintf(&builder, "%s- 等级 %s: %s\n", indent, level.Name, level.Description)
+		}
+	}
+	writeLevels("", r.Levels)
+	for i, area := range r.Areas {
+		fmt.Fprintf(&builder, "能力项 %d: %s\n", i+1, area.Name)
+		if area.Description != "" {
+			fmt.Fprintf(&builder, "  描述: %s\n", area.Description)
+		}
+		writeLevels("  ", area.Levels)
+	}
+	return builder.String()
+}
+
+// formatRubricEvidence 将各能力项的本地证据格式化为提示词中的文本，每项最多列出10个提交
+func formatRubricEvidence(evidence []rubric.Evidence) string {
+	if len(evidence) == 0 {
+		return "无"
+	}
+
+	var builder strings.Builder
+	for _, item := range evidence {
+		if len(item.Keywords) == 0 {
+			fmt.Fprintf(&builder, "- %s: 未提供关键词，请根据提交记录判断\n", item.Area)
+			continue
+		}
+		fmt.Fprintf(&builder, "- %s（关键词: %s）: %d 个提交", item.Area, strings.Join(item.Keywords, ", "), len(item.Hashes))
+		if item.Weak() {
+			builder.WriteString("，证据不足")
+		}
+		hashes := item.Hashes
+		if len(hashes) > 10 {
+			hashes = hashes[:10]
+		}
+		for i, hash := range hashes {
+			if i == 0 {
+				builder.WriteString(": ")
+			} else {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(shortHash(hash))
+		}
+		if len(item.Workstreams) > 0 {
+			fmt.Fprintf(&builder, "；相关工作流: %s", strings.Join(item.Workstreams, ", "))
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// skillLevelNames 技能证据等级在提示词中的名称
+var skillLevelNames = map[skillmatch.Level]string{
+	skillmatch.Strong:  "证据充分",
+	skillmatch.Weak:    "证据不足",
+	skillmatch.Missing: "没有证据",
+}
+
+// formatSkillMatch 将技能匹配结果格式化为提示词中的文本，每项技能最多列出10个提交
+func formatSkillMatch(result *skillmatch.Result) string {
+	if result == nil || len(result.Skills) == 0 {
+		return "未识别出目标所需的技能"
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "匹配度: %d/100（%d 项技能中 %d 项证据充分）\n", result.Score, len(result.Skills), len(result.Strengths))
+	for _, item := range result.Skills {
+		fmt.Fprintf(&builder, "- %s: %s", item.Skill, skillLevelNames[item.Level])
+		if item.Changes > 0 {
+			fmt.Fprintf(&builder, "，%d 次文件变更", item.Changes)
+		}
+		if item.Domain {
+			builder.WriteString("，属于开发者的专业领域")
+		}
+		hashes := item.Hashes
+		if len(hashes) > 10 {
+			hashes = hashes[:10]
+		}
+		for i, hash := range hashes {
+			if i == 0 {
+				fmt.Fprintf(&builder, "，%d 个提交: ", len(item.Hashes))
+			} else {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(shortHash(hash))
+		}
+		if len(item.Workstreams) > 0 {
+			fmt.Fprintf(&builder, "；相关工作流: %s", strings.Join(item.Workstreams, ", "))
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// formatSurvival 将代码存活和返工统计格式化为提示词中的文本，按仓库和语言最多各列出10项
+func formatSurvival(survival *profile.Survival) string {
+	if survival == nil || survival.Total.Added == 0 {
+		return "未统计代码存活情况"
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "抽样 %d/%d 个文件：新增 %d 行，时间范围结束时仍存在 %d 行（存活率 %.1f%%）",
+		survival.SampledFiles, survival.Files, survival.Total.Added, survival.Total.Surviving, survival.Total.SurvivalRate()*100)
+	if survival.Total.Settled > 0 {
+		fmt.Fprintf(&builder, "；%d 天内被改写或删除 %d/%d 行（返工率 %.1f%%）",
+			survival.ChurnDays, survival.Total.Churned, survival.Total.Settled, survival.Total.ChurnRate()*100)
+	}
+	builder.WriteString("\n")
+
+	groups := []struct {
+		title string
+		stats map[string]profile.LineSurvival
+		name  func(string) string
+	}{
+		{"按仓库", survival.ByRepo, filepath.Base},
+		{"按语言", survival.ByLanguage, func(s string) string { return s }},
+	}
+	for _, group := range groups {
+		keys := profile.Ranked(group.stats)
+		if len(keys) > 10 {
+			keys = keys[:10]
+		}
+		for i, key := range keys {
+			if i == 0 {
+				fmt.Fprintf(&builder, "%s: ", group.title)
+			} else {
+				builder.WriteString(", ")
+			}
+			stats := group.stats[key]
+			fmt.Fprintf(&builder, "%s 存活 %.0f%%", group.name(key), stats.SurvivalRate()*100)
+			if stats.Settled > 0 {
+				fmt.Fprintf(&builder, "（返工 %.0f%%）", stats.ChurnRate()*100)
+			}
+		}
+		if len(keys) > 0 {
+			builder.WriteString("\n")
+		}
+	}
+	return builder.String()
+}
+
+// shortHash 返回提交哈希的前8位
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
 // getFileExtension 获取文件扩展名
 func getFileExtension(filename string) string {
 	for i := len(filename) - 1; i >= 0; i-- {
@@ -235,7 +572,7 @@ func (g *GeminiClient) GenerateReportWithPrompt(commits []git.CommitInfo, fromDa
 	}
 
 	// 构建提示词
-	prompt := buildPromptWithTemplate(commits, fromDate, toDate, promptType)
+	prompt := buildPromptWithTemplate(commits, fromDate, toDate, promptType, g.extra)
 
 	// 调用Gemini API
 	ctx := context.Background()
@@ -245,15 +582,7 @@ func (g *GeminiClient) GenerateReportWithPrompt(commits []git.CommitInfo, fromDa
 		return "", fmt.Errorf("%s: %w", msg.ErrorGeminiAPIFailed, err)
 	}
 
-	// 提取回复
-	var result strings.Builder
-	for _, candidate := range resp.Candidates {
-		for _, part := range candidate.Content.Parts {
-			result.WriteString(fmt.Sprintf("%v", part))
-		}
-	}
-
-	return result.String(), nil
+	return extractText(resp), nil
 }
 
 // Close 关闭Gemini客户端
@@ -274,10 +603,18 @@ const defaultPromptTemplate = `你是一位专业的技术人才分析师。请
 - 分析时间范围：{{.TimeRange}}
 - 涉及仓库数：{{.RepoCount}}
 
-请分析开发者的技术栈、工作风格、专业领域和核心竞争力。`
+请分析开发者的技术栈、工作风格、专业领域和核心竞争力。
+
+引用要求：每个关于具体工作、项目、技术或成果的陈述后，用方括号注明支持它的提交哈希（使用上面提交记录中的哈希值），如 [a1b2c3d4] 或 [a1b2c3d4, e5f6a7b8]。只能引用上面列出的提交，不要编造哈希值。`
 
 // loadPromptTemplate 从文件加载提示词模板
 func loadPromptTemplate(promptType PromptType) (string, error) {
+	content, _, err := findPromptTemplate(promptType)
+	return content, err
+}
+
+// findPromptTemplate 查找并加载提示词模板，返回模板内容和所在的文件路径
+func findPromptTemplate(promptType PromptType) (string, string, error) {
 	// 根据提示词类型确定文件名
 	var filename string
 	switch promptType {
@@ -287,6 +624,16 @@ func loadPromptTemplate(promptType PromptType) (string, error) {
 		filename = "project-experience.txt"
 	case TechStackPrompt:
 		filename = "techstack-analysis.txt"
+	case WeeklyReportPrompt:
+		filename = "weekly-report.txt"
+	case DailyReportPrompt:
+		filename = "daily-report.txt"
+	case SelfReviewPrompt:
+		filename = "self-review.txt"
+	case SkillMatchPrompt:
+		filename = "skill-match.txt"
+	case ReleaseNotesPrompt:
+		filename = "release-notes.txt"
 	default:
 		filename = "developer-profile.txt"
 	}
@@ -295,7 +642,7 @@ func loadPromptTemplate(promptType PromptType) (string, error) {
 	cwd, err := os.Getwd()
 	if err != nil {
 		msg := i18n.T()
-		return "", fmt.Errorf("%s: %w", msg.ErrorGetCurrentDir, err)
+		return "", "", fmt.Errorf("%s: %w", msg.ErrorGetCurrentDir, err)
 	}
 
 	// 尝试从多个可能的位置加载模板
@@ -313,13 +660,13 @@ func loadPromptTemplate(promptType PromptType) (string, error) {
 		content, loadErr = loadPromptTemplateFromPath(path)
 		if loadErr == nil {
 			// 成功加载模板
-			return string(content), nil
+			return string(content), filepath.Clean(path), nil
 		}
 	}
 
 	// 所有路径都失败了，返回最后一个错误
 	msg := i18n.T()
-	return "", fmt.Errorf("%s: %w", msg.ErrorLoadPromptTemplate, loadErr)
+	return "", "", fmt.Errorf("%s: %w", msg.ErrorLoadPromptTemplate, loadErr)
 }
 
 // loadPromptTemplateFromPath 从指定路径加载提示词模板
@@ -2,7 +2,14 @@ package ai
 
 import (
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+	"github.com/MyceliumGrid/git-work-profile/internal/rubric"
+	"github.com/MyceliumGrid/git-work-profile/internal/skillmatch"
+	"github.com/google/generative-ai-go/genai"
 )
 
 // TestNewGeminiClient 测试创建Gemini客户端
@@ -35,6 +42,11 @@ func TestGetPromptType(t *testing.T) {
 		{"开发者画像", "profile", DeveloperProfilePrompt},
 		{"项目经验", "experience", ProjectExperiencePrompt},
 		{"技术栈分析", "techstack", TechStackPrompt},
+		{"工作周报", "weekly", WeeklyReportPrompt},
+		{"工作日报", "daily", DailyReportPrompt},
+		{"绩效自评", "review", SelfReviewPrompt},
+		{"技能匹配", "match", SkillMatchPrompt},
+		{"发布说明", "release", ReleaseNotesPrompt},
 		{"未知类型", "unknown", DeveloperProfilePrompt}, // 默认返回开发者画像
 		{"空字符串", "", DeveloperProfilePrompt},        // 默认返回开发者画像
 	}
@@ -81,3 +93,119 @@ func TestLoadPromptTemplate(t *testing.T) {
 		t.Errorf("加载的内容不匹配: 期望 %q, 得到 %q", testContent, string(content))
 	}
 }
+
+// TestToolExecutorResolve 测试工具调用中提交和仓库的解析
+func TestToolExecutorResolve(t *testing.T) {
+	commits := []git.CommitInfo{
+		{Hash: "abc123456789", RepoPath: "/work/api"},
+		{Hash: "def987654321", RepoPath: "/work/web"},
+	}
+	var progress strings.Builder
+	executor := newToolExecutor(commits, ToolOptions{MaxCalls: 2, Progress: &progress})
+
+	if commit, ok := executor.resolveCommit("def98765"); !ok || commit.RepoPath != "/work/web" {
+		t.Errorf("短哈希应解析到 /work/web 的提交, 得到: %v %v", commit, ok)
+	}
+	if _, ok := executor.resolveCommit("ab"); ok {
+		t.Error("过短的哈希不应被解析")
+	}
+	if _, ok := executor.resolveCommit("ffffffff"); ok {
+		t.Error("不在收集范围内的提交不应被解析")
+	}
+	if repo, ok := executor.resolveRepo("api"); !ok || repo != "/work/api" {
+		t.Errorf("仓库名称应解析为 /work/api, 得到: %s", repo)
+	}
+
+	// 工具调用进度写到指定的输出
+	executor.execute(genai.FunctionCall{Name: "unknown_tool"})
+	if !strings.Contains(progress.String(), "unknown_tool") {
+		t.Errorf("工具调用进度应写到 Progress, 得到: %q", progress.String())
+	}
+
+	// 预算用完后不再执行工具
+	executor.calls = 2
+	result := executor.execute(genai.FunctionCall{Name: toolGetDiff, Args: map[string]any{"hash": "abc12345"}})
+	if _, ok := result["error"]; !ok {
+		t.Error("工具调用预算用完后应返回错误")
+	}
+}
+
+// TestTruncateOutput 测试工具输出截断
+func TestTruncateOutput(t *testing.T) {
+	content, truncated := truncateOutput("short", 100)
+	if truncated || content != "short" {
+		t.Errorf("未超出限制时不应截断, 得到: %q", content)
+	}
+
+	content, truncated = truncateOutput("中文内容", 4)
+	if !truncated {
+		t.Error("超出限制时应截断")
+	}
+	if !strings.HasPrefix(content, "中") || strings.HasPrefix(content, "中文") {
+		t.Errorf("应在字符边界处截断, 得到: %q", content)
+	}
+}
+
+// TestFormatRubricEvidence 测试能力项证据的格式化
+func TestFormatRubricEvidence(t *testing.T) {
+	evidence := []rubric.Evidence{
+		{Area: "Quality", Keywords: []string{"test"}, Hashes: []string{"0123456789abcdef"}, Workstreams: []string{"parser"}},
+		{Area: "Leadership"},
+	}
+	result := formatRubricEvidence(evidence)
+	if !strings.Contains(result, "01234567") || strings.Contains(result, "0123456789") {
+		t.Errorf("应列出提交的短哈希, 得到: %q", result)
+	}
+	if !strings.Contains(result, "Quality（关键词: test）: 1 个提交，证据不足") {
+		t.Errorf("证据少的能力项应标记为证据不足, 得到: %q", result)
+	}
+	if !strings.Contains(result, "Leadership: 未提供关键词") {
+		t.Errorf("没有关键词的能力项应交给AI判断, 得到: %q", result)
+	}
+}
+
+// TestFormatSkillMatch 测试技能匹配结果的格式化
+func TestFormatSkillMatch(t *testing.T) {
+	result := &skillmatch.Result{
+		Score: 75,
+		Skills: []skillmatch.Evidence{
+			{Skill: "Go", Level: skillmatch.Strong, Changes: 12, Hashes: []string{"0123456789abcdef", "fedcba9876543210", "a1b2c3d4e5f6a7b8"}},
+			{Skill: "Kafka", Level: skillmatch.Missing},
+		},
+		Strengths: []skillmatch.Evidence{{Skill: "Go"}},
+	}
+	text := formatSkillMatch(result)
+	for _, want := range []string{"匹配度: 75/100（2 项技能中 1 项证据充分）", "- Go: 证据充分，12 次文件变更，3 个提交: 01234567, fedcba98", "- Kafka: 没有证据\n"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("应包含 %q, 得到: %q", want, text)
+		}
+	}
+	if formatSkillMatch(nil) != "未识别出目标所需的技能" {
+		t.Error("没有匹配结果时应说明未识别出技能")
+	}
+}
+
+// TestFormatSurvival 测试代码存活统计的格式化
+func TestFormatSurvival(t *testing.T) {
+	survival := &profile.Survival{
+		ChurnDays:    21,
+		Files:        12,
+		SampledFiles: 10,
+		Total:        profile.LineSurvival{Added: 200, Surviving: 150, Settled: 100, Churned: 20},
+		ByRepo:       map[string]profile.LineSurvival{"/work/api": {Added: 200, Surviving: 150, Settled: 100, Churned: 20}},
+		ByLanguage: map[string]profile.LineSurvival{
+			"Go":  {Added: 150, Surviving: 120, Settled: 100, Churned: 20},
+			"SQL": {Added: 50, Surviving: 30},
+		},
+	}
+	text := formatSurvival(survival)
+	for _, want := range []string{"抽样 10/12 个文件：新增 200 行，时间范围结束时仍存在 150 行（存活率 75.0%）；21 天内被改写或删除 20/100 行（返工率 20.0%）",
+		"按仓库: api 存活 75%（返工 20%）", "按语言: Go 存活 80%（返工 20%）, SQL 存活 60%\n"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("应包含 %q, 得到: %q", want, text)
+		}
+	}
+	if formatSurvival(nil) != "未统计代码存活情况" {
+		t.Error("没有统计结果时应说明未统计")
+	}
+}
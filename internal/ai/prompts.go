@@ -19,6 +19,16 @@ const (
 	ProjectExperiencePrompt PromptType = "experience"
 	// TechStackPrompt 技术栈分析
 	TechStackPrompt PromptType = "techstack"
+	// WeeklyReportPrompt 工作周报
+	WeeklyReportPrompt PromptType = "weekly"
+	// DailyReportPrompt 工作日报
+	DailyReportPrompt PromptType = "daily"
+	// SelfReviewPrompt 根据能力模型撰写绩效自评
+	SelfReviewPrompt PromptType = "review"
+	// SkillMatchPrompt 与职级要求或职位描述的匹配分析
+	SkillMatchPrompt PromptType = "match"
+	// ReleaseNotesPrompt 版本发布说明
+	ReleaseNotesPrompt PromptType = "release"
 )
 
 // GetPromptTypeFromString 根据字符串返回对应的提示词类型
@@ -30,11 +40,40 @@ func GetPromptTypeFromString(promptTypeStr string) PromptType {
 		return ProjectExperiencePrompt
 	case "techstack":
 		return TechStackPrompt
+	case "weekly":
+		return WeeklyReportPrompt
+	case "daily":
+		return DailyReportPrompt
+	case "review":
+		return SelfReviewPrompt
+	case "match":
+		return SkillMatchPrompt
+	case "release":
+		return ReleaseNotesPrompt
 	default:
 		return DeveloperProfilePrompt
 	}
 }
 
+// DefaultTimeRanges 工作报告类分析在未指定时间范围时使用的默认时间范围
+var DefaultTimeRanges = map[PromptType]string{
+	WeeklyReportPrompt: "this-week",
+	DailyReportPrompt:  "today",
+}
+
+// PromptTypes 所有的提示词类型
+var PromptTypes = []PromptType{DeveloperProfilePrompt, ProjectExperiencePrompt, TechStackPrompt, WeeklyReportPrompt, DailyReportPrompt, SelfReviewPrompt, SkillMatchPrompt, ReleaseNotesPrompt}
+
+// PromptTemplate 返回提示词类型实际使用的模板及其文件路径，
+// 找不到模板文件时返回内置的默认模板，路径为空
+func PromptTemplate(promptType PromptType) (content, source string) {
+	content, source, err := findPromptTemplate(promptType)
+	if err != nil {
+		return defaultPromptTemplate, ""
+	}
+	return content, source
+}
+
 // LoadCustomPrompt 加载自定义提示词文件
 func LoadCustomPrompt(filePath string) (string, error) {
 	msg := i18n.T()
@@ -0,0 +1,331 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/google/generative-ai-go/genai"
+)
+
+// 工具调用相关的默认限制
+const (
+	// DefaultMaxToolCalls 单次分析允许的最大工具调用次数
+	DefaultMaxToolCalls = 10
+	// DefaultMaxToolOutput 单次工具调用返回内容的最大字节数
+	DefaultMaxToolOutput = 8000
+	// maxListedFiles list_files 最多返回的条目数
+	maxListedFiles = 200
+)
+
+// 工具名称
+const (
+	toolGetCommitDetails = "get_commit_details"
+	toolGetDiff          = "get_diff"
+	toolListFiles        = "list_files"
+)
+
+// ToolOptions 工具调用的配置
+type ToolOptions struct {
+	MaxCalls  int       // 最大工具调用次数，0 表示禁用工具调用
+	MaxOutput int       // 单次工具返回内容的最大字节数
+	Progress  io.Writer // 输出工具调用进度，为空时写到标准错误
+}
+
+// key 返回影响分析结果的配置，用于缓存键，未启用时为空
+func (o *ToolOptions) key() string {
+	if o == nil {
+		return ""
+	}
+	return fmt.Sprintf("tools:%d:%d", o.MaxCalls, o.MaxOutput)
+}
+
+// toolExecutor 在本地执行模型请求的工具调用
+type toolExecutor struct {
+	commits []git.CommitInfo // 已收集的提交，工具只允许访问这些提交
+	repos   []string         // 已收集提交涉及的仓库路径
+	opts    ToolOptions
+	calls   int // 已执行的工具调用次数
+}
+
+// newToolExecutor 创建工具执行器
+func newToolExecutor(commits []git.CommitInfo, opts ToolOptions) *toolExecutor {
+	if opts.MaxOutput <= 0 {
+		opts.MaxOutput = DefaultMaxToolOutput
+	}
+	if opts.Progress == nil {
+		opts.Progress = os.Stderr
+	}
+
+	repoSet := make(map[string]bool)
+	var repos []string
+	for _, commit := range commits {
+		repoPath := commit.RepoPath
+		if repoPath == "" {
+			repoPath = "."
+		}
+		if !repoSet[repoPath] {
+			repoSet[repoPath] = true
+			repos = append(repos, repoPath)
+		}
+	}
+
+	return &toolExecutor{commits: commits, repos: repos, opts: opts}
+}
+
+// exhausted 判断工具调用预算是否已用完
+func (e *toolExecutor) exhausted() bool {
+	return e.calls >= e.opts.MaxCalls
+}
+
+// declarations 返回提供给模型的工具声明
+func (e *toolExecutor) declarations() []*genai.Tool {
+	return []*genai.Tool{{
+		FunctionDeclarations: []*genai.FunctionDeclaration{
+			{
+				Name:        toolGetCommitDetails,
+				Description: "获取某个提交的详细信息，包括完整的变更文件列表。hash 可以是提交记录中给出的短哈希。",
+				Parameters: &genai.Schema{
+					Type: genai.TypeObject,
+					Properties: map[string]*genai.Schema{
+						"hash": {Type: genai.TypeString, Description: "提交哈希（可为短哈希）"},
+					},
+					Required: []string{"hash"},
+				},
+			},
+			{
+				Name:        toolGetDiff,
+				Description: "获取某个提交的代码差异。path 为空时返回整个提交的差异，内容过长会被截断。",
+				Parameters: &genai.Schema{
+					Type: genai.TypeObject,
+					Properties: map[string]*genai.Schema{
+						"hash": {Type: genai.TypeString, Description: "提交哈希（可为短哈希）"},
+						"path": {Type: genai.TypeString, Description: "只查看该文件的差异（可选）"},
+					},
+					Required: []string{"hash"},
+				},
+			},
+			{
+				Name:        toolListFiles,
+				Description: "列出仓库当前版本中某个目录下的文件和子目录，用于了解项目结构。",
+				Parameters: &genai.Schema{
+					Type: genai.TypeObject,
+					Properties: map[string]*genai.Schema{
+						"repo": {Type: genai.TypeString, Description: "仓库名称，即提交记录中的仓库字段"},
+						"path": {Type: genai.TypeString, Description: "目录路径，为空表示仓库根目录"},
+					},
+					Required: []string{"repo"},
+				},
+			},
+		},
+	}}
+}
+
+// execute 执行一次工具调用并返回结果
+func (e *toolExecutor) execute(call genai.FunctionCall) map[string]any {
+	msg := i18n.T()
+	if e.exhausted() {
+		return map[string]any{"error": "工具调用次数已用完，请基于已有信息完成分析"}
+	}
+	e.calls++
+
+	hash := stringArg(call.Args, "hash")
+	path := stringArg(call.Args, "path")
+	fmt.Fprintf(e.opts.Progress, msg.InfoToolCall+"\n", call.Name, strings.TrimSpace(hash+" "+path))
+
+	switch call.Name {
+	case toolGetCommitDetails:
+		commit, ok := e.resolveCommit(hash)
+		if !ok {
+			return map[string]any{"error": fmt.Sprintf("未找到提交 %s，只能查询提交记录中列出的提交", hash)}
+		}
+		details, err := git.GetCommitDetails(commit.Hash, &git.Options{RepoPath: commit.RepoPath})
+		if err != nil {
+			return map[string]any{"error": err.Error()}
+		}
+		files := details.ChangedFiles
+		truncated := false
+		if len(files) > maxListedFiles {
+			files = files[:maxListedFiles]
+			truncated = true
+		}
+		return map[string]any{
+			"hash":          details.Hash,
+			"repo":          filepath.Base(commit.RepoPath),
+			"author":        details.Author,
+			"date":          details.Date.Format("2006-01-02 15:04:05"),
+			"message":       details.Message,
+			"branches":      strings.Join(details.Branches, ", "),
+			"changed_files": strings.Join(files, "\n"),
+			"truncated":     truncated,
+		}
+	case toolGetDiff:
+		commit, ok := e.resolveCommit(hash)
+		if !ok {
+			return map[string]any{"error": fmt.Sprintf("未找到提交 %s，只能查询提交记录中列出的提交", hash)}
+		}
+		if strings.HasPrefix(path, "-") {
+			return map[string]any{"error": "无效的文件路径"}
+		}
+		diff, err := git.GetCommitDiff(commit.Hash, path, &git.Options{RepoPath: commit.RepoPath})
+		if err != nil {
+			return map[string]any{"error": err.Error()}
+		}
+		diff, truncated := truncateOutput(diff, e.opts.MaxOutput)
+		return map[string]any{"hash": commit.Hash, "diff": diff, "truncated": truncated}
+	case toolListFiles:
+		repoPath, ok := e.resolveRepo(stringArg(call.Args, "repo"))
+		if !ok {
+			return map[string]any{"error": "未知的仓库，请使用提交记录中出现的仓库名称"}
+		}
+		if strings.HasPrefix(path, "-") || strings.Contains(path, "..") {
+			return map[string]any{"error": "无效的目录路径"}
+		}
+		files, err := git.ListFiles("HEAD", path, &git.Options{RepoPath: repoPath})
+		if err != nil {
+			return map[string]any{"error": err.Error()}
+		}
+		truncated := false
+		if len(files) > maxListedFiles {
+			files = files[:maxListedFiles]
+			truncated = true
+		}
+		return map[string]any{"files": strings.Join(files, "\n"), "truncated": truncated}
+	default:
+		return map[string]any{"error": fmt.Sprintf("未知的工具: %s", call.Name)}
+	}
+}
+
+// resolveCommit 根据（短）哈希在已收集的提交中查找提交
+func (e *toolExecutor) resolveCommit(hash string) (git.CommitInfo, bool) {
+	hash = strings.TrimSpace(hash)
+	if len(hash) < 4 {
+		return git.CommitInfo{}, false
+	}
+	for _, commit := range e.commits {
+		if strings.HasPrefix(commit.Hash, hash) {
+			if commit.RepoPath == "" {
+				commit.RepoPath = "."
+			}
+			return commit, true
+		}
+	}
+	return git.CommitInfo{}, false
+}
+
+// resolveRepo 根据仓库名称或路径查找已收集的仓库
+func (e *toolExecutor) resolveRepo(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	// 只有一个仓库时允许省略仓库名称
+	if name == "" && len(e.repos) == 1 {
+		return e.repos[0], true
+	}
+	for _, repo := range e.repos {
+		if repo == name || filepath.Base(repo) == name {
+			return repo, true
+		}
+	}
+	return "", false
+}
+
+// generateWithTools 以多轮对话的方式生成内容，期间执行模型请求的工具调用
+func (g *GeminiClient) generateWithTools(ctx context.Context, prompt string, commits []git.CommitInfo) (string, error) {
+	executor := newToolExecutor(commits, *g.tools)
+
+	// 复制模型配置，避免影响其他调用
+	model := *g.model
+	model.Tools = executor.declarations()
+	chat := model.StartChat()
+
+	resp, err := chat.SendMessage(ctx, genai.Text(prompt+toolUsageHint))
+	if err != nil {
+		return "", err
+	}
+
+	// 每轮至少执行一次工具调用，预算用完后再给模型一轮给出结果；
+	// 模型仍然继续请求工具调用时停止对话，避免无限循环
+	for round := 0; ; round++ {
+		calls := functionCalls(resp)
+		if len(calls) == 0 {
+			break
+		}
+		if round >= executor.opts.MaxCalls {
+			if text := extractText(resp); strings.TrimSpace(text) != "" {
+				return text, nil
+			}
+			return "", errors.New(i18n.T().ErrorToolCallLoop)
+		}
+
+		parts := make([]genai.Part, 0, len(calls))
+		for _, call := range calls {
+			parts = append(parts, genai.FunctionResponse{
+				Name:     call.Name,
+				Response: executor.execute(call),
+			})
+		}
+
+		// 预算用完后禁止继续调用工具，要求模型直接给出结果
+		if executor.exhausted() {
+			model.ToolConfig = &genai.ToolConfig{
+				FunctionCallingConfig: &genai.FunctionCallingConfig{Mode: genai.FunctionCallingNone},
+			}
+		}
+
+		resp, err = chat.SendMessage(ctx, parts...)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return extractText(resp), nil
+}
+
+// functionCalls 提取回复中的所有工具调用
+func functionCalls(resp *genai.GenerateContentResponse) []genai.FunctionCall {
+	var calls []genai.FunctionCall
+	for _, candidate := range resp.Candidates {
+		calls = append(calls, candidate.FunctionCalls()...)
+	}
+	return calls
+}
+
+// stringArg 读取工具调用中的字符串参数
+func stringArg(args map[string]any, key string) string {
+	if value, ok := args[key].(string); ok {
+		return strings.TrimSpace(value)
+	}
+	return ""
+}
+
+// truncateOutput 将内容截断到指定字节数以内，并返回是否发生截断
+func truncateOutput(content string, limit int) (string, bool) {
+	if limit <= 0 || len(content) <= limit {
+		return content, false
+	}
+	// 避免截断在多字节字符中间
+	cut := limit
+	for cut > 0 && !isRuneStart(content[cut]) {
+		cut--
+	}
+	return content[:cut] + "\n... (truncated)", true
+}
+
+// isRuneStart 判断字节是否为UTF-8字符的起始字节
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// 启用工具调用时追加到提示词末尾的说明
+const toolUsageHint = `
+
+补充说明：你可以调用以下工具查看真实的代码变更，以便对重要或含义模糊的提交（如"fix bug"、"update"）做出有依据的判断：
+- get_commit_details(hash)：查看提交的完整变更文件列表
+- get_diff(hash, path)：查看提交的代码差异
+- list_files(repo, path)：查看仓库目录结构
+工具调用次数有限，请只针对对分析结论影响最大的提交使用。`
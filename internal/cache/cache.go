@@ -0,0 +1,150 @@
+// Package cache 将AI分析结果按输入内容缓存到本地文件，相同的提示词和模型不再重复调用API
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DirEnvVar 指定缓存目录的环境变量
+const DirEnvVar = "GIT_PROFILE_CACHE_DIR"
+
+// entryExt 缓存条目的文件扩展名
+const entryExt = ".txt"
+
+// Cache 基于文件的缓存，每个条目保存为目录中以键命名的一个文件
+type Cache struct {
+	Dir string
+}
+
+// Stats 缓存的统计信息
+type Stats struct {
+	Entries int   // 条目数量
+	Size    int64 // 总字节数
+}
+
+// New 创建使用指定目录的缓存，目录在第一次写入时创建
+func New(dir string) *Cache {
+	return &Cache{Dir: dir}
+}
+
+// DefaultDir 返回默认的缓存目录：环境变量 GIT_PROFILE_CACHE_DIR，
+// 未设置时为用户缓存目录下的 git-work-profile
+func DefaultDir() (string, error) {
+	if dir := os.Getenv(DirEnvVar); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "git-work-profile"), nil
+}
+
+// Key 根据输入内容计算缓存键，任意部分不同都会得到不同的键
+func Key(parts ...string) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Get 读取缓存条目，不存在或读取失败时返回 false
+func (c *Cache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put 写入缓存条目，先写入临时文件再重命名，避免并发运行时读到不完整的内容
+func (c *Cache) Put(key string, data []byte) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// Stats 统计缓存条目的数量和大小，目录不存在时返回空统计
+func (c *Cache) Stats() (Stats, error) {
+	var stats Stats
+	entries, err := c.entries()
+	for _, entry := range entries {
+		info, infoErr := entry.Info()
+		if infoErr != nil {
+			continue
+		}
+		stats.Entries++
+		stats.Size += info.Size()
+	}
+	return stats, err
+}
+
+// Clear 删除所有缓存条目，返回删除的数量。只删除缓存写入的文件，目录中的其他文件保持不变
+func (c *Cache) Clear() (int, error) {
+	entries, err := c.entries()
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, entry := range entries {
+		if err := os.Remove(filepath.Join(c.Dir, entry.Name())); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// entries 列出缓存条目文件
+func (c *Cache) entries() ([]os.DirEntry, error) {
+	all, err := os.ReadDir(c.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []os.DirEntry
+	for _, entry := range all {
+		name, ok := strings.CutSuffix(entry.Name(), entryExt)
+		if ok && entry.Type().IsRegular() && isKey(name) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// path 返回缓存条目的文件路径
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, key+entryExt)
+}
+
+// isKey 判断文件名是否为 Key 生成的缓存键
+func isKey(name string) bool {
+	if len(name) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
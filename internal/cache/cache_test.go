@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestPutGet 测试写入和读取缓存条目
+func TestPutGet(t *testing.T) {
+	c := New(filepath.Join(t.TempDir(), "nested"))
+	key := Key("gemini-2.5-pro", "prompt")
+
+	if _, ok := c.Get(key); ok {
+		t.Fatal("空缓存不应命中")
+	}
+	if err := c.Put(key, []byte("analysis")); err != nil {
+		t.Fatalf("写入缓存失败: %v", err)
+	}
+	data, ok := c.Get(key)
+	if !ok || string(data) != "analysis" {
+		t.Errorf("读取缓存不正确: %q %v", data, ok)
+	}
+}
+
+// TestKey 测试缓存键区分各部分的边界
+func TestKey(t *testing.T) {
+	if Key("ab", "c") == Key("a", "bc") {
+		t.Error("不同的输入应得到不同的键")
+	}
+	if Key("a", "b") != Key("a", "b") {
+		t.Error("相同的输入应得到相同的键")
+	}
+}
+
+// TestStatsAndClear 测试统计和清空只处理缓存条目
+func TestStatsAndClear(t *testing.T) {
+	dir := t.TempDir()
+	c := New(dir)
+	for _, value := range []string{"one", "three"} {
+		if err := c.Put(Key(value), []byte(value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("keep"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := c.Stats()
+	if err != nil || stats.Entries != 2 || stats.Size != 8 {
+		t.Errorf("统计不正确: %+v %v", stats, err)
+	}
+
+	removed, err := c.Clear()
+	if err != nil || removed != 2 {
+		t.Errorf("应删除2个条目: %d %v", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "notes.txt")); err != nil {
+		t.Error("不应删除其他文件")
+	}
+
+	stats, err = New(filepath.Join(dir, "missing")).Stats()
+	if err != nil || stats.Entries != 0 {
+		t.Errorf("目录不存在时应返回空统计: %+v %v", stats, err)
+	}
+}
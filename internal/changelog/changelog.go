@@ -0,0 +1,194 @@
+// Package changelog 按约定式提交的类型和作用域对版本范围内的提交分组，
+// 生成 Keep a Changelog 格式的更新日志
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/cite"
+	"github.com/MyceliumGrid/git-work-profile/internal/classify"
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/tickets"
+)
+
+// Unreleased 尚未发布的版本名称
+const Unreleased = "Unreleased"
+
+// Keep a Changelog 规定的分类，按输出顺序排列
+const (
+	SectionAdded      = "Added"
+	SectionChanged    = "Changed"
+	SectionDeprecated = "Deprecated"
+	SectionRemoved    = "Removed"
+	SectionFixed      = "Fixed"
+	SectionSecurity   = "Security"
+)
+
+// Sections 所有分类，按输出顺序排列
+var Sections = []string{SectionAdded, SectionChanged, SectionDeprecated, SectionRemoved, SectionFixed, SectionSecurity}
+
+// Entry 更新日志中的一条记录
+type Entry struct {
+	Hash        string
+	Scope       string
+	Description string
+	Breaking    bool
+	Tickets     []tickets.Reference
+}
+
+// Section 一个分类下的记录，按作用域排列
+type Section struct {
+	Title   string
+	Entries []Entry
+}
+
+// Changelog 一个版本的更新日志
+type Changelog struct {
+	Version  string
+	Date     time.Time
+	Remote   string // 仓库的网页地址，用于生成提交链接，为空表示不生成
+	Sections []Section
+	Breaking []Entry // 破坏性变更，同时出现在所属的分类中
+	Skipped  int     // 不面向用户而没有列出的提交数，如测试、文档和构建
+}
+
+var (
+	deprecatePattern = regexp.MustCompile(`(?i)^(deprecate|废弃|弃用)`)
+	removePattern    = regexp.MustCompile(`(?i)^(remove|drop|delete|删除|移除)`)
+	securityPattern  = regexp.MustCompile(`(?i)(^|[^a-z])(security|cve-\d+|vulnerab)|安全|漏洞`)
+)
+
+// SectionOf 返回提交所属的分类，不面向用户的提交（测试、文档、杂项、构建、代码格式和发布）返回空
+func SectionOf(c classify.Classification) string {
+	if c.Type == "style" {
+		return ""
+	}
+	switch c.Intent {
+	case classify.IntentFeature, classify.IntentFix, classify.IntentRefactor, classify.IntentPerf, classify.IntentRevert:
+	default:
+		return ""
+	}
+
+	switch {
+	case strings.EqualFold(c.Scope, "security") || securityPattern.MatchString(c.Description):
+		return SectionSecurity
+	case deprecatePattern.MatchString(c.Description):
+		return SectionDeprecated
+	case c.Intent == classify.IntentRevert || removePattern.MatchString(c.Description):
+		return SectionRemoved
+	case c.Intent == classify.IntentFeature:
+		return SectionAdded
+	case c.Intent == classify.IntentFix:
+		return SectionFixed
+	default:
+		return SectionChanged
+	}
+}
+
+// Build 生成更新日志，提交按时间从新到旧传入，每个分类中的记录按作用域排列，相同作用域保持提交顺序
+func Build(version string, date time.Time, commits []git.CommitInfo, extractor *tickets.Extractor) Changelog {
+	log := Changelog{Version: version, Date: date}
+	bySection := make(map[string][]Entry)
+	for _, commit := range commits {
+		c := classify.Classify(commit)
+		section := SectionOf(c)
+		if section == "" && !c.Breaking {
+			log.Skipped++
+			continue
+		}
+		if section == "" {
+			section = SectionChanged
+		}
+
+		entry := Entry{Hash: commit.Hash, Scope: c.Scope, Description: c.Description, Breaking: c.Breaking}
+		if extractor != nil {
+			entry.Tickets = extractor.Extract(commit)
+		}
+		bySection[section] = append(bySection[section], entry)
+		if entry.Breaking {
+			log.Breaking = append(log.Breaking, entry)
+		}
+	}
+
+	for _, title := range Sections {
+		entries := bySection[title]
+		if len(entries) == 0 {
+			continue
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Scope < entries[j].Scope
+		})
+		log.Sections = append(log.Sections, Section{Title: title, Entries: entries})
+	}
+	return log
+}
+
+// Empty 是否没有任何面向用户的记录
+func (c Changelog) Empty() bool {
+	return len(c.Sections) == 0
+}
+
+// Markdown 返回 Keep a Changelog 格式的版本小节，如 "## [1.3.0] - 2026-10-19"，
+// 未发布的版本不写日期
+func (c Changelog) Markdown() string {
+	var b strings.Builder
+	if c.Version == Unreleased || c.Date.IsZero() {
+		fmt.Fprintf(&b, "## [%s]\n", c.Version)
+	} else {
+		fmt.Fprintf(&b, "## [%s] - %s\n", c.Version, c.Date.Format("2006-01-02"))
+	}
+	for _, section := range c.Sections {
+		fmt.Fprintf(&b, "\n### %s\n\n", section.Title)
+		for _, entry := range section.Entries {
+			b.WriteString("- " + c.formatEntry(entry) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// formatEntry 格式化一条记录，如 "**BREAKING** **api:** Remove v1 endpoints ([a1b2c3d4](…), [#12](…))"
+func (c Changelog) formatEntry(entry Entry) string {
+	var b strings.Builder
+	if entry.Breaking {
+		b.WriteString("**BREAKING** ")
+	}
+	if entry.Scope != "" {
+		fmt.Fprintf(&b, "**%s:** ", entry.Scope)
+	}
+	b.WriteString(capitalize(entry.Description))
+
+	refs := []string{link(shortHash(entry.Hash), cite.CommitURL(c.Remote, entry.Hash))}
+	for _, ref := range entry.Tickets {
+		refs = append(refs, link(ref.ID, ref.URL))
+	}
+	fmt.Fprintf(&b, " (%s)", strings.Join(refs, ", "))
+	return b.String()
+}
+
+// link 返回 Markdown 链接，url 为空时只返回文本
+func link(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return "[" + text + "](" + url + ")"
+}
+
+// capitalize 将描述的首字母转为大写
+func capitalize(s string) string {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// shortHash 返回提交哈希的前8位
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
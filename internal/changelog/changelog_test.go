@@ -0,0 +1,90 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/classify"
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/tickets"
+)
+
+// TestSectionOf 测试提交类型对应的分类
+func TestSectionOf(t *testing.T) {
+	cases := map[string]string{
+		"feat(api): add pagination":      SectionAdded,
+		"fix: handle empty input":        SectionFixed,
+		"perf: cache lookups":            SectionChanged,
+		"refactor: remove legacy client": SectionRemoved,
+		"feat: deprecate v1 endpoints":   SectionDeprecated,
+		"fix(security): escape output":   SectionSecurity,
+		"fix: patch CVE-2026-1234":       SectionSecurity,
+		"revert: feat: add pagination":   SectionRemoved,
+		"docs: update README":            "",
+		"style: gofmt":                   "",
+		"chore(release): v1.3.0":         "",
+	}
+	for message, want := range cases {
+		c, _ := classify.ParseConventional(message)
+		if got := SectionOf(c); got != want {
+			t.Errorf("%q 的分类为 %q，应为 %q", message, got, want)
+		}
+	}
+}
+
+// TestBuild 测试分组、作用域排序、工单链接和破坏性变更
+func TestBuild(t *testing.T) {
+	commits := []git.CommitInfo{
+		{Hash: "aaaaaaaaaaaa", Message: "feat(ui): add dark mode"},
+		{Hash: "bbbbbbbbbbbb", Message: "fix: crash on startup, fixes #12"},
+		{Hash: "cccccccccccc", Message: "feat(api)!: remove v1 endpoints"},
+		{Hash: "dddddddddddd", Message: "feat(api): add pagination"},
+		{Hash: "eeeeeeeeeeee", Message: "test: cover parser"},
+		{Hash: "ffffffffffff", Message: "chore: bump deps", Body: "BREAKING CHANGE: requires Go 1.24"},
+	}
+	extractor := tickets.NewExtractor(tickets.DefaultPatterns(), map[string]string{tickets.TrackerGitHub: "https://github.com/acme/app/issues/{number}"})
+	log := Build("v1.3.0", time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), commits, extractor)
+	log.Remote = "https://github.com/acme/app"
+
+	if log.Skipped != 1 {
+		t.Errorf("应跳过1个测试提交: %d", log.Skipped)
+	}
+	if len(log.Breaking) != 2 {
+		t.Errorf("应有2个破坏性变更: %+v", log.Breaking)
+	}
+
+	want := `## [v1.3.0] - 2026-10-19
+
+### Added
+
+- **api:** Add pagination ([dddddddd](https://github.com/acme/app/commit/dddddddddddd))
+- **ui:** Add dark mode ([aaaaaaaa](https://github.com/acme/app/commit/aaaaaaaaaaaa))
+
+### Changed
+
+- **BREAKING** Bump deps ([ffffffff](https://github.com/acme/app/commit/ffffffffffff))
+
+### Removed
+
+- **BREAKING** **api:** Remove v1 endpoints ([cccccccc](https://github.com/acme/app/commit/cccccccccccc))
+
+### Fixed
+
+- Crash on startup, fixes #12 ([bbbbbbbb](https://github.com/acme/app/commit/bbbbbbbbbbbb), [#12](https://github.com/acme/app/issues/12))
+`
+	if got := log.Markdown(); got != want {
+		t.Errorf("更新日志不正确:\n%s\n应为:\n%s", got, want)
+	}
+}
+
+// TestUnreleased 测试未发布的版本不写日期
+func TestUnreleased(t *testing.T) {
+	log := Build(Unreleased, time.Now(), []git.CommitInfo{{Hash: "abc", Message: "docs: typo"}}, nil)
+	if !log.Empty() {
+		t.Error("只有文档提交时应为空")
+	}
+	if got := log.Markdown(); !strings.HasPrefix(got, "## [Unreleased]\n") {
+		t.Errorf("未发布的版本不应写日期: %q", got)
+	}
+}
@@ -0,0 +1,240 @@
+// Package cite 解析AI分析结果中以 [a1b2c3d4] 形式引用的提交哈希，
+// 根据收集的提交进行校验，并生成提交链接和按章节划分的证据映射
+package cite
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// ShortLength 报告中显示的短哈希长度，与提示词中的哈希长度一致
+const ShortLength = 8
+
+// IntroSection 第一个标题之前的内容所属的章节
+const IntroSection = "introduction"
+
+// 引用的匹配规则：方括号中一个或多个以逗号分隔的 7-40 位十六进制哈希
+var (
+	citationPattern = regexp.MustCompile(`\[\s*([0-9a-fA-F]{7,40}(?:\s*[,，;；、]\s*[0-9a-fA-F]{7,40})*)\s*\]`)
+	hashPattern     = regexp.MustCompile(`[0-9a-fA-F]{7,40}`)
+	headingPattern  = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+	linkedPattern   = regexp.MustCompile(`\s*\((?:\[[0-9a-f]{7,40}\]\([^)\s]+\)(?:, )?)+\)`)
+)
+
+// Citation 分析结果中引用的一个提交
+type Citation struct {
+	Hash     string    `json:"hash"`
+	Short    string    `json:"short"`
+	Repo     string    `json:"repo"`
+	Date     time.Time `json:"date"`
+	Subject  string    `json:"subject"`
+	URL      string    `json:"url,omitempty"`
+	Sections []string  `json:"sections"` // 引用该提交的章节
+}
+
+// Result 引用的解析结果
+type Result struct {
+	Citations []Citation          `json:"citations"`         // 有效的引用，按首次出现的顺序
+	Invalid   []string            `json:"invalid,omitempty"` // 在收集的提交中找不到或有歧义的哈希
+	Evidence  map[string][]string `json:"evidence"`          // 章节标题到所引用提交完整哈希的映射
+}
+
+// Resolve 解析分析结果中的引用，按前缀匹配收集的提交。
+// remotes 为仓库路径到网页地址的映射，已知时为引用生成提交链接
+func Resolve(analysis string, commits []git.CommitInfo, remotes map[string]string) *Result {
+	result := &Result{Citations: []Citation{}, Evidence: make(map[string][]string)}
+	index := make(map[string]int) // 完整哈希到 Citations 下标
+	invalid := make(map[string]bool)
+
+	section := IntroSection
+	for _, line := range strings.Split(analysis, "\n") {
+		if m := headingPattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			section = stripCitations(m[1])
+		}
+		for _, group := range citationPattern.FindAllStringSubmatch(line, -1) {
+			for _, prefix := range hashPattern.FindAllString(group[1], -1) {
+				commit, ok := match(prefix, commits)
+				if !ok {
+					if !isNumeric(prefix) && !invalid[prefix] {
+						invalid[prefix] = true
+						result.Invalid = append(result.Invalid, prefix)
+					}
+					continue
+				}
+
+				i, seen := index[commit.Hash]
+				if !seen {
+					i = len(result.Citations)
+					index[commit.Hash] = i
+					subject, _, _ := strings.Cut(commit.Message, "\n")
+					result.Citations = append(result.Citations, Citation{
+						Hash:     commit.Hash,
+						Short:    short(commit.Hash),
+						Repo:     filepath.Base(commit.RepoPath),
+						Date:     commit.Date,
+						Subject:  strings.TrimSpace(subject),
+						URL:      CommitURL(remotes[commit.RepoPath], commit.Hash),
+						Sections: []string{},
+					})
+				}
+				citation := &result.Citations[i]
+				if !contains(citation.Sections, section) {
+					citation.Sections = append(citation.Sections, section)
+					result.Evidence[section] = append(result.Evidence[section], commit.Hash)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Clean 规范化分析结果中的引用：有效的哈希统一为短哈希，无效的哈希被删除，
+// 全部无效的引用连同前面的空格一起删除。只由数字组成的方括号内容（可能是日期等）保持原样
+func Clean(analysis string, result *Result) string {
+	return replaceCitations(analysis, func(hashes []string, original string) string {
+		var kept []string
+		for _, prefix := range hashes {
+			if citation := result.find(prefix); citation != nil {
+				if !contains(kept, citation.Short) {
+					kept = append(kept, citation.Short)
+				}
+			} else if isNumeric(prefix) {
+				return original
+			}
+		}
+		if len(kept) == 0 {
+			return ""
+		}
+		return "[" + strings.Join(kept, ", ") + "]"
+	})
+}
+
+// Link 将引用转换为 Markdown 链接，如 ([a1b2c3d4](https://…/commit/a1b2c3d4))。
+// 只有全部哈希都有链接时才转换，否则保持 [a1b2c3d4] 原样
+func Link(analysis string, result *Result) string {
+	return replaceCitations(analysis, func(hashes []string, original string) string {
+		links := make([]string, 0, len(hashes))
+		for _, prefix := range hashes {
+			citation := result.find(prefix)
+			if citation == nil || citation.URL == "" {
+				return original
+			}
+			links = append(links, "["+citation.Short+"]("+citation.URL+")")
+		}
+		return " (" + strings.Join(links, ", ") + ")"
+	})
+}
+
+// Mask 将引用替换为等长的空格，用于在不改变位置的情况下排除哈希对声明核查的干扰
+func Mask(analysis string) string {
+	return citationPattern.ReplaceAllStringFunc(analysis, func(s string) string {
+		return strings.Repeat(" ", len(s))
+	})
+}
+
+// Strip 删除分析结果中的引用和引用链接，用于简历等不需要引用的输出
+func Strip(analysis string) string {
+	analysis = linkedPattern.ReplaceAllString(analysis, "")
+	return replaceCitations(analysis, func(hashes []string, original string) string {
+		for _, prefix := range hashes {
+			if isNumeric(prefix) {
+				return original
+			}
+		}
+		return ""
+	})
+}
+
+// stripCitations 删除标题中的引用
+func stripCitations(text string) string {
+	return strings.TrimSpace(citationPattern.ReplaceAllString(text, ""))
+}
+
+// CommitURL 返回提交的网页地址，remote 为仓库的网页地址，为空时返回空
+func CommitURL(remote, hash string) string {
+	if remote == "" {
+		return ""
+	}
+	// Bitbucket 的提交页面路径为 commits
+	if strings.Contains(remote, "bitbucket.org") {
+		return remote + "/commits/" + hash
+	}
+	return remote + "/commit/" + hash
+}
+
+// replaceCitations 替换分析结果中的每个引用，replace 返回空字符串时同时删除引用前的空格
+func replaceCitations(analysis string, replace func(hashes []string, original string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range citationPattern.FindAllStringSubmatchIndex(analysis, -1) {
+		start, end := loc[0], loc[1]
+		// 已经是链接的方括号，如 [a1b2c3d4](url)
+		if end < len(analysis) && analysis[end] == '(' {
+			continue
+		}
+
+		original := analysis[start:end]
+		replacement := replace(hashPattern.FindAllString(analysis[loc[2]:loc[3]], -1), original)
+		prefix := analysis[last:start]
+		if replacement == "" || strings.HasPrefix(replacement, " ") {
+			prefix = strings.TrimRight(prefix, " ")
+		}
+		b.WriteString(prefix)
+		b.WriteString(replacement)
+		last = end
+	}
+	b.WriteString(analysis[last:])
+	return b.String()
+}
+
+// find 按哈希前缀查找有效的引用
+func (r *Result) find(prefix string) *Citation {
+	prefix = strings.ToLower(prefix)
+	for i := range r.Citations {
+		if strings.HasPrefix(r.Citations[i].Hash, prefix) {
+			return &r.Citations[i]
+		}
+	}
+	return nil
+}
+
+// match 按前缀查找唯一的提交，多个提交共享前缀时视为无法确定
+func match(prefix string, commits []git.CommitInfo) (git.CommitInfo, bool) {
+	prefix = strings.ToLower(prefix)
+	var found git.CommitInfo
+	count := 0
+	for _, commit := range commits {
+		if strings.HasPrefix(strings.ToLower(commit.Hash), prefix) && commit.Hash != found.Hash {
+			found = commit
+			count++
+		}
+	}
+	return found, count == 1
+}
+
+// short 返回短哈希
+func short(hash string) string {
+	if len(hash) > ShortLength {
+		return hash[:ShortLength]
+	}
+	return hash
+}
+
+// isNumeric 判断字符串是否只由数字组成
+func isNumeric(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
+
+// contains 判断列表中是否包含指定值
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
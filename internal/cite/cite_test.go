@@ -0,0 +1,113 @@
+package cite
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// testCommits 测试用的提交记录
+func testCommits() []git.CommitInfo {
+	return []git.CommitInfo{
+		{Hash: "a1b2c3d4e5f60718293a4b5c6d7e8f9012345678", Date: time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC), Message: "feat: migrate to gRPC", RepoPath: "/work/api"},
+		{Hash: "b2c3d4e5f60718293a4b5c6d7e8f901234567890", Date: time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC), Message: "fix: retry", RepoPath: "/work/web"},
+		{Hash: "ccc00001aaaa", Message: "one", RepoPath: "/work/web"},
+		{Hash: "ccc00002bbbb", Message: "two", RepoPath: "/work/web"},
+	}
+}
+
+const testAnalysis = `总体来看是后端开发者 [a1b2c3d4]。
+
+## 技术栈
+- 主导迁移到 gRPC [a1b2c3d4, B2C3D4E5F6]
+- 编造的提交 [deadbeef12]
+- 有歧义的提交 [ccc0000]
+- 日期 [20240305] 保持原样
+
+## 成长
+- 重试机制 [b2c3d4e5f6071829]
+`
+
+// TestResolve 测试引用的校验和按章节的证据映射
+func TestResolve(t *testing.T) {
+	result := Resolve(testAnalysis, testCommits(), map[string]string{"/work/api": "https://github.com/acme/api"})
+
+	if len(result.Citations) != 2 {
+		t.Fatalf("应有2个有效引用, 得到: %+v", result.Citations)
+	}
+	first := result.Citations[0]
+	if first.Short != "a1b2c3d4" || first.Repo != "api" || first.URL != "https://github.com/acme/api/commit/"+first.Hash {
+		t.Errorf("引用信息不正确: %+v", first)
+	}
+	if strings.Join(first.Sections, ",") != IntroSection+",技术栈" {
+		t.Errorf("引用章节不正确: %v", first.Sections)
+	}
+	if result.Citations[1].URL != "" {
+		t.Errorf("没有远程地址时不应生成链接: %+v", result.Citations[1])
+	}
+
+	if strings.Join(result.Invalid, ",") != "deadbeef12,ccc0000" {
+		t.Errorf("无效哈希不正确: %v", result.Invalid)
+	}
+	if len(result.Evidence["技术栈"]) != 2 || len(result.Evidence["成长"]) != 1 || len(result.Evidence[IntroSection]) != 1 {
+		t.Errorf("证据映射不正确: %v", result.Evidence)
+	}
+}
+
+// TestCleanAndLink 测试删除无效引用、统一短哈希并转换为链接
+func TestCleanAndLink(t *testing.T) {
+	result := Resolve(testAnalysis, testCommits(), map[string]string{"/work/api": "https://github.com/acme/api"})
+	cleaned := Clean(testAnalysis, result)
+
+	for _, want := range []string{
+		"- 主导迁移到 gRPC [a1b2c3d4, b2c3d4e5]\n",
+		"- 编造的提交\n",
+		"- 有歧义的提交\n",
+		"- 日期 [20240305] 保持原样\n",
+		"- 重试机制 [b2c3d4e5]\n",
+	} {
+		if !strings.Contains(cleaned, want) {
+			t.Errorf("清理后应包含 %q:\n%s", want, cleaned)
+		}
+	}
+
+	linked := Link(cleaned, result)
+	if !strings.Contains(linked, "后端开发者 ([a1b2c3d4](https://github.com/acme/api/commit/a1b2c3d4e5f60718293a4b5c6d7e8f9012345678))。") {
+		t.Errorf("有远程地址的引用应转换为链接:\n%s", linked)
+	}
+	if !strings.Contains(linked, "gRPC [a1b2c3d4, b2c3d4e5]") {
+		t.Errorf("部分哈希没有链接时应保持原样:\n%s", linked)
+	}
+
+	stripped := Strip(linked)
+	if strings.Contains(stripped, "a1b2c3d4") || strings.Contains(stripped, "b2c3d4e5") || !strings.Contains(stripped, "后端开发者。") {
+		t.Errorf("应删除所有引用:\n%s", stripped)
+	}
+	if !strings.Contains(stripped, "[20240305]") {
+		t.Errorf("数字内容应保持原样:\n%s", stripped)
+	}
+}
+
+// TestMask 测试遮盖引用后位置不变
+func TestMask(t *testing.T) {
+	text := "2024 年迁移 [2024abcd] 完成"
+	masked := Mask(text)
+	if len(masked) != len(text) || strings.Contains(masked, "abcd") || !strings.HasPrefix(masked, "2024 年迁移 ") {
+		t.Errorf("遮盖结果不正确: %q", masked)
+	}
+}
+
+// TestCommitURL 测试不同托管平台的提交链接
+func TestCommitURL(t *testing.T) {
+	if got := CommitURL("https://gitlab.com/g/app", "abc"); got != "https://gitlab.com/g/app/commit/abc" {
+		t.Errorf("提交链接不正确: %s", got)
+	}
+	if got := CommitURL("https://bitbucket.org/acme/app", "abc"); got != "https://bitbucket.org/acme/app/commits/abc" {
+		t.Errorf("Bitbucket 提交链接不正确: %s", got)
+	}
+	if CommitURL("", "abc") != "" {
+		t.Error("没有远程地址时应返回空")
+	}
+}
@@ -0,0 +1,266 @@
+package classify
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// Intent 表示提交的意图
+type Intent string
+
+const (
+	// IntentFeature 新功能
+	IntentFeature Intent = "feature"
+	// IntentFix 缺陷修复
+	IntentFix Intent = "fix"
+	// IntentRefactor 重构
+	IntentRefactor Intent = "refactor"
+	// IntentPerf 性能优化
+	IntentPerf Intent = "perf"
+	// IntentTest 测试
+	IntentTest Intent = "test"
+	// IntentDocs 文档
+	IntentDocs Intent = "docs"
+	// IntentChore 杂项维护
+	IntentChore Intent = "chore"
+	// IntentCI 持续集成和构建
+	IntentCI Intent = "ci"
+	// IntentRevert 回滚
+	IntentRevert Intent = "revert"
+	// IntentRelease 发布
+	IntentRelease Intent = "release"
+)
+
+// Intents 所有意图，按固定顺序排列
+var Intents = []Intent{
+	IntentFeature, IntentFix, IntentRefactor, IntentPerf, IntentTest,
+	IntentDocs, IntentChore, IntentCI, IntentRevert, IntentRelease,
+}
+
+// Classification 单个提交的分类结果
+type Classification struct {
+	Intent       Intent `json:"intent"`
+	Type         string `json:"type,omitempty"`  // 约定式提交的原始类型，如 feat
+	Scope        string `json:"scope,omitempty"` // 约定式提交的作用域
+	Breaking     bool   `json:"breaking"`        // 是否为破坏性变更
+	Conventional bool   `json:"conventional"`    // 是否符合约定式提交规范
+	Description  string `json:"description"`     // 去除前缀后的描述
+}
+
+// conventionalPattern 匹配约定式提交前缀，如 "feat(api)!: 描述"
+var conventionalPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?\s*[:：]\s*(.*)$`)
+
+// conventionalTypes 约定式提交类型到意图的映射
+var conventionalTypes = map[string]Intent{
+	"feat":     IntentFeature,
+	"feature":  IntentFeature,
+	"fix":      IntentFix,
+	"bugfix":   IntentFix,
+	"hotfix":   IntentFix,
+	"refactor": IntentRefactor,
+	"style":    IntentRefactor,
+	"perf":     IntentPerf,
+	"test":     IntentTest,
+	"tests":    IntentTest,
+	"docs":     IntentDocs,
+	"doc":      IntentDocs,
+	"chore":    IntentChore,
+	"wip":      IntentChore,
+	"deps":     IntentChore,
+	"ci":       IntentCI,
+	"build":    IntentCI,
+	"revert":   IntentRevert,
+	"release":  IntentRelease,
+}
+
+// ParseConventional 解析约定式提交前缀，不符合规范时返回 false
+func ParseConventional(message string) (Classification, bool) {
+	match := conventionalPattern.FindStringSubmatch(strings.TrimSpace(message))
+	if match == nil {
+		return Classification{}, false
+	}
+
+	commitType := strings.ToLower(match[1])
+	intent, ok := conventionalTypes[commitType]
+	if !ok {
+		return Classification{}, false
+	}
+
+	return Classification{
+		Intent:       intent,
+		Type:         commitType,
+		Scope:        strings.TrimSpace(match[2]),
+		Breaking:     match[3] == "!",
+		Conventional: true,
+		Description:  strings.TrimSpace(match[4]),
+	}, true
+}
+
+// Classify 对提交进行分类，优先解析约定式提交前缀，否则使用启发式规则
+func Classify(commit git.CommitInfo) Classification {
+	// 正文中的 BREAKING CHANGE 脚注同样表示破坏性变更
+	breaking := strings.Contains(commit.Body, "BREAKING CHANGE") || strings.Contains(commit.Body, "BREAKING-CHANGE")
+
+	if c, ok := ParseConventional(commit.Message); ok {
+		c.Breaking = c.Breaking || breaking
+		return c
+	}
+
+	return Classification{
+		Intent:      classifyHeuristic(commit),
+		Breaking:    breaking,
+		Description: strings.TrimSpace(commit.Message),
+	}
+}
+
+// heuristicRules 基于提交消息关键字的分类规则，按优先级排列
+var heuristicRules = []struct {
+	intent  Intent
+	pattern *regexp.Regexp
+}{
+	{IntentRevert, regexp.MustCompile(`(?i)^revert\b|回滚|还原`)},
+	{IntentRelease, regexp.MustCompile(`(?i)^(release|bump version)\b|^v?\d+\.\d+\.\d+$|^(发布|发版)`)},
+	{IntentChore, regexp.MustCompile(`(?i)^merge (branch|pull request|remote-tracking)\b`)},
+	// 单独出现的 issue 或"问题"不算修复（如 "add issue templates"），只识别修复类的说法
+	{IntentFix, regexp.MustCompile(`(?i)\b(fix(es|ed)?|bug|hotfix|patch|crash|resolve[sd]?)\b|\bclose[sd]? +(issue +)?#\d+|修复|修正|(解决|处理).{0,10}问题|缺陷`)},
+	{IntentPerf, regexp.MustCompile(`(?i)\b(perf|performance|optimi[sz]e[sd]?|speed ?up|faster|latency|cache)\b|性能|优化|提速`)},
+	{IntentRefactor, regexp.MustCompile(`(?i)\b(refactor(ed|ing)?|restructure|clean ?up|rename[sd]?|simplif(y|ied)|reorgani[sz]e)\b|重构|整理|清理`)},
+	{IntentTest, regexp.MustCompile(`(?i)\b(tests?|testing|unit test|e2e|coverage)\b|测试|单测`)},
+	{IntentDocs, regexp.MustCompile(`(?i)\b(docs?|documentation|readme|changelog|comments?)\b|文档|注释|说明`)},
+	{IntentCI, regexp.MustCompile(`(?i)\b(ci|cd|pipeline|workflow|github actions|jenkins|dockerfile|build script|lint)\b|流水线|构建`)},
+	{IntentFeature, regexp.MustCompile(`(?i)\b(add(s|ed)?|implement(s|ed)?|introduce[sd]?|support(s|ed)?|new|create[sd]?|feature|enable[sd]?)\b|新增|添加|实现|支持|功能`)},
+	{IntentChore, regexp.MustCompile(`(?i)\b(chore|deps?|dependenc(y|ies)|upgrade[sd]?|bump|update[sd]? (deps|dependencies)|config)\b|依赖|升级|配置`)},
+}
+
+// classifyHeuristic 使用提交消息和变更文件推断提交意图
+func classifyHeuristic(commit git.CommitInfo) Intent {
+	// 变更文件全部属于同一类别时，以文件类别为准
+	if intent, ok := classifyByFiles(commit.ChangedFiles); ok {
+		return intent
+	}
+
+	message := strings.TrimSpace(commit.Message)
+	for _, rule := range heuristicRules {
+		if rule.pattern.MatchString(message) {
+			return rule.intent
+		}
+	}
+
+	// 无法判断时，有新增代码视为功能开发，否则视为维护
+	if commit.LinesAdded > commit.LinesDeleted {
+		return IntentFeature
+	}
+	return IntentChore
+}
+
+// classifyByFiles 根据变更文件判断提交意图，只有全部文件属于同一类别时才返回结果
+func classifyByFiles(files []string) (Intent, bool) {
+	if len(files) == 0 {
+		return "", false
+	}
+
+	var intent Intent
+	for _, file := range files {
+		current, ok := fileIntent(file)
+		if !ok || (intent != "" && current != intent) {
+			return "", false
+		}
+		intent = current
+	}
+	return intent, true
+}
+
+// fileIntent 判断单个文件所属的类别
+func fileIntent(file string) (Intent, bool) {
+	lower := strings.ToLower(filepath.ToSlash(file))
+	base := filepath.Base(lower)
+	switch {
+	case strings.HasPrefix(lower, ".github/workflows/") || strings.HasPrefix(lower, ".gitlab-ci") ||
+		base == "jenkinsfile" || base == ".travis.yml" || base == ".golangci.yml" || base == ".goreleaser.yml":
+		return IntentCI, true
+	case strings.HasSuffix(base, "_test.go") || strings.Contains(base, ".test.") || strings.Contains(base, ".spec.") ||
+		strings.HasPrefix(base, "test_") || strings.Contains(lower, "/__tests__/") || strings.HasPrefix(lower, "tests/"):
+		return IntentTest, true
+	case strings.HasSuffix(base, ".md") || strings.HasSuffix(base, ".rst") || strings.HasPrefix(lower, "docs/"):
+		return IntentDocs, true
+	}
+	return "", false
+}
+
+// Breakdown 提交意图的统计结果
+type Breakdown struct {
+	Counts       map[Intent]int `json:"counts"`
+	Total        int            `json:"total"`
+	Conventional int            `json:"conventional"` // 符合约定式提交规范的提交数
+	Breaking     int            `json:"breaking"`     // 破坏性变更数
+	Scopes       map[string]int `json:"scopes"`       // 约定式提交作用域的分布
+}
+
+// Summarize 统计提交意图的分布
+func Summarize(commits []git.CommitInfo) Breakdown {
+	breakdown := Breakdown{
+		Counts: make(map[Intent]int),
+		Total:  len(commits),
+		Scopes: make(map[string]int),
+	}
+
+	for _, commit := range commits {
+		c := Classify(commit)
+		breakdown.Counts[c.Intent]++
+		if c.Conventional {
+			breakdown.Conventional++
+		}
+		if c.Breaking {
+			breakdown.Breaking++
+		}
+		if c.Scope != "" {
+			breakdown.Scopes[c.Scope]++
+		}
+	}
+
+	return breakdown
+}
+
+// Ratio 返回指定意图的占比
+func (b Breakdown) Ratio(intent Intent) float64 {
+	if b.Total == 0 {
+		return 0
+	}
+	return float64(b.Counts[intent]) / float64(b.Total)
+}
+
+// ConventionalRatio 返回符合约定式提交规范的提交占比
+func (b Breakdown) ConventionalRatio() float64 {
+	if b.Total == 0 {
+		return 0
+	}
+	return float64(b.Conventional) / float64(b.Total)
+}
+
+// Sorted 返回按数量从高到低排列的意图，数量为零的意图不包含在内
+func (b Breakdown) Sorted() []Intent {
+	var intents []Intent
+	for _, intent := range Intents {
+		if b.Counts[intent] > 0 {
+			intents = append(intents, intent)
+		}
+	}
+	sort.SliceStable(intents, func(i, j int) bool {
+		return b.Counts[intents[i]] > b.Counts[intents[j]]
+	})
+	return intents
+}
+
+// String 将统计结果格式化为单行文本，如 "feature 40.0% (20), fix 30.0% (15)"
+func (b Breakdown) String() string {
+	parts := make([]string, 0, len(b.Counts))
+	for _, intent := range b.Sorted() {
+		parts = append(parts, fmt.Sprintf("%s %.1f%% (%d)", intent, b.Ratio(intent)*100, b.Counts[intent]))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,91 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// TestParseConventional 测试解析约定式提交前缀
+func TestParseConventional(t *testing.T) {
+	tests := []struct {
+		message  string
+		ok       bool
+		intent   Intent
+		scope    string
+		breaking bool
+	}{
+		{"feat(api): add refund endpoint", true, IntentFeature, "api", false},
+		{"fix!: drop legacy token format", true, IntentFix, "", true},
+		{"refactor(core)!: split config loader", true, IntentRefactor, "core", true},
+		{"docs：更新安装说明", true, IntentDocs, "", false},
+		{"build(deps): bump x/net", true, IntentCI, "deps", false},
+		{"Fix login redirect", false, "", "", false},
+		{"unknown: something", false, "", "", false},
+	}
+
+	for _, test := range tests {
+		c, ok := ParseConventional(test.message)
+		if ok != test.ok {
+			t.Errorf("%q: 是否为约定式提交应为 %v, 得到 %v", test.message, test.ok, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if c.Intent != test.intent || c.Scope != test.scope || c.Breaking != test.breaking {
+			t.Errorf("%q: 期望 %s/%s/%v, 得到 %s/%s/%v", test.message, test.intent, test.scope, test.breaking, c.Intent, c.Scope, c.Breaking)
+		}
+	}
+}
+
+// TestClassifyHeuristic 测试非约定式提交的启发式分类
+func TestClassifyHeuristic(t *testing.T) {
+	tests := []struct {
+		commit git.CommitInfo
+		intent Intent
+	}{
+		{git.CommitInfo{Message: "Fix crash when config is missing"}, IntentFix},
+		{git.CommitInfo{Message: "修复登录跳转问题"}, IntentFix},
+		{git.CommitInfo{Message: "Resolve issue with empty diffs"}, IntentFix},
+		{git.CommitInfo{Message: "Handle empty config, closes #42"}, IntentFix},
+		{git.CommitInfo{Message: "Add issue templates"}, IntentFeature},
+		{git.CommitInfo{Message: "Link issue tracker in footer", LinesAdded: 1, LinesDeleted: 1}, IntentChore},
+		{git.CommitInfo{Message: "Implement CSV export"}, IntentFeature},
+		{git.CommitInfo{Message: "Speed up report rendering"}, IntentPerf},
+		{git.CommitInfo{Message: "Revert \"Add cache\""}, IntentRevert},
+		{git.CommitInfo{Message: "v1.4.0"}, IntentRelease},
+		{git.CommitInfo{Message: "Merge branch 'main' into feature"}, IntentChore},
+		{git.CommitInfo{Message: "more work", ChangedFiles: []string{"internal/git/git_test.go"}}, IntentTest},
+		{git.CommitInfo{Message: "wording", ChangedFiles: []string{"README.md", "docs/usage.md"}}, IntentDocs},
+		{git.CommitInfo{Message: "tweak", ChangedFiles: []string{".github/workflows/ci.yml"}}, IntentCI},
+		{git.CommitInfo{Message: "wip", LinesAdded: 120, LinesDeleted: 3}, IntentFeature},
+	}
+
+	for _, test := range tests {
+		if c := Classify(test.commit); c.Intent != test.intent {
+			t.Errorf("%q: 意图应为 %s, 得到 %s", test.commit.Message, test.intent, c.Intent)
+		}
+	}
+}
+
+// TestSummarize 测试意图统计
+func TestSummarize(t *testing.T) {
+	commits := []git.CommitInfo{
+		{Message: "feat: a"},
+		{Message: "feat(ui)!: b"},
+		{Message: "fix: c"},
+		{Message: "Fix typo in handler"},
+	}
+
+	breakdown := Summarize(commits)
+	if breakdown.Counts[IntentFeature] != 2 || breakdown.Counts[IntentFix] != 2 {
+		t.Errorf("意图统计不正确: %v", breakdown.Counts)
+	}
+	if breakdown.Conventional != 3 || breakdown.Breaking != 1 || breakdown.Scopes["ui"] != 1 {
+		t.Errorf("约定式提交统计不正确: %+v", breakdown)
+	}
+	if got := breakdown.String(); got != "feature 50.0% (2), fix 50.0% (2)" {
+		t.Errorf("格式化结果不正确: %s", got)
+	}
+}
@@ -0,0 +1,482 @@
+package cluster
+
+import (
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// 聚类的默认参数
+const (
+	// DefaultThreshold 提交加入已有工作流所需的最低相似度
+	DefaultThreshold = 0.35
+	// DefaultHalfLife 时间相关度衰减一半所需的时长
+	DefaultHalfLife = 14 * 24 * time.Hour
+	// DefaultMinSize 工作流至少包含的提交数
+	DefaultMinSize = 3
+	// DefaultMaxWorkstreams 最多输出的工作流数量
+	DefaultMaxWorkstreams = 10
+	// maxRepresentatives 每个工作流的代表性提交数
+	maxRepresentatives = 3
+)
+
+// 各类特征在相似度中的权重
+const (
+	weightText   = 0.45
+	weightPath   = 0.35
+	weightBranch = 0.1
+	weightRepo   = 0.1
+)
+
+// Workstream 一组主题相关的提交，代表一项工作或一个主要任务
+type Workstream struct {
+	Label           string           `json:"label"`
+	Keywords        []string         `json:"keywords"`
+	From            time.Time        `json:"from"`
+	To              time.Time        `json:"to"`
+	Repos           []string         `json:"repos"`
+	Branches        []string         `json:"branches"`
+	CommitCount     int              `json:"commit_count"`
+	Representatives []git.CommitInfo `json:"representative_commits"`
+	Hashes          []string         `json:"hashes"`
+}
+
+// Options 聚类选项
+type Options struct {
+	Threshold      float64              // 加入工作流的最低相似度
+	HalfLife       time.Duration        // 时间衰减半衰期
+	MinSize        int                  // 工作流最少提交数
+	MaxWorkstreams int                  // 最多输出的工作流数量
+	Embeddings     map[string][]float32 // 可选的提交消息向量，键为提交哈希
+}
+
+// DefaultOptions 返回默认的聚类选项
+func DefaultOptions() Options {
+	return Options{
+		Threshold:      DefaultThreshold,
+		HalfLife:       DefaultHalfLife,
+		MinSize:        DefaultMinSize,
+		MaxWorkstreams: DefaultMaxWorkstreams,
+	}
+}
+
+// features 单个提交或工作流的特征
+type features struct {
+	text      map[string]float64
+	paths     map[string]float64
+	branches  map[string]float64
+	repos     map[string]float64
+	embedding []float32
+}
+
+// group 聚类过程中的工作流
+type group struct {
+	centroid features
+	members  []int
+	last     time.Time
+}
+
+// Cluster 根据提交消息、变更路径、时间接近程度和分支名称将提交聚类为工作流
+func Cluster(commits []git.CommitInfo, opts Options) []Workstream {
+	if opts.Threshold <= 0 {
+		opts.Threshold = DefaultThreshold
+	}
+	if opts.HalfLife <= 0 {
+		opts.HalfLife = DefaultHalfLife
+	}
+	if opts.MinSize <= 0 {
+		opts.MinSize = DefaultMinSize
+	}
+	if opts.MaxWorkstreams <= 0 {
+		opts.MaxWorkstreams = DefaultMaxWorkstreams
+	}
+
+	// 按时间顺序处理提交
+	order := make([]int, len(commits))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return commits[order[i]].Date.Before(commits[order[j]].Date)
+	})
+
+	commitFeatures := make([]features, len(commits))
+	for i, commit := range commits {
+		commitFeatures[i] = extractFeatures(commit, opts.Embeddings[commit.Hash])
+	}
+
+	var groups []*group
+	for _, i := range order {
+		commit := commits[i]
+		best, bestScore := -1, 0.0
+		for j, g := range groups {
+			score := similarity(commitFeatures[i], g.centroid) * timeDecay(commit.Date.Sub(g.last), opts.HalfLife)
+			if score > bestScore {
+				best, bestScore = j, score
+			}
+		}
+
+		if best >= 0 && bestScore >= opts.Threshold {
+			g := groups[best]
+			g.members = append(g.members, i)
+			g.centroid = merge(g.centroid, commitFeatures[i], len(g.members))
+			if commit.Date.After(g.last) {
+				g.last = commit.Date
+			}
+			continue
+		}
+
+		groups = append(groups, &group{
+			centroid: commitFeatures[i],
+			members:  []int{i},
+			last:     commit.Date,
+		})
+	}
+
+	var workstreams []Workstream
+	for _, g := range groups {
+		if len(g.members) < opts.MinSize {
+			continue
+		}
+		workstreams = append(workstreams, buildWorkstream(commits, commitFeatures, g))
+	}
+
+	// 按提交数量排序，数量相同时按开始时间排序
+	sort.SliceStable(workstreams, func(i, j int) bool {
+		if workstreams[i].CommitCount != workstreams[j].CommitCount {
+			return workstreams[i].CommitCount > workstreams[j].CommitCount
+		}
+		return workstreams[i].From.Before(workstreams[j].From)
+	})
+
+	if len(workstreams) > opts.MaxWorkstreams {
+		workstreams = workstreams[:opts.MaxWorkstreams]
+	}
+
+	return workstreams
+}
+
+// buildWorkstream 根据聚类结果生成工作流的描述
+func buildWorkstream(commits []git.CommitInfo, commitFeatures []features, g *group) Workstream {
+	ws := Workstream{CommitCount: len(g.members)}
+
+	repoCounts := make(map[string]int)
+	branchCounts := make(map[string]int)
+	for _, i := range g.members {
+		commit := commits[i]
+		if ws.From.IsZero() || commit.Date.Before(ws.From) {
+			ws.From = commit.Date
+		}
+		if commit.Date.After(ws.To) {
+			ws.To = commit.Date
+		}
+		if commit.RepoPath != "" {
+			repoCounts[filepath.Base(commit.RepoPath)]++
+		}
+		for _, branch := range commit.Branches {
+			if !isMainBranch(branch) {
+				branchCounts[branch]++
+			}
+		}
+		ws.Hashes = append(ws.Hashes, commit.Hash)
+	}
+
+	ws.Repos = topKeys(repoCounts, len(repoCounts))
+	ws.Branches = topKeys(branchCounts, 3)
+
+	// 关键词取自消息和路径中权重最高的词
+	keywordWeights := make(map[string]float64)
+	for token, weight := range g.centroid.text {
+		keywordWeights[token] += weight
+	}
+	for token, weight := range g.centroid.paths {
+		keywordWeights[token] += weight * 0.8
+	}
+	ws.Keywords = topWeighted(keywordWeights, 3)
+	ws.Label = buildLabel(ws)
+
+	// 代表性提交为与工作流中心最相似的提交
+	members := append([]int(nil), g.members...)
+	sort.SliceStable(members, func(a, b int) bool {
+		return similarity(commitFeatures[members[a]], g.centroid) > similarity(commitFeatures[members[b]], g.centroid)
+	})
+	for _, i := range members {
+		if len(ws.Representatives) >= maxRepresentatives {
+			break
+		}
+		ws.Representatives = append(ws.Representatives, commits[i])
+	}
+
+	return ws
+}
+
+// buildLabel 生成工作流标签，优先使用多数提交共享的功能分支名
+func buildLabel(ws Workstream) string {
+	var parts []string
+	if len(ws.Repos) > 0 {
+		parts = append(parts, ws.Repos[0])
+	}
+	switch {
+	case len(ws.Branches) > 0:
+		parts = append(parts, ws.Branches[0])
+	case len(ws.Keywords) > 0:
+		parts = append(parts, strings.Join(ws.Keywords, " / "))
+	}
+	if len(parts) == 0 {
+		return "misc"
+	}
+	return strings.Join(parts, ": ")
+}
+
+// extractFeatures 提取提交的特征
+func extractFeatures(commit git.CommitInfo, embedding []float32) features {
+	f := features{
+		text:      make(map[string]float64),
+		paths:     make(map[string]float64),
+		branches:  make(map[string]float64),
+		repos:     make(map[string]float64),
+		embedding: embedding,
+	}
+
+	for _, token := range tokenize(stripConventionalPrefix(commit.Message)) {
+		f.text[token]++
+	}
+	for _, file := range commit.ChangedFiles {
+		for _, token := range pathTokens(file) {
+			f.paths[token] = 1
+		}
+	}
+	for _, branch := range commit.Branches {
+		if !isMainBranch(branch) {
+			f.branches[branch] = 1
+		}
+	}
+	if commit.RepoPath != "" {
+		f.repos[commit.RepoPath] = 1
+	}
+
+	return f
+}
+
+// similarity 计算两组特征的加权相似度
+func similarity(a, b features) float64 {
+	textScore := cosine(a.text, b.text)
+	if len(a.embedding) > 0 && len(a.embedding) == len(b.embedding) {
+		textScore = cosineVector(a.embedding, b.embedding)
+	}
+
+	score := weightText*textScore + weightPath*cosine(a.paths, b.paths)
+	score += weightRepo * cosine(a.repos, b.repos)
+
+	// 分支权重只在双方都有功能分支信息时计入，否则按比例分配给其他特征
+	if len(a.branches) > 0 && len(b.branches) > 0 {
+		score += weightBranch * cosine(a.branches, b.branches)
+	} else {
+		score /= 1 - weightBranch
+	}
+	return score
+}
+
+// merge 将提交特征合并到工作流中心，n 为合并后的成员数
+func merge(centroid, f features, n int) features {
+	merged := features{
+		text:     mergeMap(centroid.text, f.text, n),
+		paths:    mergeMap(centroid.paths, f.paths, n),
+		branches: mergeMap(centroid.branches, f.branches, n),
+		repos:    mergeMap(centroid.repos, f.repos, n),
+	}
+	if len(centroid.embedding) > 0 && len(centroid.embedding) == len(f.embedding) {
+		merged.embedding = make([]float32, len(f.embedding))
+		for i := range f.embedding {
+			merged.embedding[i] = centroid.embedding[i] + (f.embedding[i]-centroid.embedding[i])/float32(n)
+		}
+	}
+	return merged
+}
+
+// mergeMap 以增量平均的方式合并特征向量
+func mergeMap(centroid, f map[string]float64, n int) map[string]float64 {
+	merged := make(map[string]float64, len(centroid)+len(f))
+	for key, value := range centroid {
+		merged[key] = value * float64(n-1) / float64(n)
+	}
+	for key, value := range f {
+		merged[key] += value / float64(n)
+	}
+	return merged
+}
+
+// timeDecay 计算时间间隔对应的衰减系数
+func timeDecay(gap, halfLife time.Duration) float64 {
+	if gap < 0 {
+		gap = -gap
+	}
+	return math.Pow(0.5, float64(gap)/float64(halfLife))
+}
+
+// cosine 计算两个稀疏向量的余弦相似度
+func cosine(a, b map[string]float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for key, value := range a {
+		normA += value * value
+		dot += value * b[key]
+	}
+	for _, value := range b {
+		normB += value * value
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// cosineVector 计算两个稠密向量的余弦相似度
+func cosineVector(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// stopWords 提交消息中不具有区分度的常见词
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "into": true,
+	"add": true, "added": true, "adds": true, "update": true, "updated": true, "updates": true,
+	"fix": true, "fixed": true, "fixes": true, "remove": true, "removed": true, "use": true,
+	"change": true, "changes": true, "make": true, "more": true, "some": true, "when": true,
+	"merge": true, "branch": true, "pull": true, "request": true, "wip": true, "minor": true,
+	"feat": true, "chore": true, "refactor": true, "docs": true, "test": true, "tests": true,
+	"修复": true, "添加": true, "新增": true, "更新": true, "优化": true, "删除": true, "调整": true,
+}
+
+// tokenize 将文本拆分为小写的词，中文按连续汉字切分为双字词
+func tokenize(text string) []string {
+	var tokens []string
+	var word []rune
+	var han []rune
+
+	flushWord := func() {
+		if len(word) >= 3 {
+			token := strings.ToLower(string(word))
+			if !stopWords[token] {
+				tokens = append(tokens, token)
+			}
+		}
+		word = word[:0]
+	}
+	flushHan := func() {
+		for i := 0; i+1 < len(han); i++ {
+			token := string(han[i : i+2])
+			if !stopWords[token] {
+				tokens = append(tokens, token)
+			}
+		}
+		han = han[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushWord()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushHan()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushHan()
+		}
+	}
+	flushWord()
+	flushHan()
+
+	return tokens
+}
+
+// pathTokens 提取文件路径中的目录和文件名作为特征
+func pathTokens(file string) []string {
+	dir := filepath.ToSlash(filepath.Dir(file))
+	var tokens []string
+	if dir != "." {
+		parts := strings.Split(dir, "/")
+		// 取前两级目录，足以区分模块又不会过于细碎
+		for i := 0; i < len(parts) && i < 2; i++ {
+			tokens = append(tokens, strings.ToLower(parts[i]))
+		}
+		tokens = append(tokens, strings.ToLower(dir))
+	}
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	for _, token := range tokenize(base) {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// stripConventionalPrefix 去除约定式提交的类型前缀，如 "feat(api): "
+func stripConventionalPrefix(message string) string {
+	if i := strings.Index(message, ": "); i > 0 && i < 30 && !strings.ContainsAny(message[:i], " \t") {
+		return message[i+2:]
+	}
+	return message
+}
+
+// isMainBranch 判断是否为主干分支，主干分支不具备区分工作流的意义
+func isMainBranch(branch string) bool {
+	switch strings.ToLower(branch) {
+	case "main", "master", "develop", "dev", "trunk", "head":
+		return true
+	}
+	return false
+}
+
+// topKeys 返回计数最高的若干键
+func topKeys(counts map[string]int, n int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+// topWeighted 返回权重最高的若干键，忽略包含路径分隔符的组合特征
+func topWeighted(weights map[string]float64, n int) []string {
+	keys := make([]string, 0, len(weights))
+	for key := range weights {
+		if !strings.Contains(key, "/") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if weights[keys[i]] != weights[keys[j]] {
+			return weights[keys[i]] > weights[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// TestCluster 测试将提交聚类为工作流
+func TestCluster(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	var commits []git.CommitInfo
+
+	// 支付模块的一组提交
+	for i := 0; i < 4; i++ {
+		commits = append(commits, git.CommitInfo{
+			Hash:         fmt.Sprintf("pay%05d", i),
+			Date:         start.AddDate(0, 0, i),
+			Message:      fmt.Sprintf("feat(payment): support refund step %d", i),
+			ChangedFiles: []string{"internal/payment/refund.go"},
+			Branches:     []string{"feature/refund"},
+			RepoPath:     "/work/billing",
+		})
+	}
+
+	// 前端仪表盘的一组提交
+	for i := 0; i < 3; i++ {
+		commits = append(commits, git.CommitInfo{
+			Hash:         fmt.Sprintf("ui%06d", i),
+			Date:         start.AddDate(0, 0, i),
+			Message:      fmt.Sprintf("dashboard chart layout tweak %d", i),
+			ChangedFiles: []string{"web/src/dashboard/Chart.tsx"},
+			RepoPath:     "/work/portal",
+		})
+	}
+
+	// 孤立的提交不构成工作流
+	commits = append(commits, git.CommitInfo{
+		Hash:     "misc0001",
+		Date:     start,
+		Message:  "bump version",
+		RepoPath: "/work/tools",
+	})
+
+	workstreams := Cluster(commits, DefaultOptions())
+	if len(workstreams) != 2 {
+		t.Fatalf("应聚类出2个工作流, 得到: %d", len(workstreams))
+	}
+
+	first := workstreams[0]
+	if first.CommitCount != 4 || first.Repos[0] != "billing" {
+		t.Errorf("第一个工作流应为 billing 的4个提交, 得到: %+v", first)
+	}
+	if first.Label != "billing: feature/refund" {
+		t.Errorf("第一个工作流的标签应使用功能分支名, 得到: %s", first.Label)
+	}
+	if !first.From.Equal(start) || !first.To.Equal(start.AddDate(0, 0, 3)) {
+		t.Errorf("第一个工作流的时间范围不正确: %s ~ %s", first.From, first.To)
+	}
+	if len(first.Representatives) != maxRepresentatives {
+		t.Errorf("应有 %d 个代表性提交, 得到: %d", maxRepresentatives, len(first.Representatives))
+	}
+
+	if workstreams[1].CommitCount != 3 || workstreams[1].Repos[0] != "portal" {
+		t.Errorf("第二个工作流应为 portal 的3个提交, 得到: %+v", workstreams[1])
+	}
+}
+
+// TestTokenize 测试分词
+func TestTokenize(t *testing.T) {
+	tokens := tokenize("Add OAuth login 支持登录")
+	expected := []string{"oauth", "login", "支持", "持登", "登录"}
+	if len(tokens) != len(expected) {
+		t.Fatalf("分词结果应为 %v, 得到: %v", expected, tokens)
+	}
+	for i := range expected {
+		if tokens[i] != expected[i] {
+			t.Errorf("第 %d 个词应为 %s, 得到: %s", i, expected[i], tokens[i])
+		}
+	}
+}
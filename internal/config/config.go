@@ -0,0 +1,339 @@
+// Package config 读取用户级和仓库级的 YAML 配置文件及其中的命名预设，配置项与命令行参数同名，
+// 按 参数 > 环境变量 > 选中的预设 > 仓库配置 > 用户配置 的优先级合并；
+// 预设同时在两个文件中定义时，仓库配置中的定义优先
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FileEnvVar 指定用户配置文件路径的环境变量
+	FileEnvVar = "GIT_PROFILE_CONFIG"
+	// PresetEnvVar 指定预设的环境变量
+	PresetEnvVar = "GIT_PROFILE_PRESET"
+	// EnvPrefix 参数对应的环境变量前缀，如 --out-dir 对应 GIT_PROFILE_OUT_DIR
+	EnvPrefix = "GIT_PROFILE_"
+)
+
+// presetsKey 配置文件中定义预设的键
+const presetsKey = "presets"
+
+// RepoFileNames 仓库级配置文件的文件名
+var RepoFileNames = []string{".git-work-profile.yaml", ".git-work-profile.yml"}
+
+// 设置的来源
+const (
+	SourceDefault = "default"
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceUser    = "user config"
+	SourceRepo    = "repo config"
+)
+
+// Settings 一组设置：顶层的设置对所有具有同名参数的子命令生效，子命令小节中的设置只对该子命令生效
+type Settings struct {
+	Values   map[string][]string            // 参数名到值的映射
+	Commands map[string]map[string][]string // 子命令名到设置的映射
+}
+
+// File 一个配置文件
+type File struct {
+	Path string
+	Settings
+	Presets map[string]Settings // 预设名称到设置的映射
+}
+
+// Layer 同一来源的一组设置
+type Layer struct {
+	Source string
+	Values map[string][]string
+}
+
+// Setting 合并后的一项设置
+type Setting struct {
+	Values []string
+	Source string
+}
+
+// UserFile 返回用户配置文件的路径：环境变量 GIT_PROFILE_CONFIG，
+// 未设置时为用户配置目录下的 git-work-profile/config.yaml
+func UserFile() (string, error) {
+	if path := os.Getenv(FileEnvVar); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "git-work-profile", "config.yaml"), nil
+}
+
+// FindRepoFile 从 dir 开始向上查找仓库级配置文件，到达仓库根目录（包含 .git 的目录）后停止，
+// 找不到时返回空
+func FindRepoFile(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		for _, name := range RepoFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Load 读取配置文件，文件不存在时返回 nil
+func Load(path string) (*File, error) {
+	if path == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	file, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	file.Path = path
+	return file, nil
+}
+
+// Parse 解析 YAML 配置。值可以是字符串、数字、布尔值或字符串列表；
+// 值为映射的键是子命令小节，presets 下为各预设的设置，预设中同样可以有子命令小节
+func Parse(content []byte) (*File, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+
+	presets, _ := raw[presetsKey].(map[string]any)
+	if value, ok := raw[presetsKey]; ok && presets == nil && value != nil {
+		return nil, fmt.Errorf("%s: expected a mapping of preset names to settings", presetsKey)
+	}
+	delete(raw, presetsKey)
+
+	settings, err := parseSettings("", raw)
+	if err != nil {
+		return nil, err
+	}
+	file := &File{Settings: settings, Presets: make(map[string]Settings)}
+	for name, value := range presets {
+		entries, ok := value.(map[string]any)
+		if !ok && value != nil {
+			return nil, fmt.Errorf("%s.%s: expected a mapping of settings", presetsKey, name)
+		}
+		if file.Presets[name], err = parseSettings(presetsKey+"."+name+".", entries); err != nil {
+			return nil, err
+		}
+	}
+	return file, nil
+}
+
+// parseSettings 解析一组设置，prefix 用于错误信息中的键名
+func parseSettings(prefix string, raw map[string]any) (Settings, error) {
+	settings := Settings{Values: make(map[string][]string), Commands: make(map[string]map[string][]string)}
+	for key, value := range raw {
+		section, ok := value.(map[string]any)
+		if !ok {
+			values, err := toValues(prefix+key, value)
+			if err != nil {
+				return Settings{}, err
+			}
+			settings.Values[key] = values
+			continue
+		}
+		command := make(map[string][]string)
+		for name, value := range section {
+			values, err := toValues(prefix+key+"."+name, value)
+			if err != nil {
+				return Settings{}, err
+			}
+			command[name] = values
+		}
+		settings.Commands[key] = command
+	}
+	return settings, nil
+}
+
+// Validate 检查配置文件中的键是否都是已知的参数。known 的 command 为空表示顶层的设置，
+// 否则为子命令小节的名称
+func (f *File) Validate(known func(command, key string) bool) error {
+	if f == nil {
+		return nil
+	}
+	var unknown []string
+	check := func(prefix string, settings Settings) {
+		for key := range settings.Values {
+			if !known("", key) {
+				unknown = append(unknown, prefix+key)
+			}
+		}
+		for command, values := range settings.Commands {
+			for key := range values {
+				if !known(command, key) {
+					unknown = append(unknown, prefix+command+"."+key)
+				}
+			}
+		}
+	}
+	check("", f.Settings)
+	for name, preset := range f.Presets {
+		check(presetsKey+"."+name+".", preset)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%s: unknown options: %s", f.Path, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// PresetNames 返回配置文件中定义的所有预设名称，已排序
+func PresetNames(files ...*File) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, file := range files {
+		if file == nil {
+			continue
+		}
+		for name := range file.Presets {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Layers 按优先级从低到高返回配置文件中对子命令 command 生效的设置：用户配置、仓库配置，
+// 以及选中的预设在用户配置和仓库配置中的定义。preset 不为空但两个文件中都没有定义时返回错误
+func Layers(user, repo *File, command, preset string) ([]Layer, error) {
+	files := []struct {
+		file   *File
+		source string
+	}{{user, SourceUser}, {repo, SourceRepo}}
+
+	var layers []Layer
+	for _, item := range files {
+		if item.file != nil {
+			layers = append(layers, item.file.layer(command, item.source))
+		}
+	}
+	if preset == "" {
+		return layers, nil
+	}
+
+	found := false
+	for _, item := range files {
+		if item.file == nil {
+			continue
+		}
+		if settings, ok := item.file.Presets[preset]; ok {
+			found = true
+			layers = append(layers, settings.layer(command, fmt.Sprintf("preset %s (%s)", preset, item.source)))
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown preset %q", preset)
+	}
+	return layers, nil
+}
+
+// layer 合并顶层设置和子命令小节中的设置，小节中的设置优先
+func (s Settings) layer(command, source string) Layer {
+	values := make(map[string][]string, len(s.Values))
+	for key, value := range s.Values {
+		values[key] = value
+	}
+	for key, value := range s.Commands[command] {
+		values[key] = value
+	}
+	return Layer{Source: source, Values: values}
+}
+
+// EnvLayer 返回环境变量中的设置，names 为参数名
+func EnvLayer(names []string, lookup func(string) (string, bool)) Layer {
+	layer := Layer{Source: SourceEnv, Values: make(map[string][]string)}
+	for _, name := range names {
+		if value, ok := lookup(EnvName(name)); ok {
+			layer.Values[name] = []string{value}
+		}
+	}
+	return layer
+}
+
+// EnvName 返回参数对应的环境变量名，如 out-dir 对应 GIT_PROFILE_OUT_DIR
+func EnvName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// Merge 按顺序合并各层设置，后面的层覆盖前面的层
+func Merge(layers ...Layer) map[string]Setting {
+	merged := make(map[string]Setting)
+	for _, layer := range layers {
+		for key, values := range layer.Values {
+			merged[key] = Setting{Values: values, Source: layer.Source}
+		}
+	}
+	return merged
+}
+
+// toValues 将 YAML 中的值转换为字符串列表，以 ~/ 开头的值展开为用户主目录
+func toValues(key string, value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return []string{""}, nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, ok := item.(map[string]any); ok {
+				return nil, fmt.Errorf("%s: expected a list of values", key)
+			}
+			if _, ok := item.([]any); ok {
+				return nil, fmt.Errorf("%s: expected a list of values", key)
+			}
+			values = append(values, expandHome(fmt.Sprint(item)))
+		}
+		return values, nil
+	case map[string]any:
+		return nil, fmt.Errorf("%s: expected a value or a list of values", key)
+	default:
+		return []string{expandHome(fmt.Sprint(v))}, nil
+	}
+}
+
+// expandHome 将 ~/ 开头的路径展开为用户主目录
+func expandHome(value string) string {
+	if value != "~" && !strings.HasPrefix(value, "~/") {
+		return value
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return value
+	}
+	return filepath.Join(home, strings.TrimPrefix(value, "~"))
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleConfig = `
+model: gemini-2.5-pro
+format: [markdown, html]
+max-tool-calls: 3
+verify: false
+stats:
+  format: csv
+presets:
+  weekly-team:
+    range: 1w
+    repos: ~/work
+    stats:
+      range: 1m
+`
+
+// TestParse 测试解析值、列表、子命令小节和预设
+func TestParse(t *testing.T) {
+	file, err := Parse([]byte(sampleConfig))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+
+	want := map[string][]string{
+		"model":          {"gemini-2.5-pro"},
+		"format":         {"markdown", "html"},
+		"max-tool-calls": {"3"},
+		"verify":         {"false"},
+	}
+	if !reflect.DeepEqual(file.Values, want) {
+		t.Errorf("顶层设置不正确: %v", file.Values)
+	}
+	if got := file.Commands["stats"]["format"]; !reflect.DeepEqual(got, []string{"csv"}) {
+		t.Errorf("子命令小节不正确: %v", file.Commands)
+	}
+
+	preset, ok := file.Presets["weekly-team"]
+	if !ok {
+		t.Fatal("应解析出预设 weekly-team")
+	}
+	home, _ := os.UserHomeDir()
+	if got := preset.Values["repos"]; !reflect.DeepEqual(got, []string{filepath.Join(home, "work")}) {
+		t.Errorf("~/ 应展开为用户主目录: %v", got)
+	}
+	if got := preset.Commands["stats"]["range"]; !reflect.DeepEqual(got, []string{"1m"}) {
+		t.Errorf("预设中的子命令小节不正确: %v", preset.Commands)
+	}
+}
+
+// TestParseErrors 测试无效的配置
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"语法错误":     "model: [",
+		"嵌套过深":     "stats:\n  format:\n    a: b\n",
+		"列表中的映射":   "format:\n  - a: b\n",
+		"预设不是映射":   "presets: [a]\n",
+		"预设设置不是映射": "presets:\n  weekly: 1w\n",
+	}
+	for name, content := range cases {
+		if _, err := Parse([]byte(content)); err == nil {
+			t.Errorf("%s: 应返回错误", name)
+		}
+	}
+}
+
+// TestValidate 测试未知的键
+func TestValidate(t *testing.T) {
+	file, err := Parse([]byte(sampleConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Path = "config.yaml"
+
+	known := func(command, key string) bool {
+		if command == "stats" {
+			return key == "format" || key == "range"
+		}
+		return key != "verify"
+	}
+	err = file.Validate(known)
+	if err == nil || !strings.Contains(err.Error(), "unknown options: verify") {
+		t.Errorf("应报告未知的键 verify: %v", err)
+	}
+
+	if err := file.Validate(func(string, string) bool { return true }); err != nil {
+		t.Errorf("所有键都已知时不应返回错误: %v", err)
+	}
+	if err := (*File)(nil).Validate(known); err != nil {
+		t.Errorf("没有配置文件时不应返回错误: %v", err)
+	}
+}
+
+// TestLayers 测试各层设置的优先级
+func TestLayers(t *testing.T) {
+	user, err := Parse([]byte(sampleConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Parse([]byte("model: gemini-2.5-flash\npresets:\n  weekly-team:\n    author: Alice\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layers, err := Layers(user, repo, "stats", "weekly-team")
+	if err != nil {
+		t.Fatalf("获取设置失败: %v", err)
+	}
+	env := EnvLayer([]string{"verify", "author"}, func(name string) (string, bool) {
+		return "true", name == "GIT_PROFILE_VERIFY"
+	})
+	merged := Merge(append(layers, env)...)
+
+	checks := map[string]Setting{
+		"model":  {Values: []string{"gemini-2.5-flash"}, Source: SourceRepo},
+		"format": {Values: []string{"csv"}, Source: SourceUser},
+		"range":  {Values: []string{"1m"}, Source: "preset weekly-team (user config)"},
+		"author": {Values: []string{"Alice"}, Source: "preset weekly-team (repo config)"},
+		"verify": {Values: []string{"true"}, Source: SourceEnv},
+	}
+	for key, want := range checks {
+		if got := merged[key]; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %+v, 应为 %+v", key, got, want)
+		}
+	}
+
+	if _, err := Layers(user, repo, "analyze", "monthly"); err == nil {
+		t.Error("未定义的预设应返回错误")
+	}
+	if layers, err := Layers(nil, nil, "analyze", ""); err != nil || len(layers) != 0 {
+		t.Errorf("没有配置文件时应返回空: %v %v", layers, err)
+	}
+}
+
+// TestEnvName 测试参数名对应的环境变量名
+func TestEnvName(t *testing.T) {
+	if got := EnvName("max-tool-calls"); got != "GIT_PROFILE_MAX_TOOL_CALLS" {
+		t.Errorf("环境变量名不正确: %s", got)
+	}
+}
+
+// TestFindRepoFile 测试向上查找仓库级配置文件，并在仓库根目录停止
+func TestFindRepoFile(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	sub := filepath.Join(repo, "src", "pkg")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, RepoFileNames[0]), []byte("model: x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := FindRepoFile(sub); got != "" {
+		t.Errorf("不应越过仓库根目录查找: %s", got)
+	}
+
+	path := filepath.Join(repo, RepoFileNames[1])
+	if err := os.WriteFile(path, []byte("model: x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindRepoFile(sub); got != path {
+		t.Errorf("应找到仓库根目录的配置文件: %s", got)
+	}
+}
+
+// TestLoadMissing 测试配置文件不存在时返回 nil
+func TestLoadMissing(t *testing.T) {
+	file, err := Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if file != nil || err != nil {
+		t.Errorf("不存在的文件应返回 nil: %v %v", file, err)
+	}
+}
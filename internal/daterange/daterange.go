@@ -0,0 +1,287 @@
+// Package daterange 解析时间范围表达式，如 6m、30d、2025-Q3、FY2025、last-month、
+// this-week、ytd、since:2024-03，以及 2024-01..2024-06 这样可以省略一端的范围
+package daterange
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DayLayout 日期的格式
+const DayLayout = "2006-01-02"
+
+// Examples 支持的表达式示例，用于帮助和错误信息
+const Examples = "6m, 30d, 2w, 1y, 2025, 2025-03, 2025-03-15, 2025-Q3, 2025-H1, FY2025, FY2025-Q2, " +
+	"today, yesterday, this-week, last-week, this-month, last-month, this-quarter, last-quarter, " +
+	"this-year, last-year, this-fy, last-fy, ytd, qtd, mtd, 2-weeks-ago, 3-months-ago, since:2024-03, until:2024-06, 2024-01..2024-06"
+
+// Range 一个时间范围，From 为第一天的开始，To 为最后一天的最后一秒。From 为零值表示不限开始时间
+type Range struct {
+	From time.Time
+	To   time.Time
+}
+
+// OpenStart 是否不限开始时间
+func (r Range) OpenStart() bool {
+	return r.From.IsZero()
+}
+
+// Options 解析表达式的选项
+type Options struct {
+	Now         time.Time  // 当前时间，零值表示 time.Now()
+	FiscalStart time.Month // 财年开始的月份，零值表示一月。财年以结束时所在的年份命名
+}
+
+var (
+	durationPattern = regexp.MustCompile(`^(\d+)([dwmy])$`)
+	yearPattern     = regexp.MustCompile(`^(\d{4})$`)
+	monthPattern    = regexp.MustCompile(`^(\d{4})-(\d{1,2})$`)
+	dayPattern      = regexp.MustCompile(`^\d{4}-\d{1,2}-\d{1,2}$`)
+	quarterPattern  = regexp.MustCompile(`^(\d{4})-q(\d)$`)
+	halfPattern     = regexp.MustCompile(`^(\d{4})-h(\d)$`)
+	fiscalPattern   = regexp.MustCompile(`^fy(\d{4})(?:-q(\d))?$`)
+	agoPattern      = regexp.MustCompile(`^(\d+)-(day|week|month|quarter|year)s?-ago$`)
+)
+
+// Parse 解析时间范围表达式。since:X 表示从 X 开始到今天，until:X 表示到 X 结束且不限开始时间，
+// A..B 表示从 A 开始到 B 结束，省略 A 表示不限开始时间，省略 B 表示到今天
+func Parse(expr string, opts Options) (Range, error) {
+	expr = normalize(expr)
+	if expr == "" {
+		return Range{}, fmt.Errorf("empty time range")
+	}
+	today := startOfDay(opts.now())
+
+	var from, to string
+	switch {
+	case strings.HasPrefix(expr, "since:"):
+		from = strings.TrimPrefix(expr, "since:")
+	case strings.HasPrefix(expr, "until:"):
+		to = strings.TrimPrefix(expr, "until:")
+	case strings.Contains(expr, ".."):
+		from, to, _ = strings.Cut(expr, "..")
+	default:
+		return resolve(expr, today, opts)
+	}
+	if from == "" && to == "" {
+		return Range{}, fmt.Errorf("time range %q has neither a start nor an end", expr)
+	}
+	return Between(from, to, opts)
+}
+
+// Between 返回从表达式 from 所表示的时间段开始、到表达式 to 所表示的时间段结束的范围，
+// from 为空表示不限开始时间，to 为空表示到今天
+func Between(from, to string, opts Options) (Range, error) {
+	today := startOfDay(opts.now())
+	r := Range{To: endOfDay(today)}
+	if from = normalize(from); from != "" {
+		start, err := resolve(from, today, opts)
+		if err != nil {
+			return Range{}, err
+		}
+		r.From = start.From
+	}
+	if to = normalize(to); to != "" {
+		end, err := resolve(to, today, opts)
+		if err != nil {
+			return Range{}, err
+		}
+		r.To = end.To
+	}
+	if !r.OpenStart() && r.From.After(r.To) {
+		return Range{}, fmt.Errorf("start %s is after end %s", r.From.Format(DayLayout), r.To.Format(DayLayout))
+	}
+	return r, nil
+}
+
+// Validate 检查表达式的语法是否正确
+func Validate(expr string) error {
+	_, err := Parse(expr, Options{})
+	return err
+}
+
+// resolve 解析单个表达式：相对时长、相对的日历时间段或具体的日期、月份、季度和年份
+func resolve(expr string, today time.Time, opts Options) (Range, error) {
+	if r, ok := relative(expr, today, opts); ok {
+		return r, nil
+	}
+
+	if m := durationPattern.FindStringSubmatch(expr); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if n == 0 {
+			return Range{}, fmt.Errorf("duration %q must be greater than zero", expr)
+		}
+		var from time.Time
+		switch m[2] {
+		case "d":
+			from = today.AddDate(0, 0, -n)
+		case "w":
+			from = today.AddDate(0, 0, -7*n)
+		case "m":
+			from = today.AddDate(0, -n, 0)
+		case "y":
+			from = today.AddDate(-n, 0, 0)
+		}
+		return Range{From: from, To: endOfDay(today)}, nil
+	}
+
+	loc := today.Location()
+	if m := yearPattern.FindStringSubmatch(expr); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		return months(date(year, 1, 1, loc), 12), nil
+	}
+	if m := monthPattern.FindStringSubmatch(expr); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return Range{}, fmt.Errorf("invalid month in %q", expr)
+		}
+		return months(date(year, time.Month(month), 1, loc), 1), nil
+	}
+	if dayPattern.MatchString(expr) {
+		day, err := time.ParseInLocation("2006-1-2", expr, loc)
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid date %q", expr)
+		}
+		return Range{From: day, To: endOfDay(day)}, nil
+	}
+	if m := quarterPattern.FindStringSubmatch(expr); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		quarter, _ := strconv.Atoi(m[2])
+		if quarter < 1 || quarter > 4 {
+			return Range{}, fmt.Errorf("invalid quarter in %q, expected Q1 to Q4", expr)
+		}
+		return months(date(year, time.Month(3*quarter-2), 1, loc), 3), nil
+	}
+	if m := halfPattern.FindStringSubmatch(expr); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		half, _ := strconv.Atoi(m[2])
+		if half < 1 || half > 2 {
+			return Range{}, fmt.Errorf("invalid half year in %q, expected H1 or H2", expr)
+		}
+		return months(date(year, time.Month(6*half-5), 1, loc), 6), nil
+	}
+	if m := fiscalPattern.FindStringSubmatch(expr); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		start := fiscalYearStart(year, opts.fiscalStart(), loc)
+		if m[2] == "" {
+			return months(start, 12), nil
+		}
+		quarter, _ := strconv.Atoi(m[2])
+		if quarter < 1 || quarter > 4 {
+			return Range{}, fmt.Errorf("invalid quarter in %q, expected Q1 to Q4", expr)
+		}
+		return months(start.AddDate(0, 3*(quarter-1), 0), 3), nil
+	}
+
+	return Range{}, fmt.Errorf("unrecognized time range %q", expr)
+}
+
+// relative 解析相对于今天的日历时间段，包含今天的时间段到今天结束
+func relative(expr string, today time.Time, opts Options) (Range, bool) {
+	loc := today.Location()
+	thisMonth := date(today.Year(), today.Month(), 1, loc)
+	thisQuarter := date(today.Year(), today.Month()-(today.Month()-1)%3, 1, loc)
+	thisYear := date(today.Year(), 1, 1, loc)
+	thisWeek := today.AddDate(0, 0, -(int(today.Weekday())+6)%7) // 每周从周一开始
+	thisFiscalYear := date(today.Year(), opts.fiscalStart(), 1, loc)
+	if thisFiscalYear.After(today) {
+		thisFiscalYear = thisFiscalYear.AddDate(-1, 0, 0)
+	}
+	toToday := func(from time.Time) Range { return Range{From: from, To: endOfDay(today)} }
+
+	switch expr {
+	case "today":
+		return toToday(today), true
+	case "yesterday":
+		day := today.AddDate(0, 0, -1)
+		return Range{From: day, To: endOfDay(day)}, true
+	case "this-week", "wtd":
+		return toToday(thisWeek), true
+	case "last-week":
+		return Range{From: thisWeek.AddDate(0, 0, -7), To: thisWeek.Add(-time.Second)}, true
+	case "this-month", "mtd":
+		return toToday(thisMonth), true
+	case "last-month":
+		return months(thisMonth.AddDate(0, -1, 0), 1), true
+	case "this-quarter", "qtd":
+		return toToday(thisQuarter), true
+	case "last-quarter":
+		return months(thisQuarter.AddDate(0, -3, 0), 3), true
+	case "this-year", "ytd":
+		return toToday(thisYear), true
+	case "last-year":
+		return months(thisYear.AddDate(-1, 0, 0), 12), true
+	case "this-fy":
+		return toToday(thisFiscalYear), true
+	case "last-fy":
+		return months(thisFiscalYear.AddDate(-1, 0, 0), 12), true
+	}
+
+	// N-weeks-ago 等表示往前第 N 个完整的日历时间段
+	if m := agoPattern.FindStringSubmatch(expr); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "day":
+			day := today.AddDate(0, 0, -n)
+			return Range{From: day, To: endOfDay(day)}, true
+		case "week":
+			week := thisWeek.AddDate(0, 0, -7*n)
+			return Range{From: week, To: week.AddDate(0, 0, 7).Add(-time.Second)}, true
+		case "month":
+			return months(thisMonth.AddDate(0, -n, 0), 1), true
+		case "quarter":
+			return months(thisQuarter.AddDate(0, -3*n, 0), 3), true
+		case "year":
+			return months(thisYear.AddDate(-n, 0, 0), 12), true
+		}
+	}
+	return Range{}, false
+}
+
+// fiscalYearStart 返回以 year 年结束的财年的第一天
+func fiscalYearStart(year int, start time.Month, loc *time.Location) time.Time {
+	if start == time.January {
+		return date(year, time.January, 1, loc)
+	}
+	return date(year-1, start, 1, loc)
+}
+
+// months 返回从 from 开始的 n 个月
+func months(from time.Time, n int) Range {
+	return Range{From: from, To: from.AddDate(0, n, 0).Add(-time.Second)}
+}
+
+// normalize 去除空白并转为小写
+func normalize(expr string) string {
+	return strings.ToLower(strings.TrimSpace(expr))
+}
+
+func date(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return date(t.Year(), t.Month(), t.Day(), t.Location())
+}
+
+func endOfDay(day time.Time) time.Time {
+	return day.AddDate(0, 0, 1).Add(-time.Second)
+}
+
+func (o Options) now() time.Time {
+	if o.Now.IsZero() {
+		return time.Now()
+	}
+	return o.Now
+}
+
+func (o Options) fiscalStart() time.Month {
+	if o.FiscalStart < time.January || o.FiscalStart > time.December {
+		return time.January
+	}
+	return o.FiscalStart
+}
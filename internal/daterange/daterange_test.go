@@ -0,0 +1,117 @@
+package daterange
+
+import (
+	"testing"
+	"time"
+)
+
+// now 测试使用的当前时间：2026-10-21 是周三
+var now = time.Date(2026, 10, 21, 15, 30, 0, 0, time.UTC)
+
+// TestParse 测试各种表达式对应的开始和结束日期
+func TestParse(t *testing.T) {
+	cases := []struct {
+		expr     string
+		from, to string
+	}{
+		{"6m", "2026-04-21", "2026-10-21"},
+		{"30d", "2026-09-21", "2026-10-21"},
+		{"2w", "2026-10-07", "2026-10-21"},
+		{"1y", "2025-10-21", "2026-10-21"},
+		{"2025", "2025-01-01", "2025-12-31"},
+		{"2024-02", "2024-02-01", "2024-02-29"},
+		{"2025-03-15", "2025-03-15", "2025-03-15"},
+		{"2025-Q3", "2025-07-01", "2025-09-30"},
+		{"2025-H2", "2025-07-01", "2025-12-31"},
+		{"FY2025", "2025-01-01", "2025-12-31"},
+		{"today", "2026-10-21", "2026-10-21"},
+		{"yesterday", "2026-10-20", "2026-10-20"},
+		{"this-week", "2026-10-19", "2026-10-21"},
+		{"last-week", "2026-10-12", "2026-10-18"},
+		{"this-month", "2026-10-01", "2026-10-21"},
+		{"last-month", "2026-09-01", "2026-09-30"},
+		{"this-quarter", "2026-10-01", "2026-10-21"},
+		{"last-quarter", "2026-07-01", "2026-09-30"},
+		{"ytd", "2026-01-01", "2026-10-21"},
+		{"last-year", "2025-01-01", "2025-12-31"},
+		{"2-weeks-ago", "2026-10-05", "2026-10-11"},
+		{"1-day-ago", "2026-10-20", "2026-10-20"},
+		{"3-months-ago", "2026-07-01", "2026-07-31"},
+		{"1-quarter-ago", "2026-07-01", "2026-09-30"},
+		{"since:2024-03", "2024-03-01", "2026-10-21"},
+		{"since:last-month", "2026-09-01", "2026-10-21"},
+		{"2024-01..2024-06", "2024-01-01", "2024-06-30"},
+		{"2025-Q1..", "2025-01-01", "2026-10-21"},
+		{" Last-Month ", "2026-09-01", "2026-09-30"},
+	}
+	for _, c := range cases {
+		r, err := Parse(c.expr, Options{Now: now})
+		if err != nil {
+			t.Errorf("%s: 解析失败: %v", c.expr, err)
+			continue
+		}
+		if got := r.From.Format(DayLayout); got != c.from {
+			t.Errorf("%s: 开始日期为 %s，应为 %s", c.expr, got, c.from)
+		}
+		if got := r.To.Format(DayLayout); got != c.to {
+			t.Errorf("%s: 结束日期为 %s，应为 %s", c.expr, got, c.to)
+		}
+		if r.To.Hour() != 23 || r.To.Minute() != 59 {
+			t.Errorf("%s: 结束时间应为当天的最后一秒: %v", c.expr, r.To)
+		}
+	}
+}
+
+// TestFiscalYear 测试财年以结束时所在的年份命名
+func TestFiscalYear(t *testing.T) {
+	opts := Options{Now: now, FiscalStart: time.April}
+	cases := map[string][2]string{
+		"FY2025":    {"2024-04-01", "2025-03-31"},
+		"FY2025-Q2": {"2024-07-01", "2024-09-30"},
+		"this-fy":   {"2026-04-01", "2026-10-21"},
+		"last-fy":   {"2025-04-01", "2026-03-31"},
+	}
+	for expr, want := range cases {
+		r, err := Parse(expr, opts)
+		if err != nil {
+			t.Errorf("%s: 解析失败: %v", expr, err)
+			continue
+		}
+		if r.From.Format(DayLayout) != want[0] || r.To.Format(DayLayout) != want[1] {
+			t.Errorf("%s: 为 %s 到 %s，应为 %s 到 %s", expr, r.From.Format(DayLayout), r.To.Format(DayLayout), want[0], want[1])
+		}
+	}
+}
+
+// TestOpenStart 测试不限开始时间的范围
+func TestOpenStart(t *testing.T) {
+	for _, expr := range []string{"until:2024-06", "..2024-06"} {
+		r, err := Parse(expr, Options{Now: now})
+		if err != nil {
+			t.Fatalf("%s: 解析失败: %v", expr, err)
+		}
+		if !r.OpenStart() || r.To.Format(DayLayout) != "2024-06-30" {
+			t.Errorf("%s: 应不限开始时间并在 2024-06-30 结束: %+v", expr, r)
+		}
+	}
+}
+
+// TestBetween 测试 --from/--to 对应的范围
+func TestBetween(t *testing.T) {
+	r, err := Between("2025-Q3", "", Options{Now: now})
+	if err != nil || r.From.Format(DayLayout) != "2025-07-01" || r.To.Format(DayLayout) != "2026-10-21" {
+		t.Errorf("只有开始时间时应到今天结束: %+v %v", r, err)
+	}
+	if _, err := Between("2025-06", "2025-05", Options{Now: now}); err == nil {
+		t.Error("开始时间晚于结束时间应返回错误")
+	}
+}
+
+// TestParseErrors 测试无效的表达式
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "6months", "0d", "2025-13", "2025-02-30", "2025-Q5", "2025-H3", "FY25", "since:", "..", "2025-06..2025-01"} {
+		if _, err := Parse(expr, Options{Now: now}); err == nil {
+			t.Errorf("%q 应返回错误", expr)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+// Package export 将收集的提交记录和汇总表导出为 CSV 或 NDJSON，不需要AI
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+)
+
+const (
+	// FormatCSV 逗号分隔值，列表字段以分号连接
+	FormatCSV = "csv"
+	// FormatNDJSON 每行一个 JSON 对象
+	FormatNDJSON = "ndjson"
+)
+
+// listSeparator CSV 中列表字段的分隔符
+const listSeparator = ";"
+
+// IsFormat 判断是否为导出格式
+func IsFormat(format string) bool {
+	return format == FormatCSV || format == FormatNDJSON
+}
+
+// Table 导出的表，单元格的值可以是 string、int 或 []string
+type Table struct {
+	Name    string
+	Columns []string
+	Rows    [][]any
+}
+
+// Commits 将提交记录转换为表，按时间、仓库和哈希排序
+func Commits(commits []git.CommitInfo) Table {
+	sorted := append([]git.CommitInfo(nil), commits...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Date.Equal(sorted[j].Date) {
+			return sorted[i].Date.Before(sorted[j].Date)
+		}
+		if sorted[i].RepoPath != sorted[j].RepoPath {
+			return sorted[i].RepoPath < sorted[j].RepoPath
+		}
+		return sorted[i].Hash < sorted[j].Hash
+	})
+
+	table := Table{
+		Name:    "commits",
+		Columns: []string{"repo", "repo_path", "hash", "author", "date", "subject", "branches", "files", "files_changed", "lines_added", "lines_deleted"},
+	}
+	for _, commit := range sorted {
+		table.Rows = append(table.Rows, []any{
+			filepath.Base(commit.RepoPath),
+			commit.RepoPath,
+			commit.Hash,
+			commit.Author,
+			commit.Date.Format(time.RFC3339),
+			commit.Message,
+			nonNil(commit.Branches),
+			nonNil(commit.ChangedFiles),
+			len(commit.ChangedFiles),
+			commit.LinesAdded,
+			commit.LinesDeleted,
+		})
+	}
+	return table
+}
+
+// Aggregates 生成按仓库、月份和编程语言汇总的表
+func Aggregates(commits []git.CommitInfo) []Table {
+	return []Table{byRepo(commits), byMonth(commits), byLanguage(commits)}
+}
+
+// repoTotals 单个仓库的汇总
+type repoTotals struct {
+	commits, added, deleted int
+	files, authors          map[string]bool
+	first, last             time.Time
+}
+
+// byRepo 按仓库汇总
+func byRepo(commits []git.CommitInfo) Table {
+	totals := make(map[string]*repoTotals)
+	for _, commit := range commits {
+		t, ok := totals[commit.RepoPath]
+		if !ok {
+			t = &repoTotals{files: make(map[string]bool), authors: make(map[string]bool), first: commit.Date, last: commit.Date}
+			totals[commit.RepoPath] = t
+		}
+		t.commits++
+		t.added += commit.LinesAdded
+		t.deleted += commit.LinesDeleted
+		for _, file := range commit.ChangedFiles {
+			t.files[file] = true
+		}
+		if commit.Author != "" {
+			t.authors[commit.Author] = true
+		}
+		if commit.Date.Before(t.first) {
+			t.first = commit.Date
+		}
+		if commit.Date.After(t.last) {
+			t.last = commit.Date
+		}
+	}
+
+	table := Table{
+		Name:    "repos",
+		Columns: []string{"repo", "repo_path", "commits", "lines_added", "lines_deleted", "files", "authors", "first_commit", "last_commit"},
+	}
+	for _, path := range sortedKeys(totals) {
+		t := totals[path]
+		table.Rows = append(table.Rows, []any{
+			filepath.Base(path), path, t.commits, t.added, t.deleted, len(t.files), len(t.authors),
+			t.first.Format(time.RFC3339), t.last.Format(time.RFC3339),
+		})
+	}
+	return table
+}
+
+// monthTotals 单个月份的汇总
+type monthTotals struct {
+	commits, added, deleted int
+	days, repos             map[string]bool
+}
+
+// byMonth 按月份汇总
+func byMonth(commits []git.CommitInfo) Table {
+	totals := make(map[string]*monthTotals)
+	for _, commit := range commits {
+		month := commit.Date.Format("2006-01")
+		t, ok := totals[month]
+		if !ok {
+			t = &monthTotals{days: make(map[string]bool), repos: make(map[string]bool)}
+			totals[month] = t
+		}
+		t.commits++
+		t.added += commit.LinesAdded
+		t.deleted += commit.LinesDeleted
+		t.days[commit.Date.Format("2006-01-02")] = true
+		t.repos[commit.RepoPath] = true
+	}
+
+	table := Table{
+		Name:    "months",
+		Columns: []string{"month", "commits", "lines_added", "lines_deleted", "active_days", "repos"},
+	}
+	for _, month := range sortedKeys(totals) {
+		t := totals[month]
+		table.Rows = append(table.Rows, []any{month, t.commits, t.added, t.deleted, len(t.days), len(t.repos)})
+	}
+	return table
+}
+
+// languageTotals 单种语言的汇总
+type languageTotals struct {
+	commits, changes int
+	repos            map[string]bool
+}
+
+// byLanguage 按编程语言汇总，按文件变更次数从多到少排序
+func byLanguage(commits []git.CommitInfo) Table {
+	totals := make(map[string]*languageTotals)
+	for _, commit := range commits {
+		seen := make(map[string]bool)
+		for _, file := range commit.ChangedFiles {
+			lang, ok := profile.LanguageOf(file)
+			if !ok {
+				continue
+			}
+			t, ok := totals[lang]
+			if !ok {
+				t = &languageTotals{repos: make(map[string]bool)}
+				totals[lang] = t
+			}
+			t.changes++
+			t.repos[commit.RepoPath] = true
+			if !seen[lang] {
+				seen[lang] = true
+				t.commits++
+			}
+		}
+	}
+
+	languages := sortedKeys(totals)
+	sort.SliceStable(languages, func(i, j int) bool {
+		return totals[languages[i]].changes > totals[languages[j]].changes
+	})
+
+	table := Table{
+		Name:    "languages",
+		Columns: []string{"language", "commits", "file_changes", "repos"},
+	}
+	for _, lang := range languages {
+		t := totals[lang]
+		table.Rows = append(table.Rows, []any{lang, t.commits, t.changes, len(t.repos)})
+	}
+	return table
+}
+
+// Write 以指定格式写出表
+func Write(w io.Writer, table Table, format string) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, table)
+	case FormatNDJSON:
+		return writeNDJSON(w, table)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// WriteFiles 将每张表写入目录中的单独文件，如 repos.csv，返回写入的文件路径
+func WriteFiles(dir string, tables []Table, format string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, table := range tables {
+		path := filepath.Join(dir, table.Name+"."+format)
+		file, err := os.Create(path)
+		if err != nil {
+			return paths, err
+		}
+		err = Write(file, table, format)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// writeCSV 写出带表头的 CSV
+func writeCSV(w io.Writer, table Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Columns); err != nil {
+		return err
+	}
+	record := make([]string, len(table.Columns))
+	for _, row := range table.Rows {
+		for i, value := range row {
+			record[i] = cellString(value, listSeparator)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteMarkdown 以 Markdown 表格写出表，数值列右对齐，列表字段以逗号连接
+func WriteMarkdown(w io.Writer, table Table) error {
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(table.Columns, " | ") + " |\n|")
+	for i := range table.Columns {
+		if len(table.Rows) > 0 && i < len(table.Rows[0]) && isInt(table.Rows[0][i]) {
+			b.WriteString("---:|")
+		} else {
+			b.WriteString("---|")
+		}
+	}
+	b.WriteString("\n")
+	for _, row := range table.Rows {
+		cells := make([]string, len(row))
+		for i, value := range row {
+			cells[i] = strings.ReplaceAll(cellString(value, ", "), "|", "\\|")
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// cellString 将单元格的值转换为文本，列表字段以 sep 连接
+func cellString(value any, sep string) string {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case []string:
+		return strings.Join(v, sep)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// isInt 判断单元格的值是否为整数
+func isInt(value any) bool {
+	_, ok := value.(int)
+	return ok
+}
+
+// writeNDJSON 每行写出一个对象，字段顺序与列顺序一致
+func writeNDJSON(w io.Writer, table Table) error {
+	writer := bufio.NewWriter(w)
+	for _, row := range table.Rows {
+		writer.WriteByte('{')
+		for i, value := range row {
+			if i > 0 {
+				writer.WriteByte(',')
+			}
+			key, _ := json.Marshal(table.Columns[i])
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			writer.Write(key)
+			writer.WriteByte(':')
+			writer.Write(encoded)
+		}
+		writer.WriteString("}\n")
+	}
+	return writer.Flush()
+}
+
+// sortedKeys 返回排序后的键
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// nonNil 将空列表输出为 [] 而不是 null
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
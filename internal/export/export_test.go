@@ -0,0 +1,172 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// testCommits 测试用的提交记录，故意不按时间排序
+func testCommits() []git.CommitInfo {
+	return []git.CommitInfo{
+		{
+			Hash:         "def7654321",
+			Author:       "alice",
+			Date:         time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC),
+			Message:      "fix: handle \"empty\", input",
+			Branches:     []string{"main", "release"},
+			ChangedFiles: []string{"main.go", "web/app.ts"},
+			LinesAdded:   5,
+			LinesDeleted: 2,
+			RepoPath:     "/work/api",
+		},
+		{
+			Hash:         "abc1234567",
+			Author:       "alice",
+			Date:         time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC),
+			Message:      "feat: add endpoint",
+			ChangedFiles: []string{"server.go", "README.md"},
+			LinesAdded:   40,
+			RepoPath:     "/work/api",
+		},
+		{
+			Hash:         "0123456789",
+			Author:       "bob",
+			Date:         time.Date(2024, 3, 5, 18, 0, 0, 0, time.UTC),
+			Message:      "docs: update",
+			ChangedFiles: []string{"index.ts"},
+			LinesAdded:   3,
+			LinesDeleted: 1,
+			RepoPath:     "/work/web",
+		},
+	}
+}
+
+// TestCommitsCSV 测试提交记录的 CSV 输出：排序、转义和列表字段
+func TestCommitsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Commits(testCommits()), FormatCSV); err != nil {
+		t.Fatalf("写出CSV失败: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV无法解析: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("应有表头和3行数据, 得到: %d 行", len(records))
+	}
+	if strings.Join(records[0], ",") != "repo,repo_path,hash,author,date,subject,branches,files,files_changed,lines_added,lines_deleted" {
+		t.Errorf("表头不正确: %v", records[0])
+	}
+	if records[1][2] != "abc1234567" || records[2][2] != "0123456789" || records[3][2] != "def7654321" {
+		t.Errorf("应按时间排序: %v", records[1:])
+	}
+	last := records[3]
+	if last[0] != "api" || last[4] != "2024-04-02T09:00:00Z" || last[5] != `fix: handle "empty", input` {
+		t.Errorf("字段不正确: %v", last)
+	}
+	if last[6] != "main;release" || last[7] != "main.go;web/app.ts" || last[8] != "2" || last[9] != "5" {
+		t.Errorf("列表或数字字段不正确: %v", last)
+	}
+}
+
+// TestCommitsNDJSON 测试每行是一个保持列顺序的 JSON 对象
+func TestCommitsNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Commits(testCommits()), FormatNDJSON); err != nil {
+		t.Fatalf("写出NDJSON失败: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("应有3行, 得到: %d", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], `{"repo":"api","repo_path":"/work/api","hash":"abc1234567"`) {
+		t.Errorf("字段顺序不正确: %s", lines[0])
+	}
+
+	var row struct {
+		Branches []string `json:"branches"`
+		Files    []string `json:"files"`
+		Added    int      `json:"lines_added"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &row); err != nil {
+		t.Fatalf("行不是合法的JSON: %v", err)
+	}
+	if row.Branches == nil || len(row.Branches) != 0 || len(row.Files) != 2 || row.Added != 40 {
+		t.Errorf("字段值不正确: %+v", row)
+	}
+}
+
+// TestAggregates 测试按仓库、月份和语言汇总
+func TestAggregates(t *testing.T) {
+	tables := Aggregates(testCommits())
+	if len(tables) != 3 || tables[0].Name != "repos" || tables[1].Name != "months" || tables[2].Name != "languages" {
+		t.Fatalf("汇总表不正确: %+v", tables)
+	}
+
+	repos := tables[0].Rows
+	if len(repos) != 2 || repos[0][0] != "api" || repos[0][2] != 2 || repos[0][3] != 45 || repos[0][5] != 4 {
+		t.Errorf("仓库汇总不正确: %v", repos)
+	}
+	if repos[0][7] != "2024-03-05T10:00:00Z" || repos[0][8] != "2024-04-02T09:00:00Z" {
+		t.Errorf("首末提交时间不正确: %v", repos[0])
+	}
+
+	months := tables[1].Rows
+	if len(months) != 2 || months[0][0] != "2024-03" || months[0][1] != 2 || months[0][4] != 1 || months[0][5] != 2 {
+		t.Errorf("月份汇总不正确: %v", months)
+	}
+
+	languages := tables[2].Rows
+	if len(languages) != 2 || languages[0][0] != "Go" || languages[0][2] != 2 || languages[1][0] != "TypeScript" || languages[1][3] != 2 {
+		t.Errorf("语言汇总不正确: %v", languages)
+	}
+}
+
+// TestWriteFiles 测试每张表写入单独的文件
+func TestWriteFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	paths, err := WriteFiles(dir, Aggregates(testCommits()), FormatCSV)
+	if err != nil {
+		t.Fatalf("写入汇总文件失败: %v", err)
+	}
+	if len(paths) != 3 || filepath.Base(paths[0]) != "repos.csv" {
+		t.Fatalf("文件路径不正确: %v", paths)
+	}
+	content, err := os.ReadFile(paths[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "month,commits,") {
+		t.Errorf("文件内容不正确: %s", content)
+	}
+
+	if err := Write(&bytes.Buffer{}, Table{}, "xlsx"); err == nil {
+		t.Error("不支持的格式应返回错误")
+	}
+}
+
+// TestWriteMarkdown 测试 Markdown 表格的对齐和转义
+func TestWriteMarkdown(t *testing.T) {
+	table := Table{
+		Columns: []string{"repo", "commits", "authors"},
+		Rows:    [][]any{{"a|b", 3, []string{"alice", "bob"}}},
+	}
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, table); err != nil {
+		t.Fatal(err)
+	}
+	want := "| repo | commits | authors |\n|---|---:|---|\n| a\\|b | 3 | alice, bob |\n"
+	if buf.String() != want {
+		t.Errorf("Markdown 表格不正确:\n%s\n期望:\n%s", buf.String(), want)
+	}
+}
@@ -2,9 +2,12 @@ package git
 
 import (
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,31 +41,51 @@ func NewGitOptions(repoPath string) *Options {
 	return opts
 }
 
+// repoPath 返回选项中的仓库路径，选项为空时返回当前目录
+func (o *Options) repoPath() string {
+	if o == nil || o.RepoPath == "" {
+		return "."
+	}
+	return o.RepoPath
+}
+
 // CommitInfo 表示一个Git提交的信息
 type CommitInfo struct {
 	Hash         string
 	Author       string
+	Email        string // 作者邮箱，经过 .mailmap 解析
 	Date         time.Time
 	Message      string
+	Body         string   // 提交消息正文（不含标题行）
 	Branches     []string // 分支信息
 	ChangedFiles []string
-	RepoPath     string // 仓库路径，标识提交来自哪个仓库
+	FileStats    []FileStat // 每个变更文件的增删行数，与 ChangedFiles 顺序相同
+	LinesAdded   int        // 新增行数
+	LinesDeleted int        // 删除行数
+	RepoPath     string     // 仓库路径，标识提交来自哪个仓库
 }
 
-// GetCommitsBetween 获取指定时间范围内的所有提交
-func GetCommitsBetween(fromDate, toDate time.Time, opts *Options) ([]CommitInfo, error) {
-	// 格式化日期为git log可接受的格式
-	fromStr := fromDate.Format("2006-01-02")
-	toStr := toDate.Format("2006-01-02")
+// FileStat 一个变更文件的增删行数，二进制文件均为 0
+type FileStat struct {
+	Path    string
+	Added   int
+	Deleted int
+}
 
-	// 构建git log命令的参数列表
+// GetCommitsBetween 获取指定时间范围内的所有提交，fromDate 为零值表示不限开始时间
+func GetCommitsBetween(fromDate, toDate time.Time, opts *Options) ([]CommitInfo, error) {
+	// 构建git log命令的参数列表，时间精确到秒，避免 git 用当前时刻补全只有日期的参数
 	args := []string{
 		"log",
-		"--all",                            // 获取所有分支的提交
-		"--pretty=format:%H|%an|%ad|%s|%D", // 添加%D获取分支信息
+		"--all",                           // 获取所有分支的提交
+		"--pretty=format:" + recordFormat, // 包含分支信息和消息正文
 		"--date=iso",
-		"--after=" + fromStr,
-		"--before=" + toStr,
+		"--numstat",    // 获取变更文件及增删行数
+		"--no-renames", // 重命名按删除和新增处理，简化路径解析
+		"--before=" + toDate.Format(gitTimeLayout),
+	}
+	if !fromDate.IsZero() {
+		args = append(args, "--after="+fromDate.Format(gitTimeLayout))
 	}
 
 	// 如果指定了作者，添加作者筛选条件
@@ -108,11 +131,228 @@ func GetCommitsThisWeek(opts *Options) ([]CommitInfo, error) {
 	return GetCommitsBetween(monday, nextMonday, opts)
 }
 
+// Branch 一个尚未合并到当前分支的本地分支
+type Branch struct {
+	Name       string    // 分支名
+	RepoPath   string    // 仓库路径
+	Author     string    // 最后一次提交的作者
+	LastCommit time.Time // 最后一次提交的时间
+	Subject    string    // 最后一次提交的标题
+	Ahead      int       // 当前分支中没有的提交数
+}
+
+// branchFormat 每个分支的输出格式：分支名、最后一次提交的时间、作者和标题
+const branchFormat = "%(refname:short)%1f%(committerdate:iso-strict)%1f%(authorname)%1f%(subject)"
+
+// InProgressBranches 返回 since 之后有提交、尚未合并到当前分支的本地分支，按最后一次提交的时间倒序。
+// 指定了作者时只返回最后一次提交的作者名包含该名称的分支，与 git log --author 一致
+func InProgressBranches(since time.Time, opts *Options) ([]Branch, error) {
+	cmd := exec.Command("git", "for-each-ref", "--no-merged=HEAD", "--sort=-committerdate", "--format="+branchFormat, "refs/heads")
+	cmd.Dir = opts.repoPath()
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	author := ""
+	if opts != nil {
+		author = opts.Author
+	}
+	branches := parseBranches(string(output), since, author)
+	for i := range branches {
+		branches[i].RepoPath = opts.repoPath()
+		count := exec.Command("git", "rev-list", "--count", "HEAD.."+branches[i].Name)
+		count.Dir = opts.repoPath()
+		if out, err := count.Output(); err == nil {
+			branches[i].Ahead, _ = strconv.Atoi(strings.TrimSpace(string(out)))
+		}
+	}
+	return branches, nil
+}
+
+// parseBranches 解析 git for-each-ref 的输出，跳过 since 之前的分支和其他作者的分支
+func parseBranches(output string, since time.Time, author string) []Branch {
+	var branches []Branch
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, fieldSeparator)
+		if len(fields) != 4 {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil || date.Before(since) {
+			continue
+		}
+		if author != "" && !strings.Contains(strings.ToLower(fields[2]), strings.ToLower(author)) {
+			continue
+		}
+		branches = append(branches, Branch{Name: fields[0], Author: fields[2], LastCommit: date, Subject: fields[3]})
+	}
+	return branches
+}
+
+// RefRange 两个引用之间的版本范围，From 为空表示从第一个提交开始
+type RefRange struct {
+	From string
+	To   string
+}
+
+// String 返回 git log 使用的修订范围，如 v1.2.0..v1.3.0
+func (r RefRange) String() string {
+	if r.From == "" {
+		return r.To
+	}
+	return r.From + ".." + r.To
+}
+
+// ResolveRefRange 解析版本范围表达式：A..B 表示 A 之后到 B 为止的提交，省略 B 表示 HEAD，
+// 省略 A 表示从第一个提交开始；只有 B 时从 B 之前最近的标签开始，表达式为空时为最近的标签到 HEAD。
+// 找不到更早的标签时从第一个提交开始
+func ResolveRefRange(expr string, opts *Options) (RefRange, error) {
+	expr = strings.TrimSpace(expr)
+	var r RefRange
+	if from, to, ok := strings.Cut(expr, ".."); ok {
+		r = RefRange{From: from, To: strings.TrimPrefix(to, ".")}
+		if r.To == "" {
+			r.To = "HEAD"
+		}
+	} else {
+		r.To = expr
+		if r.To == "" {
+			r.To = "HEAD"
+		}
+		// 不包括 B 本身的标签，从 B 的父提交开始查找；B 没有父提交时返回错误，视为从第一个提交开始
+		if tag, err := runGit(opts, "describe", "--tags", "--abbrev=0", r.To+"^"); err == nil {
+			r.From = tag
+		}
+	}
+
+	for _, ref := range []string{r.From, r.To} {
+		if ref == "" {
+			continue
+		}
+		if _, err := runGit(opts, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+			return RefRange{}, fmt.Errorf("unknown revision %q", ref)
+		}
+	}
+	return r, nil
+}
+
+// RefDate 返回引用所指提交的提交时间
+func RefDate(ref string, opts *Options) (time.Time, error) {
+	output, err := runGit(opts, "log", "-1", "--format=%cI", ref)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, output)
+}
+
+// RevisionAt 返回 HEAD 历史中指定时间之前的最后一个提交，没有时返回空
+func RevisionAt(t time.Time, opts *Options) (string, error) {
+	return runGit(opts, "rev-list", "-1", "--before="+t.Format(gitTimeLayout), "HEAD")
+}
+
+// Reachable 返回从修订可以到达、在指定时间之后提交的哈希，用于判断提交是否已合并
+func Reachable(rev string, since time.Time, opts *Options) (map[string]bool, error) {
+	args := []string{"rev-list", rev}
+	if !since.IsZero() {
+		args = append(args, "--since="+since.Format(gitTimeLayout))
+	}
+	output, err := runGit(opts, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[string]bool)
+	for _, hash := range strings.Fields(output) {
+		hashes[hash] = true
+	}
+	return hashes, nil
+}
+
+// BlameLines 返回文件在修订中的每一行最后由哪个提交引入，按提交哈希统计行数，忽略空白变更。
+// 文件在修订中不存在时返回空
+func BlameLines(rev, path string, opts *Options) (map[string]int, error) {
+	if _, err := runGit(opts, "cat-file", "-e", rev+":"+path); err != nil {
+		return map[string]int{}, nil
+	}
+	output, err := runGit(opts, "blame", "-w", "--porcelain", rev, "--", path)
+	if err != nil {
+		return nil, err
+	}
+	return parseBlame(output), nil
+}
+
+// parseBlame 解析 git blame --porcelain 的输出：每一行内容之前都有以提交哈希开头的行头，
+// 内容行以制表符开头。哈希为 SHA-1（40位）或 SHA-256（64位）
+func parseBlame(output string) map[string]int {
+	counts := make(map[string]int)
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && (len(fields[0]) == 40 || len(fields[0]) == 64) && isHex(fields[0]) {
+			counts[fields[0]]++
+		}
+	}
+	return counts
+}
+
+// isHex 判断字符串是否只包含小写十六进制字符
+func isHex(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// GetCommitsInRange 获取版本范围内所有作者的提交，不包括合并提交
+func GetCommitsInRange(r RefRange, opts *Options) ([]CommitInfo, error) {
+	cmd := exec.Command("git", "log",
+		"--no-merges",
+		"--pretty=format:"+recordFormat,
+		"--date=iso",
+		"--numstat",
+		"--no-renames",
+		r.String(),
+		"--")
+	cmd.Dir = opts.repoPath()
+
+	output, err := cmd.Output()
+	if err != nil {
+		msg := i18n.T()
+		return nil, fmt.Errorf("%s: %w", msg.ErrorGitLogFailed, err)
+	}
+
+	commits, err := parseCommits(string(output))
+	if err != nil {
+		return nil, err
+	}
+	for i := range commits {
+		commits[i].RepoPath = opts.repoPath()
+	}
+	return commits, nil
+}
+
+// runGit 在仓库中执行 git 命令，返回去除首尾空白的输出
+func runGit(opts *Options, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = opts.repoPath()
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // GetCommitDetails 获取指定提交的详细信息
 func GetCommitDetails(hash string, opts *Options) (*CommitInfo, error) {
-	// 获取提交的基本信息
+	// 获取提交的基本信息（不输出补丁内容，避免干扰解析）
 	cmd := exec.Command("git", "show",
-		"--pretty=format:%H|%an|%ad|%s|%D",
+		"--no-patch",
+		"--pretty=format:%H|%aN|%ad|%s|%D",
 		"--date=iso",
 		hash)
 
@@ -151,12 +391,74 @@ func GetCommitDetails(hash string, opts *Options) (*CommitInfo, error) {
 	}
 
 	// 解析变更文件列表
-	files := strings.Split(strings.TrimSpace(string(outputFiles)), "\n")
-	commit.ChangedFiles = files
+	for _, file := range strings.Split(strings.TrimSpace(string(outputFiles)), "\n") {
+		if file != "" {
+			commit.ChangedFiles = append(commit.ChangedFiles, file)
+		}
+	}
+	commit.RepoPath = opts.repoPath()
 
 	return &commit, nil
 }
 
+// GetCommitDiff 获取指定提交的补丁内容，path 不为空时只返回该文件的变更
+func GetCommitDiff(hash, path string, opts *Options) (string, error) {
+	args := []string{"show", "--pretty=format:", "--patch", "--no-color", hash}
+	if path != "" {
+		args = append(args, "--", path)
+	}
+
+	cmd := exec.Command("git", args...)
+
+	// 设置工作目录
+	if opts != nil {
+		cmd.Dir = opts.RepoPath
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		msg := i18n.T()
+		return "", fmt.Errorf("%s: %w", msg.ErrorGetCommitDiff, err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ListFiles 列出仓库在指定引用下某个目录中的文件和子目录
+func ListFiles(ref, dir string, opts *Options) ([]string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	args := []string{"ls-tree", "--name-only", ref}
+	if dir = strings.Trim(dir, "/"); dir != "" && dir != "." {
+		// 以斜杠结尾时 ls-tree 列出目录内容而不是目录本身
+		args = append(args, dir+"/")
+	}
+
+	cmd := exec.Command("git", args...)
+
+	// 设置工作目录
+	if opts != nil {
+		cmd.Dir = opts.RepoPath
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		msg := i18n.T()
+		return nil, fmt.Errorf("%s: %w", msg.ErrorListFiles, err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+
+	return files, nil
+}
+
 // GetGitUserName 获取Git用户名
 func GetGitUserName(repoPath string) (string, error) {
 	// 构建git config命令获取用户名
@@ -183,8 +485,62 @@ func GetGitUserName(repoPath string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// 记录分隔符，用于在 git log 输出中区分多行的消息正文
+const (
+	recordSeparator = "\x1e"
+	fieldSeparator  = "\x1f"
+)
+
+// recordFormat 每个提交的输出格式：记录分隔符、标题行、正文、作者邮箱，之后是 --numstat 的输出。
+// 作者名和邮箱使用 %aN 和 %aE，按 .mailmap 合并同一贡献者的不同名称
+const recordFormat = "%x1e%H|%aN|%ad|%s|%D%x1f%b%x1f%aE%x1f"
+
+// gitTimeLayout 传给 git log --after 和 --before 的时间格式
+const gitTimeLayout = "2006-01-02 15:04:05 -0700"
+
 // parseCommits 解析git log的输出
 func parseCommits(output string) ([]CommitInfo, error) {
+	if strings.Contains(output, recordSeparator) {
+		return parseRecords(output)
+	}
+	return parseLines(output)
+}
+
+// parseRecords 解析使用 recordFormat 格式输出的提交
+func parseRecords(output string) ([]CommitInfo, error) {
+	var commits []CommitInfo
+	for _, record := range strings.Split(output, recordSeparator) {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+
+		// 记录由标题行、正文、作者邮箱和变更文件统计组成，不含邮箱的记录只有三部分
+		fields := strings.SplitN(record, fieldSeparator, 4)
+		header := fields[0]
+		var body, email, rest string
+		switch len(fields) {
+		case 4:
+			body, email, rest = fields[1], fields[2], fields[3]
+		case 3:
+			body, rest = fields[1], fields[2]
+		}
+
+		parsed, err := parseLines(header + "\n" + rest)
+		if err != nil {
+			return nil, err
+		}
+		if len(parsed) == 0 {
+			continue
+		}
+		parsed[0].Body = strings.TrimSpace(body)
+		parsed[0].Email = strings.TrimSpace(email)
+		commits = append(commits, parsed[0])
+	}
+	return commits, nil
+}
+
+// parseLines 逐行解析标题行和 --numstat 输出
+func parseLines(output string) ([]CommitInfo, error) {
 	lines := strings.Split(strings.TrimSpace(output), "\n")
 	commits := make([]CommitInfo, 0, len(lines))
 
@@ -193,6 +549,18 @@ func parseCommits(output string) ([]CommitInfo, error) {
 			continue
 		}
 
+		// --numstat 输出的文件变更行，归属于上一个提交
+		if added, deleted, file, ok := parseNumstatLine(line); ok {
+			if len(commits) > 0 {
+				last := &commits[len(commits)-1]
+				last.ChangedFiles = append(last.ChangedFiles, file)
+				last.FileStats = append(last.FileStats, FileStat{Path: file, Added: added, Deleted: deleted})
+				last.LinesAdded += added
+				last.LinesDeleted += deleted
+			}
+			continue
+		}
+
 		parts := strings.SplitN(line, "|", 5) // 增加了分支信息字段
 		if len(parts) < 5 {
 			continue
@@ -262,8 +630,121 @@ func parseCommits(output string) ([]CommitInfo, error) {
 	return commits, nil
 }
 
-// DiscoverGitRepos 发现指定目录下的所有Git仓库
-func DiscoverGitRepos(rootPath string) ([]string, error) {
+// parseNumstatLine 解析 --numstat 输出的一行，格式为 "新增\t删除\t文件路径"
+func parseNumstatLine(line string) (added, deleted int, file string, ok bool) {
+	parts := strings.SplitN(line, "\t", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return 0, 0, "", false
+	}
+
+	// 二进制文件的增删行数显示为 "-"
+	if parts[0] != "-" {
+		n, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return 0, 0, "", false
+		}
+		added = n
+	}
+	if parts[1] != "-" {
+		n, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return 0, 0, "", false
+		}
+		deleted = n
+	}
+
+	return added, deleted, parts[2], true
+}
+
+// manifestFiles 常见的依赖清单文件
+var manifestFiles = []string{
+	"go.mod",
+	"package.json",
+	"requirements.txt",
+	"pyproject.toml",
+	"Pipfile",
+	"pom.xml",
+	"build.gradle",
+	"build.gradle.kts",
+	"Cargo.toml",
+	"Gemfile",
+	"composer.json",
+	"Dockerfile",
+	"docker-compose.yml",
+}
+
+// ReadManifests 读取仓库根目录下的依赖清单文件内容，返回文件名到内容的映射
+func ReadManifests(opts *Options) map[string]string {
+	manifests := make(map[string]string)
+	for _, name := range manifestFiles {
+		cmd := exec.Command("git", "show", "HEAD:"+name)
+
+		// 设置工作目录
+		if opts != nil {
+			cmd.Dir = opts.RepoPath
+		}
+
+		// 文件不存在时忽略
+		output, err := cmd.Output()
+		if err != nil {
+			continue
+		}
+		manifests[name] = string(output)
+	}
+	return manifests
+}
+
+// codeOwnersFiles GitHub 和 GitLab 查找 CODEOWNERS 文件的位置，按优先级排列
+var codeOwnersFiles = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// ReadCodeOwners 读取仓库 HEAD 中的 CODEOWNERS 文件，没有时返回空
+func ReadCodeOwners(opts *Options) string {
+	for _, name := range codeOwnersFiles {
+		output, err := runGit(opts, "show", "HEAD:"+name)
+		if err == nil {
+			return output
+		}
+	}
+	return ""
+}
+
+// RemoteWebURL 返回仓库 origin 远程的网页地址，如 https://github.com/org/repo，没有远程或无法识别时返回空
+func RemoteWebURL(opts *Options) string {
+	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd.Dir = opts.repoPath()
+
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return webURL(strings.TrimSpace(string(output)))
+}
+
+// webURL 将 git 远程地址转换为网页地址，支持 https、ssh 和 git@host:path 形式，去掉用户信息和 .git 后缀
+func webURL(remote string) string {
+	remote = strings.TrimSuffix(strings.TrimSuffix(remote, "/"), ".git")
+	switch {
+	case strings.HasPrefix(remote, "https://"), strings.HasPrefix(remote, "http://"), strings.HasPrefix(remote, "ssh://"):
+		u, err := url.Parse(remote)
+		if err != nil || u.Hostname() == "" {
+			return ""
+		}
+		return "https://" + u.Hostname() + u.Path
+	case strings.Contains(remote, ":") && !strings.Contains(remote, "://"):
+		host, path, _ := strings.Cut(remote, ":")
+		if i := strings.LastIndex(host, "@"); i >= 0 {
+			host = host[i+1:]
+		}
+		if host == "" || path == "" {
+			return ""
+		}
+		return "https://" + host + "/" + strings.TrimPrefix(path, "/")
+	}
+	return ""
+}
+
+// DiscoverGitRepos 发现指定目录下的所有Git仓库，扫描进度写到 progress
+func DiscoverGitRepos(rootPath string, progress io.Writer) ([]string, error) {
 	var repos []string
 
 	// 如果根路径为空，使用当前目录
@@ -309,7 +790,7 @@ func DiscoverGitRepos(rootPath string) ([]string, error) {
 	}
 
 	msg := i18n.T()
-	fmt.Printf(msg.InfoScanningDirectory+"\n", absRootPath)
+	fmt.Fprintf(progress, msg.InfoScanningDirectory+"\n", absRootPath)
 
 	// 遍历目录
 	err = filepath.Walk(absRootPath, func(path string, info os.FileInfo, err error) error {
@@ -324,7 +805,7 @@ func DiscoverGitRepos(rootPath string) ([]string, error) {
 			if info.Name() == ".git" {
 				repoPath := filepath.Dir(path)
 				repos = append(repos, repoPath)
-				fmt.Printf(msg.InfoFoundGitRepo+"\n", repoPath)
+				fmt.Fprintf(progress, msg.InfoFoundGitRepo+"\n", repoPath)
 				// 跳过.git目录的子目录遍历
 				return filepath.SkipDir
 			}
@@ -356,6 +837,6 @@ func DiscoverGitRepos(rootPath string) ([]string, error) {
 		return nil, fmt.Errorf("%s: %w", msg.ErrorWalkDirectory, err)
 	}
 
-	fmt.Printf(msg.InfoScanComplete+"\n", len(repos))
+	fmt.Fprintf(progress, msg.InfoScanComplete+"\n", len(repos))
 	return repos, nil
 }
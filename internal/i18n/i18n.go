@@ -51,6 +51,7 @@ type Messages struct {
 	FormatMarkdown string
 	FormatJSON     string
 	FormatText     string
+	FormatHTML     string
 
 	// 提示信息
 	InputRepoPath  string
@@ -115,6 +116,7 @@ type Messages struct {
 	InfoReportSaved      string
 	InfoTimeRange        string
 	InfoCustomTimeRange  string
+	InfoToolCall         string
 
 	// 分析类型标签
 	LabelAnalysisTypeProfile    string
@@ -151,6 +153,247 @@ type Messages struct {
 	ReportAIAnalysis           string
 	ReportFooter               string
 
+	// 核查相关
+	ReportVerification        string
+	ReportVerificationSummary string
+	ReportAllClaimsVerified   string
+	ReportUnverifiedMarker    string
+	InfoVerifyingClaims       string
+
+	// 工作流相关
+	InfoClusteringCommits      string
+	InfoFoundWorkstreams       string
+	WarningEmbeddingFailed     string
+	ReportMajorInitiatives     string
+	ReportWorkstreamCommits    string
+	ReportWorkstreamRepos      string
+	ReportWorkstreamKeywords   string
+	ReportWorkstreamHighlights string
+
+	// 提交意图相关
+	ReportCommitIntents     string
+	ReportConventionalRatio string
+	ReportBreakingChanges   string
+	ReportIntent            string
+	ReportRatio             string
+
+	// 工单相关
+	InfoFoundTickets      string
+	ErrorInvalidIssueFlag string
+	ReportTickets         string
+	ReportTicket          string
+	ReportTicketStatus    string
+	ReportTicketClosed    string
+	ReportTicketOpen      string
+
+	// HTML报告相关
+	ReportContributionCalendar string
+	ReportCommitsByHour        string
+	ReportCommitsByWeekday     string
+	ReportLanguages            string
+	ReportRepositories         string
+	ReportLinesChanged         string
+	ReportActiveDays           string
+	ReportWeekdayNames         string // 以空格分隔的周一至周日名称
+	ReportLess                 string
+	ReportMore                 string
+
+	// SVG图表相关
+	ChartCommitsPerMonth string
+	BadgeTopLanguage     string
+	BadgeCommitsInYear   string
+	BadgeCommitsSince    string
+	BadgeRepos           string
+	InfoRenderedCharts   string
+	ErrorRenderFailed    string
+	CmdRenderShort       string
+	FlagRenderDir        string
+	FlagRenderTheme      string
+
+	// 报告模板相关
+	FlagTemplate          string
+	CmdTemplateShort      string
+	ErrorTemplateNotFound string
+
+	// JSON Resume相关
+	FormatJSONResume string
+	FlagMergeResume  string
+	ErrorReadResume  string
+
+	// LaTeX和DOCX简历相关
+	FormatLaTeX      string
+	FormatDOCX       string
+	ResumeTitle      string
+	ResumeProjects   string
+	ResumeSkills     string
+	ResumeTechStack  string
+	ResumePresent    string
+	FlagResumeStyle  string
+	ErrorResumeStyle string
+
+	// JSON报告schema相关
+	FlagIncludeCommits string
+	CmdSchemaShort     string
+
+	// CSV和NDJSON导出相关
+	FlagAggregateDir      string
+	InfoExportedCommits   string
+	InfoAggregatesWritten string
+	ErrorExportFailed     string
+
+	// 多格式输出相关
+	FlagOutDir                   string
+	FlagFileNameTemplate         string
+	ErrorUnknownFormat           string
+	ErrorOutputMultipleFormats   string
+	ErrorTemplateMultipleFormats string
+
+	// 终端显示相关
+	ReportMonthlyActivity string
+
+	// 提交引用相关
+	ReportSources           string
+	ReportInvalidCitations  string
+	WarningInvalidCitations string
+
+	// 子命令相关
+	CmdAnalyzeShort         string
+	CmdStatsShort           string
+	CmdScanShort            string
+	CmdPromptsShort         string
+	CmdPromptsShowShort     string
+	CmdCacheShort           string
+	CmdCacheClearShort      string
+	CmdConfigShort          string
+	FlagNoCache             string
+	FlagStatsFormat         string
+	HintUsage               string
+	ReportCommitStatistics  string
+	InfoFoundRepos          string
+	LabelBuiltinPrompt      string
+	ErrorUnknownPromptType  string
+	InfoCachedAnalysis      string
+	WarningCacheWriteFailed string
+	InfoCacheDir            string
+	InfoCacheEntries        string
+	InfoCacheCleared        string
+	ErrorCacheFailed        string
+
+	// 配置文件相关
+	CmdConfigShowShort   string
+	FlagConfig           string
+	FlagPreset           string
+	ErrorConfigFailed    string
+	ErrorConfigValue     string
+	ErrorUnknownPreset   string
+	LabelConfigNotFound  string
+	LabelConfigNoPresets string
+
+	// 时间范围相关
+	FlagFiscalStart       string
+	ErrorFiscalStart      string
+	ErrorInvalidTimeRange string
+	LabelRangeStart       string
+	TimeRangeExpression   string
+	InputTimeRange        string
+
+	// 工作报告相关
+	AnalysisWeekly              string
+	AnalysisDaily               string
+	LabelAnalysisTypeWeekly     string
+	LabelAnalysisTypeDaily      string
+	ReportTitleWeekly           string
+	ReportTitleDaily            string
+	InfoFoundInProgressBranches string
+
+	// 绩效自评相关
+	AnalysisReview          string
+	LabelAnalysisTypeReview string
+	ReportTitleReview       string
+	FlagRubric              string
+	ErrorRubricRequired     string
+	ErrorRubricLoad         string
+	InputRubricPath         string
+	InfoRubricEvidence      string
+
+	// 技能匹配相关
+	CmdMatchShort            string
+	FlagMatchLevel           string
+	LabelAnalysisTypeMatch   string
+	ReportTitleMatch         string
+	ErrorMatchTarget         string
+	ErrorMatchTargetRequired string
+	InfoSkillMatch           string
+	WarningNoSkillsFound     string
+
+	// 更新日志相关
+	CmdChangelogShort        string
+	FlagRelease              string
+	FlagChangelogOnly        string
+	ErrorInvalidRefRange     string
+	ErrorNoCommitsInRefRange string
+	ErrorReleaseAnalysis     string
+	InfoChangelogCommits     string
+	WarningChangelogEmpty    string
+	ReportTitleRelease       string
+
+	// 团队相关
+	CmdTeamShort              string
+	FlagMinCommits            string
+	FlagTeamLocalOnly         string
+	ErrorMinCommits           string
+	ErrorNoTeamMembers        string
+	InfoTeamMembers           string
+	InfoTeamMember            string
+	InfoTeamIndexSaved        string
+	ReportTitleTeam           string
+	ReportTitleMemberStats    string
+	ReportTeamMembers         string
+	ReportTeamName            string
+	ReportTeamCommits         string
+	ReportTeamDomain          string
+	ReportTeamReports         string
+	ReportTeamCoverage        string
+	ReportTeamArea            string
+	ReportTeamContributors    string
+	ReportTeamBusFactor       string
+	ReportTeamCollaboration   string
+	ReportTeamPair            string
+	ReportTeamSharedFiles     string
+	ReportTeamCoAuthored      string
+	ReportTeamNoCollaboration string
+
+	// 代码归属相关
+	CmdExpertsShort          string
+	FlagHalfLife             string
+	FlagExpertsLimit         string
+	ErrorNoCommitsForPath    string
+	InfoKnowledgeAreas       string
+	ReportTitleExperts       string
+	ReportKnowledgeMap       string
+	ReportKnowledgeArea      string
+	ReportKnowledgeExperts   string
+	ReportBusFactor          string
+	ReportCodeOwnersMismatch string
+	ReportExpertName         string
+	ReportFamiliarity        string
+	ReportExpertLines        string
+	ReportLastCommit         string
+
+	// 代码存活相关
+	FlagBlameSample       string
+	FlagChurnDays         string
+	InfoAnalyzingSurvival string
+	InfoSurvival          string
+	WarningSurvivalFailed string
+	ReportCodeSurvival    string
+	ReportSurvivalRate    string
+	ReportChurnRate       string
+	ReportChurnWindow     string
+	ReportSampledFiles    string
+	ReportLinesAdded      string
+	ReportSurvivingLines  string
+
 	// Cobra命令描述
 	CmdShortDesc    string
 	CmdLongDesc     string
@@ -168,6 +411,12 @@ type Messages struct {
 	FlagModel    string
 	FlagAuthor   string
 
+	FlagMaxToolCalls string
+	FlagVerify       string
+	FlagEmbeddings   string
+	FlagIssuePattern string
+	FlagIssueURL     string
+
 	// 其他错误
 	ErrorCreateOutputFile string
 
@@ -178,6 +427,8 @@ type Messages struct {
 	ErrorGeminiClientFailed string
 	ErrorGeminiAPIFailed    string
 	ErrorLoadPromptTemplate string
+	ErrorEmbeddingFailed    string
+	ErrorToolCallLoop       string
 
 	// 文件和目录错误
 	ErrorGetCurrentDir      string
@@ -194,6 +445,8 @@ type Messages struct {
 	ErrorParseDateFailed    string
 	ErrorGetChangedFiles    string
 	ErrorGetGitUsername     string
+	ErrorGetCommitDiff      string
+	ErrorListFiles          string
 
 	// 其他
 	Canceled         string
@@ -233,6 +486,7 @@ var (
 		FormatMarkdown: "Markdown - Formatted document (Recommended)",
 		FormatJSON:     "JSON - Structured data",
 		FormatText:     "Text - Plain text",
+		FormatHTML:     "HTML - Self-contained page with charts",
 
 		InputRepoPath:  "Enter repository path",
 		InputReposPath: "Enter directory path (containing multiple Git repositories)",
@@ -283,6 +537,7 @@ var (
 		FormatMarkdown: "Markdown - 格式化文档（推荐）",
 		FormatJSON:     "JSON - 结构化数据",
 		FormatText:     "Text - 纯文本",
+		FormatHTML:     "HTML - 带图表的独立网页",
 
 		InputRepoPath:  "输入仓库路径",
 		InputReposPath: "输入仓库目录路径（包含多个Git仓库）",
@@ -393,6 +648,7 @@ func init() {
 	englishMessages.InfoReportSaved = "Report saved to: %s"
 	englishMessages.InfoTimeRange = "Analysis time range: %s (%s to %s)"
 	englishMessages.InfoCustomTimeRange = "Analysis time range: %s to %s"
+	englishMessages.InfoToolCall = "  AI tool call: %s(%s)"
 
 	englishMessages.LabelAnalysisTypeProfile = "Analysis type: Developer Profile"
 	englishMessages.LabelAnalysisTypeExperience = "Analysis type: Project Experience"
@@ -439,6 +695,7 @@ func init() {
 	chineseMessages.InfoReportSaved = "报告已保存到: %s"
 	chineseMessages.InfoTimeRange = "分析时间范围: %s (%s 到 %s)"
 	chineseMessages.InfoCustomTimeRange = "分析时间范围: %s 到 %s"
+	chineseMessages.InfoToolCall = "  AI 调用工具: %s(%s)"
 
 	chineseMessages.LabelAnalysisTypeProfile = "分析类型: 开发者画像"
 	chineseMessages.LabelAnalysisTypeExperience = "分析类型: 项目经验总结"
@@ -544,16 +801,19 @@ Running without any parameters will start interactive configuration mode.`
 // 命令行参数描述
 func init() {
 	// 英文 - 命令行参数
-	englishMessages.FlagAnalysis = "Analysis type (profile=developer profile, experience=project experience, techstack=tech stack)"
-	englishMessages.FlagFrom = "Start date (YYYY-MM-DD format)"
-	englishMessages.FlagTo = "End date (YYYY-MM-DD format)"
-	englishMessages.FlagRange = "Time range (3m=3 months, 6m=6 months, 1y=1 year, 2y=2 years)"
-	englishMessages.FlagFormat = "Output format (text, markdown, json)"
+	englishMessages.FlagAnalysis = "Analysis type (profile=developer profile, experience=project experience, techstack=tech stack, weekly=weekly report, daily=daily report, review=self-review against --rubric)"
+	englishMessages.FlagFrom = "Start date or period, e.g. 2024-03-01, 2024-03, 2025-Q3 (overrides --range)"
+	englishMessages.FlagTo = "End date or period, e.g. 2024-06-30, 2024-06, FY2025; without --from the range is open-ended"
+	englishMessages.FlagRange = "Time range: 6m, 30d, 1y, 2025-Q3, FY2025, last-month, this-week, ytd, since:2024-03, 2024-01..2024-06"
+	englishMessages.FlagFormat = "Output formats, comma-separated (text, markdown, json, html, jsonresume, latex, docx, csv, ndjson)"
 	englishMessages.FlagOutput = "Output file path (default: stdout)"
 	englishMessages.FlagRepo = "Git repository path (default: current directory)"
 	englishMessages.FlagRepos = "Repository directory path, analyze all Git repos in this directory"
 	englishMessages.FlagModel = "Gemini model name (default: gemini-2.5-pro)"
 	englishMessages.FlagAuthor = "Git author name"
+	englishMessages.FlagEmbeddings = "Use Gemini embeddings of commit messages when clustering commits into workstreams"
+	englishMessages.FlagVerify = "Verify AI claims against collected commits and flag unsupported ones"
+	englishMessages.FlagMaxToolCalls = "Maximum number of tool calls the AI may make to inspect commit details and diffs (0 disables)"
 
 	englishMessages.ErrorCreateOutputFile = "Error: Failed to create output file: %v"
 	englishMessages.WarningPromptLoadFailed = "Warning: Failed to load prompt template: %v, using default prompt"
@@ -562,6 +822,8 @@ func init() {
 	englishMessages.ErrorGeminiClientFailed = "Failed to create Gemini client"
 	englishMessages.ErrorGeminiAPIFailed = "Gemini API call failed"
 	englishMessages.ErrorLoadPromptTemplate = "Failed to load prompt template"
+	englishMessages.ErrorEmbeddingFailed = "Failed to generate commit embeddings"
+	englishMessages.ErrorToolCallLoop = "the model kept requesting tool calls after the budget was spent"
 
 	// 英文 - 文件和目录错误
 	englishMessages.ErrorGetCurrentDir = "Failed to get current directory"
@@ -578,18 +840,23 @@ func init() {
 	englishMessages.ErrorParseDateFailed = "Failed to parse date"
 	englishMessages.ErrorGetChangedFiles = "Failed to get changed files"
 	englishMessages.ErrorGetGitUsername = "Failed to get Git username"
+	englishMessages.ErrorGetCommitDiff = "Failed to get commit diff"
+	englishMessages.ErrorListFiles = "Failed to list repository files"
 
 	// 中文 - 命令行参数
-	chineseMessages.FlagAnalysis = "分析类型 (profile=开发者画像, experience=项目经验, techstack=技术栈)"
-	chineseMessages.FlagFrom = "开始日期 (YYYY-MM-DD 格式)"
-	chineseMessages.FlagTo = "结束日期 (YYYY-MM-DD 格式)"
-	chineseMessages.FlagRange = "时间范围 (3m=3个月, 6m=6个月, 1y=1年, 2y=2年)"
-	chineseMessages.FlagFormat = "输出格式 (text, markdown, json)"
+	chineseMessages.FlagAnalysis = "分析类型 (profile=开发者画像, experience=项目经验, techstack=技术栈, weekly=工作周报, daily=工作日报, review=根据 --rubric 撰写绩效自评)"
+	chineseMessages.FlagFrom = "开始日期或时间段，如 2024-03-01、2024-03、2025-Q3 (优先于 --range)"
+	chineseMessages.FlagTo = "结束日期或时间段，如 2024-06-30、2024-06、FY2025；不指定 --from 时不限开始时间"
+	chineseMessages.FlagRange = "时间范围：6m、30d、1y、2025-Q3、FY2025、last-month、this-week、ytd、since:2024-03、2024-01..2024-06"
+	chineseMessages.FlagFormat = "输出格式，多个格式以逗号分隔 (text, markdown, json, html, jsonresume, latex, docx, csv, ndjson)"
 	chineseMessages.FlagOutput = "输出文件路径 (默认为标准输出)"
 	chineseMessages.FlagRepo = "Git仓库路径 (默认为当前目录)"
 	chineseMessages.FlagRepos = "仓库目录路径，分析该目录下的所有Git仓库"
 	chineseMessages.FlagModel = "Gemini模型名称 (默认为gemini-2.5-pro)"
 	chineseMessages.FlagAuthor = "Git作者名称"
+	chineseMessages.FlagEmbeddings = "将提交聚类为工作流时使用Gemini生成的提交消息向量"
+	chineseMessages.FlagVerify = "根据收集的提交核查AI分析中的声明，并标注无依据的内容"
+	chineseMessages.FlagMaxToolCalls = "AI 查看提交详情和代码差异时允许的最大工具调用次数 (0 表示禁用)"
 
 	chineseMessages.ErrorCreateOutputFile = "错误: 创建输出文件失败: %v"
 	chineseMessages.WarningPromptLoadFailed = "警告: 加载提示词模板失败: %v, 使用默认提示词"
@@ -598,6 +865,8 @@ func init() {
 	chineseMessages.ErrorGeminiClientFailed = "创建Gemini客户端失败"
 	chineseMessages.ErrorGeminiAPIFailed = "调用Gemini API失败"
 	chineseMessages.ErrorLoadPromptTemplate = "无法加载提示词模板"
+	chineseMessages.ErrorEmbeddingFailed = "生成提交向量失败"
+	chineseMessages.ErrorToolCallLoop = "工具调用次数用完后模型仍在请求工具调用"
 
 	// 中文 - 文件和目录错误
 	chineseMessages.ErrorGetCurrentDir = "获取当前目录失败"
@@ -614,4 +883,564 @@ func init() {
 	chineseMessages.ErrorParseDateFailed = "解析日期失败"
 	chineseMessages.ErrorGetChangedFiles = "获取变更文件列表失败"
 	chineseMessages.ErrorGetGitUsername = "获取Git用户名失败"
+	chineseMessages.ErrorGetCommitDiff = "获取提交差异失败"
+	chineseMessages.ErrorListFiles = "列出仓库文件失败"
+}
+
+// 核查相关消息
+func init() {
+	// 英文 - 核查
+	englishMessages.ReportVerification = "Claim Verification"
+	englishMessages.ReportVerificationSummary = "%d of %d claims could not be verified against the collected commits, files and manifests:"
+	englishMessages.ReportAllClaimsVerified = "All %d extracted claims are supported by the collected data."
+	englishMessages.ReportUnverifiedMarker = "⚠️[unverified]"
+	englishMessages.InfoVerifyingClaims = "Verifying AI claims against collected evidence..."
+
+	// 中文 - 核查
+	chineseMessages.ReportVerification = "声明核查"
+	chineseMessages.ReportVerificationSummary = "%d/%d 条声明无法在收集的提交、文件和依赖清单中找到依据："
+	chineseMessages.ReportAllClaimsVerified = "提取的 %d 条声明均能在收集的数据中找到依据。"
+	chineseMessages.ReportUnverifiedMarker = "⚠️[未核实]"
+	chineseMessages.InfoVerifyingClaims = "正在根据收集的数据核查AI分析中的声明..."
+}
+
+// 工作流相关消息
+func init() {
+	// 英文 - 工作流
+	englishMessages.InfoClusteringCommits = "Clustering commits into workstreams..."
+	englishMessages.InfoFoundWorkstreams = "  Found %d workstreams"
+	englishMessages.WarningEmbeddingFailed = "Warning: %v, falling back to local text similarity"
+	englishMessages.ReportMajorInitiatives = "Major Initiatives"
+	englishMessages.ReportWorkstreamCommits = "commits"
+	englishMessages.ReportWorkstreamRepos = "Repositories"
+	englishMessages.ReportWorkstreamKeywords = "Keywords"
+	englishMessages.ReportWorkstreamHighlights = "Representative commits"
+
+	// 中文 - 工作流
+	chineseMessages.InfoClusteringCommits = "正在将提交聚类为工作流..."
+	chineseMessages.InfoFoundWorkstreams = "  识别出 %d 个工作流"
+	chineseMessages.WarningEmbeddingFailed = "警告: %v，改用本地文本相似度"
+	chineseMessages.ReportMajorInitiatives = "主要工作"
+	chineseMessages.ReportWorkstreamCommits = "个提交"
+	chineseMessages.ReportWorkstreamRepos = "仓库"
+	chineseMessages.ReportWorkstreamKeywords = "关键词"
+	chineseMessages.ReportWorkstreamHighlights = "代表性提交"
+}
+
+// 提交意图相关消息
+func init() {
+	// 英文 - 提交意图
+	englishMessages.ReportCommitIntents = "Commit Intent"
+	englishMessages.ReportConventionalRatio = "Conventional Commits"
+	englishMessages.ReportBreakingChanges = "Breaking Changes"
+	englishMessages.ReportIntent = "Intent"
+	englishMessages.ReportRatio = "Share"
+
+	// 中文 - 提交意图
+	chineseMessages.ReportCommitIntents = "提交意图分布"
+	chineseMessages.ReportConventionalRatio = "约定式提交占比"
+	chineseMessages.ReportBreakingChanges = "破坏性变更"
+	chineseMessages.ReportIntent = "意图"
+	chineseMessages.ReportRatio = "占比"
+}
+
+// 工单相关消息
+func init() {
+	// 英文 - 工单
+	englishMessages.InfoFoundTickets = "  Found %d referenced tickets"
+	englishMessages.ErrorInvalidIssueFlag = "Error: %v"
+	englishMessages.ReportTickets = "Delivered Tickets"
+	englishMessages.ReportTicket = "Ticket"
+	englishMessages.ReportTicketStatus = "Status"
+	englishMessages.ReportTicketClosed = "closed"
+	englishMessages.ReportTicketOpen = "referenced"
+	englishMessages.FlagIssuePattern = "Additional ticket pattern as name=regex, first capture group is the ticket ID (repeatable)"
+	englishMessages.FlagIssueURL = "Ticket URL template as name=url, {id} and {number} are replaced (repeatable, e.g. jira=https://jira.example.com/browse/{id})"
+
+	// 中文 - 工单
+	chineseMessages.InfoFoundTickets = "  发现 %d 个关联工单"
+	chineseMessages.ErrorInvalidIssueFlag = "错误: %v"
+	chineseMessages.ReportTickets = "交付的工单"
+	chineseMessages.ReportTicket = "工单"
+	chineseMessages.ReportTicketStatus = "状态"
+	chineseMessages.ReportTicketClosed = "已关闭"
+	chineseMessages.ReportTicketOpen = "已引用"
+	chineseMessages.FlagIssuePattern = "额外的工单匹配规则，格式为 名称=正则表达式，第一个捕获组为工单号（可重复）"
+	chineseMessages.FlagIssueURL = "工单链接模板，格式为 名称=链接，{id} 和 {number} 会被替换（可重复，如 jira=https://jira.example.com/browse/{id}）"
+}
+
+// HTML报告相关消息
+func init() {
+	// 英文 - HTML报告
+	englishMessages.ReportContributionCalendar = "Contribution Calendar"
+	englishMessages.ReportCommitsByHour = "Commits by Hour"
+	englishMessages.ReportCommitsByWeekday = "Commits by Weekday"
+	englishMessages.ReportLanguages = "Languages"
+	englishMessages.ReportRepositories = "Repositories"
+	englishMessages.ReportLinesChanged = "Lines added / deleted"
+	englishMessages.ReportActiveDays = "Active days"
+	englishMessages.ReportWeekdayNames = "Mon Tue Wed Thu Fri Sat Sun"
+	englishMessages.ReportLess = "Less"
+	englishMessages.ReportMore = "More"
+
+	// 中文 - HTML报告
+	chineseMessages.ReportContributionCalendar = "贡献日历"
+	chineseMessages.ReportCommitsByHour = "按小时分布"
+	chineseMessages.ReportCommitsByWeekday = "按星期分布"
+	chineseMessages.ReportLanguages = "编程语言"
+	chineseMessages.ReportRepositories = "仓库"
+	chineseMessages.ReportLinesChanged = "新增 / 删除行数"
+	chineseMessages.ReportActiveDays = "活跃天数"
+	chineseMessages.ReportWeekdayNames = "一 二 三 四 五 六 日"
+	chineseMessages.ReportLess = "少"
+	chineseMessages.ReportMore = "多"
+}
+
+// SVG图表相关消息
+func init() {
+	// 英文 - SVG图表
+	englishMessages.ChartCommitsPerMonth = "Commits per Month"
+	englishMessages.BadgeTopLanguage = "top language"
+	englishMessages.BadgeCommitsInYear = "commits %d"
+	englishMessages.BadgeCommitsSince = "commits since %s"
+	englishMessages.BadgeRepos = "repos"
+	englishMessages.InfoRenderedCharts = "SVG charts written to: %s (%d files)"
+	englishMessages.ErrorRenderFailed = "Failed to render SVG charts: %v"
+	englishMessages.CmdRenderShort = "Render SVG charts and badges from commit statistics (no AI required)"
+	englishMessages.FlagRenderDir = "Directory to write SVG charts and badges to (heatmap, languages, commits per month, badges)"
+	englishMessages.FlagRenderTheme = "Themes for SVG charts, comma separated (light, dark)"
+
+	// 中文 - SVG图表
+	chineseMessages.ChartCommitsPerMonth = "每月提交数"
+	chineseMessages.BadgeTopLanguage = "主要语言"
+	chineseMessages.BadgeCommitsInYear = "%d年提交"
+	chineseMessages.BadgeCommitsSince = "%s以来提交"
+	chineseMessages.BadgeRepos = "仓库"
+	chineseMessages.InfoRenderedCharts = "SVG图表已保存到: %s（%d 个文件）"
+	chineseMessages.ErrorRenderFailed = "生成SVG图表失败: %v"
+	chineseMessages.CmdRenderShort = "根据提交统计生成SVG图表和徽章（无需AI）"
+	chineseMessages.FlagRenderDir = "SVG图表和徽章的输出目录（贡献日历、语言占比、每月提交数、徽章）"
+	chineseMessages.FlagRenderTheme = "SVG图表的主题，以逗号分隔（light, dark）"
+}
+
+// 报告模板相关消息
+func init() {
+	// 英文 - 报告模板
+	englishMessages.FlagTemplate = "Custom report template file (Go text/template; .html templates use html/template), see TEMPLATES.md"
+	englishMessages.CmdTemplateShort = "Print the built-in report template for a format (text, markdown, html, latex, docx)"
+	englishMessages.ErrorTemplateNotFound = "No built-in template for format: %s"
+
+	// 中文 - 报告模板
+	chineseMessages.FlagTemplate = "自定义报告模板文件（Go text/template，.html 模板使用 html/template），详见 TEMPLATES.md"
+	chineseMessages.CmdTemplateShort = "输出指定格式的内置报告模板（text、markdown、html、latex、docx）"
+	chineseMessages.ErrorTemplateNotFound = "该格式没有内置模板: %s"
+}
+
+// JSON Resume相关消息
+func init() {
+	// 英文 - JSON Resume
+	englishMessages.FormatJSONResume = "JSON Resume - Projects and skills for CV tooling"
+	englishMessages.FlagMergeResume = "Merge the jsonresume output into this existing resume.json (written back unless --output is set)"
+	englishMessages.ErrorReadResume = "Failed to read resume.json: %v"
+
+	// 中文 - JSON Resume
+	chineseMessages.FormatJSONResume = "JSON Resume - 用于简历工具的项目和技能"
+	chineseMessages.FlagMergeResume = "将 jsonresume 输出合并到已有的 resume.json（未指定 --output 时写回该文件）"
+	chineseMessages.ErrorReadResume = "读取 resume.json 失败: %v"
+}
+
+// LaTeX和DOCX简历相关消息
+func init() {
+	// 英文 - LaTeX和DOCX简历
+	englishMessages.FormatLaTeX = "LaTeX - moderncv resume source"
+	englishMessages.FormatDOCX = "DOCX - Word resume document"
+	englishMessages.ResumeTitle = "Software Engineer"
+	englishMessages.ResumeProjects = "Projects"
+	englishMessages.ResumeSkills = "Skills"
+	englishMessages.ResumeTechStack = "Tech stack"
+	englishMessages.ResumePresent = "present"
+	englishMessages.FlagResumeStyle = "Resume style for latex and docx output (classic, casual, banking, oldstyle, fancy)"
+	englishMessages.ErrorResumeStyle = "Invalid resume style: %v"
+
+	// 中文 - LaTeX和DOCX简历
+	chineseMessages.FormatLaTeX = "LaTeX - moderncv 简历源文件"
+	chineseMessages.FormatDOCX = "DOCX - Word 简历文档"
+	chineseMessages.ResumeTitle = "软件工程师"
+	chineseMessages.ResumeProjects = "项目经验"
+	chineseMessages.ResumeSkills = "专业技能"
+	chineseMessages.ResumeTechStack = "技术栈"
+	chineseMessages.ResumePresent = "至今"
+	chineseMessages.FlagResumeStyle = "latex 和 docx 简历的样式（classic、casual、banking、oldstyle、fancy）"
+	chineseMessages.ErrorResumeStyle = "简历样式无效: %v"
+}
+
+// JSON报告schema相关消息
+func init() {
+	// 英文 - JSON报告schema
+	englishMessages.FlagIncludeCommits = "Include every analyzed commit (files, line stats, classification, tickets) in json output"
+	englishMessages.CmdSchemaShort = "Print the JSON Schema of the json report format"
+
+	// 中文 - JSON报告schema
+	chineseMessages.FlagIncludeCommits = "在 json 输出中包含每个提交的详细数据（文件、行数统计、分类、工单）"
+	chineseMessages.CmdSchemaShort = "输出 json 报告格式的 JSON Schema"
+}
+
+// CSV和NDJSON导出相关消息
+func init() {
+	// 英文 - CSV和NDJSON导出
+	englishMessages.FlagAggregateDir = "Directory for per-repo, per-month and per-language tables (csv and ndjson output only)"
+	englishMessages.InfoExportedCommits = "Exported %d commits"
+	englishMessages.InfoAggregatesWritten = "Aggregate tables written to: %s (%d files)"
+	englishMessages.ErrorExportFailed = "Failed to export commits: %v"
+
+	// 中文 - CSV和NDJSON导出
+	chineseMessages.FlagAggregateDir = "按仓库、月份和语言汇总的表的输出目录（仅用于 csv 和 ndjson 输出）"
+	chineseMessages.InfoExportedCommits = "已导出 %d 个提交"
+	chineseMessages.InfoAggregatesWritten = "汇总表已保存到: %s（%d 个文件）"
+	chineseMessages.ErrorExportFailed = "导出提交记录失败: %v"
+}
+
+// 多格式输出相关消息
+func init() {
+	// 英文 - 多格式输出
+	englishMessages.FlagOutDir = "Write each format to a file in this directory, named by --filename-template"
+	englishMessages.FlagFileNameTemplate = "File name template for --out-dir, without extension ({author}, {analysis}, {format}, {from}, {to})"
+	englishMessages.ErrorUnknownFormat = "Unknown output format: %s"
+	englishMessages.ErrorOutputMultipleFormats = "--output accepts a single format; use --out-dir for several formats"
+	englishMessages.ErrorTemplateMultipleFormats = "--template applies to a single format; run once per custom template"
+
+	// 中文 - 多格式输出
+	chineseMessages.FlagOutDir = "将每种格式输出到该目录中的文件，文件名由 --filename-template 决定"
+	chineseMessages.FlagFileNameTemplate = "--out-dir 中的文件名模板，不含扩展名（{author}、{analysis}、{format}、{from}、{to}）"
+	chineseMessages.ErrorUnknownFormat = "未知的输出格式: %s"
+	chineseMessages.ErrorOutputMultipleFormats = "--output 只能用于一种格式，输出多种格式请使用 --out-dir"
+	chineseMessages.ErrorTemplateMultipleFormats = "--template 只能用于一种格式，请为每个自定义模板分别运行"
+}
+
+// 终端显示相关消息
+func init() {
+	// 英文 - 终端显示
+	englishMessages.ReportMonthlyActivity = "Monthly Activity"
+
+	// 中文 - 终端显示
+	chineseMessages.ReportMonthlyActivity = "每月活跃度"
+}
+
+// 提交引用相关消息
+func init() {
+	// 英文 - 提交引用
+	englishMessages.ReportSources = "Sources"
+	englishMessages.ReportInvalidCitations = "Removed citations that match no analyzed commit: %s"
+	englishMessages.WarningInvalidCitations = "Warning: the analysis cited commits that were not analyzed, citations removed: %s"
+
+	// 中文 - 提交引用
+	chineseMessages.ReportSources = "引用来源"
+	chineseMessages.ReportInvalidCitations = "已删除无法对应到分析提交的引用: %s"
+	chineseMessages.WarningInvalidCitations = "警告: 分析结果引用了不在分析范围内的提交，已删除这些引用: %s"
+}
+
+// 子命令相关消息
+func init() {
+	// 英文 - 子命令
+	englishMessages.CmdAnalyzeShort = "Analyze commits with AI and generate reports (same as running with flags and no subcommand)"
+	englishMessages.CmdStatsShort = "Show commit statistics per repository, month and language (no AI required)"
+	englishMessages.CmdScanShort = "List the Git repositories that would be analyzed, with their remote URLs"
+	englishMessages.CmdPromptsShort = "List the prompt template used by each analysis type"
+	englishMessages.CmdPromptsShowShort = "Print the prompt template for an analysis type (profile, experience, techstack)"
+	englishMessages.CmdCacheShort = "Show the location and size of the AI analysis cache"
+	englishMessages.CmdCacheClearShort = "Remove all cached AI analysis results"
+	englishMessages.CmdConfigShort = "Show the effective configuration (same as config show)"
+	englishMessages.FlagNoCache = "Always call the AI, ignoring and not reusing cached analysis results"
+	englishMessages.FlagStatsFormat = "Output format: markdown, csv or ndjson"
+	englishMessages.HintUsage = "Run '%s --help' for usage."
+	englishMessages.ReportCommitStatistics = "Commit Statistics"
+	englishMessages.InfoFoundRepos = "Found %d repositories"
+	englishMessages.LabelBuiltinPrompt = "(built-in)"
+	englishMessages.ErrorUnknownPromptType = "Unknown analysis type: %s (available: %s)"
+	englishMessages.InfoCachedAnalysis = "Using the cached analysis for identical input (--no-cache to regenerate)"
+	englishMessages.WarningCacheWriteFailed = "Warning: failed to cache the analysis result: %v"
+	englishMessages.InfoCacheDir = "Cache directory: %s"
+	englishMessages.InfoCacheEntries = "Cached analyses: %d (%s)"
+	englishMessages.InfoCacheCleared = "Removed %d cached analyses"
+	englishMessages.ErrorCacheFailed = "Cache error: %v"
+
+	// 中文 - 子命令
+	chineseMessages.CmdAnalyzeShort = "使用AI分析提交记录并生成报告（与不带子命令、指定参数运行相同）"
+	chineseMessages.CmdStatsShort = "按仓库、月份和语言统计提交记录（无需AI）"
+	chineseMessages.CmdScanShort = "列出将要分析的Git仓库及其远程地址"
+	chineseMessages.CmdPromptsShort = "列出各分析类型使用的提示词模板"
+	chineseMessages.CmdPromptsShowShort = "输出指定分析类型的提示词模板（profile、experience、techstack）"
+	chineseMessages.CmdCacheShort = "显示AI分析结果缓存的位置和大小"
+	chineseMessages.CmdCacheClearShort = "删除所有缓存的AI分析结果"
+	chineseMessages.CmdConfigShort = "显示生效的配置（与 config show 相同）"
+	chineseMessages.FlagNoCache = "总是调用AI，不使用也不保存缓存的分析结果"
+	chineseMessages.FlagStatsFormat = "输出格式：markdown、csv 或 ndjson"
+	chineseMessages.HintUsage = "运行 '%s --help' 查看用法。"
+	chineseMessages.ReportCommitStatistics = "提交统计"
+	chineseMessages.InfoFoundRepos = "找到 %d 个仓库"
+	chineseMessages.LabelBuiltinPrompt = "（内置）"
+	chineseMessages.ErrorUnknownPromptType = "未知的分析类型: %s（可用: %s）"
+	chineseMessages.InfoCachedAnalysis = "输入相同，使用缓存的分析结果（使用 --no-cache 重新生成）"
+	chineseMessages.WarningCacheWriteFailed = "警告: 缓存分析结果失败: %v"
+	chineseMessages.InfoCacheDir = "缓存目录: %s"
+	chineseMessages.InfoCacheEntries = "缓存的分析结果: %d 个（%s）"
+	chineseMessages.InfoCacheCleared = "已删除 %d 个缓存的分析结果"
+	chineseMessages.ErrorCacheFailed = "缓存错误: %v"
+}
+
+// 配置文件相关消息
+func init() {
+	// 英文 - 配置文件
+	englishMessages.CmdConfigShowShort = "Show the effective value and source of every option after applying flags, environment, config files and presets"
+	englishMessages.FlagConfig = "User config file (default: $GIT_PROFILE_CONFIG or git-work-profile/config.yaml in the user config directory)"
+	englishMessages.FlagPreset = "Apply a named preset from the config files (default: $GIT_PROFILE_PRESET)"
+	englishMessages.ErrorConfigFailed = "Failed to read configuration: %v"
+	englishMessages.ErrorConfigValue = "Invalid value %q for %s from %s: %v"
+	englishMessages.ErrorUnknownPreset = "Unknown preset: %s (available: %s)"
+	englishMessages.LabelConfigNotFound = "(not found)"
+	englishMessages.LabelConfigNoPresets = "(none)"
+
+	// 中文 - 配置文件
+	chineseMessages.CmdConfigShowShort = "显示应用参数、环境变量、配置文件和预设后每个选项的值及其来源"
+	chineseMessages.FlagConfig = "用户配置文件（默认：$GIT_PROFILE_CONFIG 或用户配置目录下的 git-work-profile/config.yaml）"
+	chineseMessages.FlagPreset = "应用配置文件中的命名预设（默认：$GIT_PROFILE_PRESET）"
+	chineseMessages.ErrorConfigFailed = "读取配置失败: %v"
+	chineseMessages.ErrorConfigValue = "%[3]s 中 %[2]s 的值 %[1]q 无效: %[4]v"
+	chineseMessages.ErrorUnknownPreset = "未知的预设: %s（可用: %s）"
+	chineseMessages.LabelConfigNotFound = "（未找到）"
+	chineseMessages.LabelConfigNoPresets = "（无）"
+}
+
+// 时间范围相关消息
+func init() {
+	// 英文 - 时间范围
+	englishMessages.FlagFiscalStart = "First month of the fiscal year (1-12) for FY expressions; FY2025 is the fiscal year ending in 2025"
+	englishMessages.ErrorFiscalStart = "Invalid --fiscal-start %d, expected a month from 1 to 12"
+	englishMessages.ErrorInvalidTimeRange = "Invalid time range: %v\nSupported expressions: %s"
+	englishMessages.LabelRangeStart = "first commit"
+	englishMessages.TimeRangeExpression = "Other range (2025-Q3, FY2025, last-month, since:2024-03...)"
+	englishMessages.InputTimeRange = "Enter time range"
+
+	// 中文 - 时间范围
+	chineseMessages.FlagFiscalStart = "财年开始的月份 (1-12)，用于 FY 表达式；FY2025 表示在 2025 年结束的财年"
+	chineseMessages.ErrorFiscalStart = "无效的 --fiscal-start %d，应为 1 到 12 的月份"
+	chineseMessages.ErrorInvalidTimeRange = "无效的时间范围: %v\n支持的表达式: %s"
+	chineseMessages.LabelRangeStart = "第一次提交"
+	chineseMessages.TimeRangeExpression = "其他时间范围 (2025-Q3、FY2025、last-month、since:2024-03...)"
+	chineseMessages.InputTimeRange = "输入时间范围"
+}
+
+// 工作报告相关消息
+func init() {
+	// 英文 - 工作报告
+	englishMessages.AnalysisWeekly = "Weekly Report - Work done this week, grouped by project"
+	englishMessages.AnalysisDaily = "Daily Report - Work done today, for the stand-up"
+	englishMessages.LabelAnalysisTypeWeekly = "Analysis type: Weekly Report"
+	englishMessages.LabelAnalysisTypeDaily = "Analysis type: Daily Report"
+	englishMessages.ReportTitleWeekly = "Weekly Work Report"
+	englishMessages.ReportTitleDaily = "Daily Work Report"
+	englishMessages.InfoFoundInProgressBranches = "Found %d in-progress branches"
+
+	// 中文 - 工作报告
+	chineseMessages.AnalysisWeekly = "工作周报 - 按项目汇总本周的工作"
+	chineseMessages.AnalysisDaily = "工作日报 - 汇总今天的工作，用于站会"
+	chineseMessages.LabelAnalysisTypeWeekly = "分析类型: 工作周报"
+	chineseMessages.LabelAnalysisTypeDaily = "分析类型: 工作日报"
+	chineseMessages.ReportTitleWeekly = "工作周报"
+	chineseMessages.ReportTitleDaily = "工作日报"
+	chineseMessages.InfoFoundInProgressBranches = "发现 %d 个进行中的分支"
+}
+
+// 绩效自评相关消息
+func init() {
+	// 英文 - 绩效自评
+	englishMessages.AnalysisReview = "Self-Review - Performance self-assessment against a competency rubric"
+	englishMessages.LabelAnalysisTypeReview = "Analysis type: Self-Review"
+	englishMessages.ReportTitleReview = "Performance Self-Review"
+	englishMessages.FlagRubric = "Competency rubric file for the review analysis (YAML or Markdown)"
+	englishMessages.ErrorRubricRequired = "the review analysis requires a competency rubric, use --rubric"
+	englishMessages.ErrorRubricLoad = "failed to load rubric: %v"
+	englishMessages.InputRubricPath = "Competency rubric file (YAML or Markdown)"
+	englishMessages.InfoRubricEvidence = "Matched evidence for %d competency areas, %d with weak evidence"
+
+	// 中文 - 绩效自评
+	chineseMessages.AnalysisReview = "绩效自评 - 根据能力模型撰写绩效自评"
+	chineseMessages.LabelAnalysisTypeReview = "分析类型: 绩效自评"
+	chineseMessages.ReportTitleReview = "绩效自评"
+	chineseMessages.FlagRubric = "绩效自评使用的能力模型文件（YAML 或 Markdown）"
+	chineseMessages.ErrorRubricRequired = "绩效自评需要能力模型文件，请使用 --rubric 指定"
+	chineseMessages.ErrorRubricLoad = "读取能力模型失败: %v"
+	chineseMessages.InputRubricPath = "能力模型文件（YAML 或 Markdown）"
+	chineseMessages.InfoRubricEvidence = "已为 %d 个能力项查找证据，其中 %d 个证据不足"
+}
+
+// 技能匹配相关消息
+func init() {
+	// 英文 - 技能匹配
+	englishMessages.CmdMatchShort = "Compare the profile with a career ladder level or job description"
+	englishMessages.FlagMatchLevel = "Only use the section of the target file whose heading contains this level"
+	englishMessages.LabelAnalysisTypeMatch = "Analysis type: Skill Match"
+	englishMessages.ReportTitleMatch = "Skill Match Analysis"
+	englishMessages.ErrorMatchTarget = "failed to load target: %v"
+	englishMessages.ErrorMatchTargetRequired = "the match analysis needs a target file, use the match command"
+	englishMessages.InfoSkillMatch = "Fit score %d/100: %d of %d required skills have strong evidence"
+	englishMessages.WarningNoSkillsFound = "Warning: no known skills found in the target, add a \"Skills:\" line to list them"
+
+	// 中文 - 技能匹配
+	chineseMessages.CmdMatchShort = "与职级要求或职位描述比较，分析技能匹配度"
+	chineseMessages.FlagMatchLevel = "只使用目标文件中标题包含该职级的章节"
+	chineseMessages.LabelAnalysisTypeMatch = "分析类型: 技能匹配"
+	chineseMessages.ReportTitleMatch = "技能匹配分析"
+	chineseMessages.ErrorMatchTarget = "读取目标描述失败: %v"
+	chineseMessages.ErrorMatchTargetRequired = "技能匹配需要目标描述文件，请使用 match 子命令"
+	chineseMessages.InfoSkillMatch = "匹配度 %d/100：所需的 %[3]d 项技能中 %[2]d 项证据充分"
+	chineseMessages.WarningNoSkillsFound = "警告: 未在目标描述中识别出已知的技能，可以添加 \"技能:\" 行列出所需的技能"
+}
+
+// 更新日志相关消息
+func init() {
+	// 英文 - 更新日志
+	englishMessages.CmdChangelogShort = "Generate release notes and a changelog between tags"
+	englishMessages.FlagRelease = "Version name for the changelog (default: the end tag, or Unreleased for HEAD)"
+	englishMessages.FlagChangelogOnly = "Only write the changelog, without AI release notes"
+	englishMessages.ErrorInvalidRefRange = "invalid tag range: %v"
+	englishMessages.ErrorNoCommitsInRefRange = "no commits found in %s"
+	englishMessages.ErrorReleaseAnalysis = "release notes are generated from a tag range, use the changelog command"
+	englishMessages.InfoChangelogCommits = "Found %d commits in %s"
+	englishMessages.WarningChangelogEmpty = "Warning: no user-facing changes found (only tests, docs, chores or builds)"
+	englishMessages.ReportTitleRelease = "Release Notes %s"
+
+	// 中文 - 更新日志
+	chineseMessages.CmdChangelogShort = "生成两个标签之间的发布说明和更新日志"
+	chineseMessages.FlagRelease = "更新日志中的版本名称（默认为范围终点的标签，终点为 HEAD 时为 Unreleased）"
+	chineseMessages.FlagChangelogOnly = "只输出更新日志，不使用AI撰写发布说明"
+	chineseMessages.ErrorInvalidRefRange = "无效的版本范围: %v"
+	chineseMessages.ErrorNoCommitsInRefRange = "%s 中没有提交"
+	chineseMessages.ErrorReleaseAnalysis = "发布说明按版本范围生成，请使用 changelog 子命令"
+	chineseMessages.InfoChangelogCommits = "在 %[2]s 中找到 %[1]d 个提交"
+	chineseMessages.WarningChangelogEmpty = "警告: 没有面向用户的变更（只有测试、文档、杂项或构建）"
+	chineseMessages.ReportTitleRelease = "%s 发布说明"
+}
+
+// 团队相关消息
+func init() {
+	// 英文 - 团队
+	englishMessages.CmdTeamShort = "Profile every contributor and summarize the team"
+	englishMessages.FlagMinCommits = "Minimum number of commits for a contributor to be included"
+	englishMessages.FlagTeamLocalOnly = "Only write local statistics for each member, without AI reports"
+	englishMessages.ErrorMinCommits = "--min-commits must be at least 1, got %d"
+	englishMessages.ErrorNoTeamMembers = "no contributors with at least %d commits found"
+	englishMessages.InfoTeamMembers = "Found %d contributors"
+	englishMessages.InfoTeamMember = "[%d/%d] Profiling %s"
+	englishMessages.InfoTeamIndexSaved = "Team index saved to: %s"
+	englishMessages.ReportTitleTeam = "Team Overview"
+	englishMessages.ReportTitleMemberStats = "Commit Statistics: %s"
+	englishMessages.ReportTeamMembers = "Members"
+	englishMessages.ReportTeamName = "Name"
+	englishMessages.ReportTeamCommits = "Commits"
+	englishMessages.ReportTeamDomain = "Primary Domain"
+	englishMessages.ReportTeamReports = "Reports"
+	englishMessages.ReportTeamCoverage = "Coverage"
+	englishMessages.ReportTeamArea = "Area"
+	englishMessages.ReportTeamContributors = "Contributors"
+	englishMessages.ReportTeamBusFactor = "Bus Factor"
+	englishMessages.ReportTeamCollaboration = "Collaboration"
+	englishMessages.ReportTeamPair = "Pair"
+	englishMessages.ReportTeamSharedFiles = "Shared Files"
+	englishMessages.ReportTeamCoAuthored = "Co-authored Commits"
+	englishMessages.ReportTeamNoCollaboration = "No contributors changed the same files."
+
+	// 中文 - 团队
+	chineseMessages.CmdTeamShort = "为每个贡献者生成画像并汇总团队情况"
+	chineseMessages.FlagMinCommits = "计入团队的贡献者最少的提交数"
+	chineseMessages.FlagTeamLocalOnly = "只为每个成员生成本地统计，不使用AI生成报告"
+	chineseMessages.ErrorMinCommits = "--min-commits 至少为 1，当前为 %d"
+	chineseMessages.ErrorNoTeamMembers = "没有提交数不少于 %d 的贡献者"
+	chineseMessages.InfoTeamMembers = "找到 %d 位贡献者"
+	chineseMessages.InfoTeamMember = "[%d/%d] 正在分析 %s"
+	chineseMessages.InfoTeamIndexSaved = "团队索引已保存到: %s"
+	chineseMessages.ReportTitleTeam = "团队概览"
+	chineseMessages.ReportTitleMemberStats = "%s 的提交统计"
+	chineseMessages.ReportTeamMembers = "成员"
+	chineseMessages.ReportTeamName = "姓名"
+	chineseMessages.ReportTeamCommits = "提交数"
+	chineseMessages.ReportTeamDomain = "主要领域"
+	chineseMessages.ReportTeamReports = "报告"
+	chineseMessages.ReportTeamCoverage = "代码区域覆盖"
+	chineseMessages.ReportTeamArea = "代码区域"
+	chineseMessages.ReportTeamContributors = "贡献者"
+	chineseMessages.ReportTeamBusFactor = "总线系数"
+	chineseMessages.ReportTeamCollaboration = "协作"
+	chineseMessages.ReportTeamPair = "成员"
+	chineseMessages.ReportTeamSharedFiles = "共同修改的文件"
+	chineseMessages.ReportTeamCoAuthored = "共同完成的提交"
+	chineseMessages.ReportTeamNoCollaboration = "没有成员修改过相同的文件。"
+}
+
+// 代码归属相关消息
+func init() {
+	// 英文 - 代码归属
+	englishMessages.CmdExpertsShort = "Rank who knows a file or directory best"
+	englishMessages.FlagHalfLife = "Days after which a commit counts half as much toward familiarity (0 to weight all commits equally)"
+	englishMessages.FlagExpertsLimit = "Maximum number of people to list (0 for all)"
+	englishMessages.ErrorNoCommitsForPath = "no commits touching %s in the selected time range"
+	englishMessages.InfoKnowledgeAreas = "Knowledge map: %d code areas"
+	englishMessages.ReportTitleExperts = "Experts: %s"
+	englishMessages.ReportKnowledgeMap = "Knowledge Map"
+	englishMessages.ReportKnowledgeArea = "Area"
+	englishMessages.ReportKnowledgeExperts = "Experts"
+	englishMessages.ReportBusFactor = "Bus Factor"
+	englishMessages.ReportCodeOwnersMismatch = "declared owners are not among the main contributors"
+	englishMessages.ReportExpertName = "Name"
+	englishMessages.ReportFamiliarity = "Familiarity"
+	englishMessages.ReportExpertLines = "Lines Changed"
+	englishMessages.ReportLastCommit = "Last Commit"
+
+	// 中文 - 代码归属
+	chineseMessages.CmdExpertsShort = "按熟悉程度列出最了解某个文件或目录的人"
+	chineseMessages.FlagHalfLife = "提交对熟悉程度的贡献减半所需的天数（0 表示所有提交权重相同）"
+	chineseMessages.FlagExpertsLimit = "最多列出的人数（0 表示全部）"
+	chineseMessages.ErrorNoCommitsForPath = "所选时间范围内没有修改 %s 的提交"
+	chineseMessages.InfoKnowledgeAreas = "知识地图: %d 个代码区域"
+	chineseMessages.ReportTitleExperts = "%s 的专家"
+	chineseMessages.ReportKnowledgeMap = "知识地图"
+	chineseMessages.ReportKnowledgeArea = "代码区域"
+	chineseMessages.ReportKnowledgeExperts = "专家"
+	chineseMessages.ReportBusFactor = "总线系数"
+	chineseMessages.ReportCodeOwnersMismatch = "声明的负责人不在主要贡献者之中"
+	chineseMessages.ReportExpertName = "姓名"
+	chineseMessages.ReportFamiliarity = "熟悉程度"
+	chineseMessages.ReportExpertLines = "变更行数"
+	chineseMessages.ReportLastCommit = "最近提交"
+}
+
+// 代码存活相关消息
+func init() {
+	// 英文 - 代码存活
+	englishMessages.FlagBlameSample = "Maximum number of files per repository to run git blame on for code survival, picked by lines added (0 for all, -1 to skip)"
+	englishMessages.FlagChurnDays = "Lines rewritten or deleted within this many days of being added count as churn (0 to skip)"
+	englishMessages.InfoAnalyzingSurvival = "Measuring code survival with git blame..."
+	englishMessages.InfoSurvival = "Code survival: %.1f%% of lines added still exist (%d files sampled)"
+	englishMessages.WarningSurvivalFailed = "Warning: code survival analysis failed: %v"
+	englishMessages.ReportCodeSurvival = "Code Survival"
+	englishMessages.ReportSurvivalRate = "Survival Rate"
+	englishMessages.ReportChurnRate = "Churn Rate"
+	englishMessages.ReportChurnWindow = "rewritten within %d days"
+	englishMessages.ReportSampledFiles = "Files Sampled"
+	englishMessages.ReportLinesAdded = "Lines Added"
+	englishMessages.ReportSurvivingLines = "Surviving"
+
+	// 中文 - 代码存活
+	chineseMessages.FlagBlameSample = "代码存活分析中每个仓库最多执行 git blame 的文件数，按新增行数选取（0 表示全部，-1 表示跳过）"
+	chineseMessages.FlagChurnDays = "新增后在多少天内被改写或删除的代码行计为返工（0 表示不统计）"
+	chineseMessages.InfoAnalyzingSurvival = "正在使用 git blame 统计代码存活情况..."
+	chineseMessages.InfoSurvival = "代码存活: 新增的代码行中 %.1f%% 仍然存在（抽样 %d 个文件）"
+	chineseMessages.WarningSurvivalFailed = "警告: 代码存活分析失败: %v"
+	chineseMessages.ReportCodeSurvival = "代码存活"
+	chineseMessages.ReportSurvivalRate = "存活率"
+	chineseMessages.ReportChurnRate = "返工率"
+	chineseMessages.ReportChurnWindow = "%d 天内被改写"
+	chineseMessages.ReportSampledFiles = "抽样文件"
+	chineseMessages.ReportLinesAdded = "新增行数"
+	chineseMessages.ReportSurvivingLines = "存活行数"
 }
@@ -5,9 +5,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/MyceliumGrid/git-work-profile/internal/ai"
+	"github.com/MyceliumGrid/git-work-profile/internal/daterange"
 	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/MyceliumGrid/git-work-profile/internal/rubric"
 	"github.com/manifoldco/promptui"
 )
 
@@ -22,10 +26,11 @@ type Config struct {
 	OutputFormat   string
 	OutputFile     string
 	AuthorName     string
+	RubricPath     string // 能力模型文件，仅用于绩效自评
 }
 
-// RunInteractive 运行交互式配置
-func RunInteractive() (*Config, error) {
+// RunInteractive 运行交互式配置，defaults 中的值作为各步骤的默认选项，空值使用内置默认值
+func RunInteractive(defaults Config) (*Config, error) {
 	config := &Config{}
 
 	// 先选择语言
@@ -41,14 +46,19 @@ func RunInteractive() (*Config, error) {
 	fmt.Println()
 
 	// 1. 选择分析类型
+	analysisTypes := []string{"profile", "experience", "techstack", "weekly", "daily", "review"}
 	analysisPrompt := promptui.Select{
 		Label: msg.SelectAnalysisType,
 		Items: []string{
 			msg.AnalysisProfile,
 			msg.AnalysisExperience,
 			msg.AnalysisTechStack,
+			msg.AnalysisWeekly,
+			msg.AnalysisDaily,
+			msg.AnalysisReview,
 		},
-		Size: 3,
+		Size:      6,
+		CursorPos: indexOf(analysisTypes, defaults.AnalysisType, 0),
 	}
 
 	idx, _, err := analysisPrompt.Run()
@@ -56,10 +66,34 @@ func RunInteractive() (*Config, error) {
 		return nil, err
 	}
 
-	analysisTypes := []string{"profile", "experience", "techstack"}
 	config.AnalysisType = analysisTypes[idx]
 
-	// 2. 选择时间范围
+	// 绩效自评需要能力模型文件
+	if config.AnalysisType == "review" {
+		rubricPrompt := promptui.Prompt{
+			Label:    msg.InputRubricPath,
+			Default:  defaults.RubricPath,
+			Validate: validateRubric,
+		}
+		config.RubricPath, err = rubricPrompt.Run()
+		if err != nil {
+			return nil, err
+		}
+		config.RubricPath = strings.TrimSpace(config.RubricPath)
+	}
+
+	// 2. 选择时间范围，周报和日报默认为本周和今天
+	if suggested, ok := ai.DefaultTimeRanges[ai.PromptType(config.AnalysisType)]; ok && (defaults.TimeRange == "" || defaults.TimeRange == "6m") {
+		defaults.TimeRange = suggested
+	}
+	timeRanges := []string{"3m", "6m", "1y", "2y"}
+	timeRangeCursor := indexOf(timeRanges, defaults.TimeRange, 1) // 默认选中6个月
+	switch {
+	case defaults.TimeRange == "custom":
+		timeRangeCursor = 5
+	case defaults.TimeRange != "" && !slices.Contains(timeRanges, defaults.TimeRange):
+		timeRangeCursor = 4
+	}
 	timeRangePrompt := promptui.Select{
 		Label: msg.SelectTimeRange,
 		Items: []string{
@@ -67,10 +101,11 @@ func RunInteractive() (*Config, error) {
 			msg.TimeRange6Months,
 			msg.TimeRange1Year,
 			msg.TimeRange2Years,
+			msg.TimeRangeExpression,
 			msg.TimeRangeCustom,
 		},
-		Size:      5,
-		CursorPos: 1, // 默认选中6个月
+		Size:      6,
+		CursorPos: timeRangeCursor,
 	}
 
 	idx, _, err = timeRangePrompt.Run()
@@ -79,13 +114,27 @@ func RunInteractive() (*Config, error) {
 	}
 
 	// 处理时间范围选择
-	if idx == 4 {
+	switch idx {
+	case 4:
+		// 时间范围表达式，如 2025-Q3、last-month
+		rangePrompt := promptui.Prompt{
+			Label:    msg.InputTimeRange,
+			Default:  defaultValue(timeRangeCursor == 4, defaults.TimeRange, ""),
+			Validate: daterange.Validate,
+		}
+		config.TimeRange, err = rangePrompt.Run()
+		if err != nil {
+			return nil, err
+		}
+		config.TimeRange = strings.TrimSpace(config.TimeRange)
+	case 5:
 		// 自定义日期范围
 		config.TimeRange = "custom"
 
 		// 输入开始日期
 		fromDatePrompt := promptui.Prompt{
 			Label:    msg.InputFromDate,
+			Default:  defaults.CustomFromDate,
 			Validate: validateDate,
 		}
 		config.CustomFromDate, err = fromDatePrompt.Run()
@@ -96,14 +145,14 @@ func RunInteractive() (*Config, error) {
 		// 输入结束日期
 		toDatePrompt := promptui.Prompt{
 			Label:    msg.InputToDate,
+			Default:  defaults.CustomToDate,
 			Validate: validateDate,
 		}
 		config.CustomToDate, err = toDatePrompt.Run()
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		timeRanges := []string{"3m", "6m", "1y", "2y"}
+	default:
 		config.TimeRange = timeRanges[idx]
 	}
 
@@ -115,7 +164,8 @@ func RunInteractive() (*Config, error) {
 			msg.RepoModeSingle,
 			msg.RepoModeMultiple,
 		},
-		Size: 3,
+		Size:      3,
+		CursorPos: indexOf([]string{"current", "single", "multiple"}, defaults.RepoMode, 0),
 	}
 
 	idx, _, err = repoModePrompt.Run()
@@ -138,7 +188,7 @@ func RunInteractive() (*Config, error) {
 		config.RepoMode = "single"
 		repoPathPrompt := promptui.Prompt{
 			Label:    msg.InputRepoPath,
-			Default:  ".",
+			Default:  defaultValue(defaults.RepoMode == "single", defaults.RepoPath, "."),
 			Validate: validateGitRepo,
 		}
 		config.RepoPath, err = repoPathPrompt.Run()
@@ -150,7 +200,7 @@ func RunInteractive() (*Config, error) {
 		config.RepoMode = "multiple"
 		reposPathPrompt := promptui.Prompt{
 			Label:    msg.InputReposPath,
-			Default:  getDefaultProjectsPath(),
+			Default:  defaultValue(defaults.RepoMode == "multiple", defaults.RepoPath, getDefaultProjectsPath()),
 			Validate: validateDirectory,
 		}
 		config.RepoPath, err = reposPathPrompt.Run()
@@ -160,15 +210,20 @@ func RunInteractive() (*Config, error) {
 	}
 
 	// 4. 选择输出格式
+	formats := []string{"markdown", "json", "text", "html", "jsonresume", "latex", "docx"}
 	formatPrompt := promptui.Select{
 		Label: msg.SelectOutputFormat,
 		Items: []string{
 			msg.FormatMarkdown,
 			msg.FormatJSON,
 			msg.FormatText,
+			msg.FormatHTML,
+			msg.FormatJSONResume,
+			msg.FormatLaTeX,
+			msg.FormatDOCX,
 		},
-		Size:      3,
-		CursorPos: 0,
+		Size:      7,
+		CursorPos: indexOf(formats, defaults.OutputFormat, 0),
 	}
 
 	idx, _, err = formatPrompt.Run()
@@ -176,11 +231,13 @@ func RunInteractive() (*Config, error) {
 		return nil, err
 	}
 
-	formats := []string{"markdown", "json", "text"}
 	config.OutputFormat = formats[idx]
 
 	// 5. 输出文件
-	defaultFileName := generateDefaultOutputFile(config.AnalysisType, config.OutputFormat)
+	defaultFileName := defaults.OutputFile
+	if defaultFileName == "" {
+		defaultFileName = generateDefaultOutputFile(config.AnalysisType, config.OutputFormat)
+	}
 	outputPrompt := promptui.Prompt{
 		Label:   fmt.Sprintf("%s (%s)", msg.InputOutputFile, msg.OutputFileHint),
 		Default: defaultFileName, // 默认保存到文件
@@ -203,7 +260,10 @@ func RunInteractive() (*Config, error) {
 
 	// 6. 作者名称（可选）
 	// 获取当前Git用户名作为默认值
-	defaultAuthor := getCurrentGitUserName(config.RepoPath)
+	defaultAuthor := defaults.AuthorName
+	if defaultAuthor == "" {
+		defaultAuthor = getCurrentGitUserName(config.RepoPath)
+	}
 
 	authorPrompt := promptui.Prompt{
 		Label:   msg.InputAuthor,
@@ -266,6 +326,22 @@ func RunInteractive() (*Config, error) {
 	return config, nil
 }
 
+// indexOf 返回 value 在 items 中的位置，不存在时返回 fallback
+func indexOf(items []string, value string, fallback int) int {
+	if idx := slices.Index(items, value); idx >= 0 {
+		return idx
+	}
+	return fallback
+}
+
+// defaultValue 在 use 为真且 value 不为空时返回 value，否则返回 fallback
+func defaultValue(use bool, value, fallback string) string {
+	if use && value != "" {
+		return value
+	}
+	return fallback
+}
+
 // getDefaultProjectsPath 获取默认的项目目录路径
 func getDefaultProjectsPath() string {
 	home, err := os.UserHomeDir()
@@ -301,6 +377,12 @@ func generateDefaultOutputFile(analysisType, format string) string {
 		prefix = "project-experience"
 	case "techstack":
 		prefix = "techstack-analysis"
+	case "weekly":
+		prefix = "weekly-report"
+	case "daily":
+		prefix = "daily-report"
+	case "review":
+		prefix = "self-review"
 	default:
 		prefix = "analysis"
 	}
@@ -311,6 +393,14 @@ func generateDefaultOutputFile(analysisType, format string) string {
 		ext = "md"
 	case "json":
 		ext = "json"
+	case "html":
+		ext = "html"
+	case "jsonresume":
+		ext = "resume.json"
+	case "latex":
+		ext = "tex"
+	case "docx":
+		ext = "docx"
 	default:
 		ext = "txt"
 	}
@@ -434,25 +524,25 @@ func selectLanguage() error {
 	return nil
 }
 
-// validateDate 验证日期格式
+// validateDate 验证日期，也可以是 2024-03、2025-Q3 等时间段
 func validateDate(input string) error {
 	msg := i18n.T()
 	input = strings.TrimSpace(input)
 	if input == "" {
 		return fmt.Errorf("%s", msg.ErrorDateEmpty)
 	}
-
-	// 验证格式 YYYY-MM-DD
-	if len(input) != 10 {
-		return fmt.Errorf("%s", msg.ErrorDateFormatInvalid)
+	if _, err := daterange.Between("", input, daterange.Options{}); err != nil {
+		return fmt.Errorf("%s: %v", msg.ErrorDateFormatInvalid, err)
 	}
+	return nil
+}
 
-	// 简单验证
-	parts := strings.Split(input, "-")
-	if len(parts) != 3 {
-		return fmt.Errorf("%s", msg.ErrorDateFormatInvalid)
+// validateRubric 验证能力模型文件能否读取和解析
+func validateRubric(path string) error {
+	msg := i18n.T()
+	if _, err := rubric.Load(strings.TrimSpace(path)); err != nil {
+		return fmt.Errorf(msg.ErrorRubricLoad, err)
 	}
-
 	return nil
 }
 
@@ -0,0 +1,82 @@
+package ownership
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CodeOwners CODEOWNERS 文件中的规则，按文件中的顺序排列
+type CodeOwners []Rule
+
+// Rule CODEOWNERS 中的一条规则
+type Rule struct {
+	Pattern string
+	Owners  []string
+	re      *regexp.Regexp
+}
+
+// ParseCodeOwners 解析 CODEOWNERS 文件，忽略注释、空行和 GitLab 的章节标题
+func ParseCodeOwners(text string) CodeOwners {
+	var rules CodeOwners
+	for _, line := range strings.Split(text, "\n") {
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "[") || strings.HasPrefix(fields[0], "^[") {
+			continue
+		}
+		rules = append(rules, Rule{Pattern: fields[0], Owners: fields[1:], re: compilePattern(fields[0])})
+	}
+	return rules
+}
+
+// Owners 返回文件的负责人，与 GitHub 相同，最后一条匹配的规则生效
+func (c CodeOwners) Owners(file string) []string {
+	for i := len(c) - 1; i >= 0; i-- {
+		if c[i].re.MatchString(file) {
+			return c[i].Owners
+		}
+	}
+	return nil
+}
+
+// compilePattern 将 gitignore 风格的模式转换为正则表达式：以 / 开头或包含 / 的模式从仓库根目录匹配，
+// 否则匹配任意层级的文件或目录名；匹配目录时包括其中的所有文件
+func compilePattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasPrefix(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	if strings.Contains(pattern, "/") {
+		anchored = true
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	if dirOnly {
+		b.WriteString("/.*$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.MustCompile(b.String())
+}
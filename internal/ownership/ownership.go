@@ -0,0 +1,398 @@
+// Package ownership 按目录统计各作者的提交和代码行占比，按时间衰减加权得到熟悉程度，
+// 估算总线系数，并与 CODEOWNERS 中声明的负责人比较
+package ownership
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+const (
+	// DefaultDepth 知识地图默认的目录层级，如 internal/git
+	DefaultDepth = 2
+	// DefaultHalfLife 默认的权重半衰期（天），超过半衰期的提交权重减半
+	DefaultHalfLife = 180
+)
+
+// Options 代码归属的计算选项
+type Options struct {
+	Depth      int                   // 目录层级，小于 1 时使用 DefaultDepth
+	HalfLife   int                   // 权重半衰期（天），小于 1 表示不按时间衰减
+	Now        time.Time             // 计算权重的时间点，通常为时间范围的结束时间
+	CodeOwners map[string]CodeOwners // 仓库路径到 CODEOWNERS 规则的映射，可为空
+}
+
+// Owner 一个作者在代码区域中的贡献
+type Owner struct {
+	Name        string
+	Emails      []string
+	Commits     int
+	Lines       int     // 新增和删除的行数
+	CommitShare float64 // 提交数占比，未加权
+	LineShare   float64 // 代码行占比，未加权
+	Score       float64 // 按时间衰减加权的提交和代码行占比的平均值，所有作者合计为 1
+	First, Last time.Time
+}
+
+// Area 一个代码区域（目录）的归属情况
+type Area struct {
+	Path      string // 目录，仓库根目录下的文件为 /，有多个仓库时加上仓库名，如 app:internal/git
+	Commits   int
+	Lines     int
+	Owners    []Owner  // 按熟悉程度从高到低排列
+	BusFactor int      // 熟悉程度合计超过一半所需的最少作者数
+	Declared  []string // CODEOWNERS 中声明的负责人
+	Mismatch  bool     // 声明的负责人不在主要贡献者之中
+}
+
+// Map 按目录计算代码归属，结果按提交数从多到少排列
+func Map(commits []git.CommitInfo, opts Options) []Area {
+	depth := opts.Depth
+	if depth < 1 {
+		depth = DefaultDepth
+	}
+	multiRepo := repoCount(commits) > 1
+
+	tallies := make(map[string]*tally)
+	for _, commit := range commits {
+		seen := make(map[string]bool)
+		for _, stat := range fileStats(commit) {
+			key := areaPath(commit.RepoPath, stat.Path, depth, multiRepo)
+			t := tallies[key]
+			if t == nil {
+				t = newTally()
+				tallies[key] = t
+			}
+			t.addLines(commit, stat, opts)
+			if !seen[key] {
+				seen[key] = true
+				t.addCommit(commit, opts)
+			}
+		}
+	}
+
+	areas := make([]Area, 0, len(tallies))
+	for key, t := range tallies {
+		areas = append(areas, t.area(key))
+	}
+	sort.Slice(areas, func(i, j int) bool {
+		if areas[i].Commits != areas[j].Commits {
+			return areas[i].Commits > areas[j].Commits
+		}
+		return areas[i].Path < areas[j].Path
+	})
+	return areas
+}
+
+// Experts 计算路径（相对于仓库根目录的文件或目录，为空或 . 表示整个仓库）的代码归属，
+// 多个仓库中的同名路径合并计算
+func Experts(commits []git.CommitInfo, path string, opts Options) Area {
+	path = strings.Trim(filepath.ToSlash(filepath.Clean(path)), "/")
+	if path == "." {
+		path = ""
+	}
+
+	t := newTally()
+	for _, commit := range commits {
+		touched := false
+		for _, stat := range fileStats(commit) {
+			if path != "" && stat.Path != path && !strings.HasPrefix(stat.Path, path+"/") {
+				continue
+			}
+			t.addLines(commit, stat, opts)
+			touched = true
+		}
+		if touched {
+			t.addCommit(commit, opts)
+		}
+	}
+	if path == "" {
+		path = "/"
+	}
+	return t.area(path)
+}
+
+// Filter 返回作者参与过的代码区域
+func Filter(areas []Area, names ...string) []Area {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	var result []Area
+	for _, area := range areas {
+		for _, owner := range area.Owners {
+			if wanted[owner.Name] {
+				result = append(result, area)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// TopExperts 返回熟悉程度最高的 n 个作者，如 "Alice 62%, Bob 30%"
+func (a Area) TopExperts(n int) string {
+	owners := a.Owners
+	if len(owners) > n {
+		owners = owners[:n]
+	}
+	parts := make([]string, len(owners))
+	for i, owner := range owners {
+		parts[i] = fmt.Sprintf("%s %d%%", owner.Name, int(math.Round(owner.Score*100)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Rank 返回作者在代码区域中的排名，从 1 开始，未参与时返回 0
+func (a Area) Rank(name string) int {
+	for i, owner := range a.Owners {
+		if owner.Name == name {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// tally 累计一个代码区域中各作者的贡献
+type tally struct {
+	owners   map[string]*owner
+	order    []string
+	declared map[string]bool // CODEOWNERS 中声明的负责人
+}
+
+// owner 一个作者的累计贡献，weighted* 为按时间衰减加权的值
+type owner struct {
+	Owner
+	emails          map[string]bool
+	weightedCommits float64
+	weightedLines   float64
+}
+
+// newTally 创建空的累计结果
+func newTally() *tally {
+	return &tally{owners: make(map[string]*owner), declared: make(map[string]bool)}
+}
+
+// get 返回提交作者的累计贡献
+func (t *tally) get(commit git.CommitInfo) *owner {
+	o := t.owners[commit.Author]
+	if o == nil {
+		o = &owner{Owner: Owner{Name: commit.Author, First: commit.Date, Last: commit.Date}, emails: make(map[string]bool)}
+		t.owners[commit.Author] = o
+		t.order = append(t.order, commit.Author)
+	}
+	if commit.Email != "" {
+		o.emails[commit.Email] = true
+	}
+	if commit.Date.Before(o.First) {
+		o.First = commit.Date
+	}
+	if commit.Date.After(o.Last) {
+		o.Last = commit.Date
+	}
+	return o
+}
+
+// addCommit 记录一次提交，每个提交在一个代码区域中只计一次
+func (t *tally) addCommit(commit git.CommitInfo, opts Options) {
+	o := t.get(commit)
+	o.Commits++
+	o.weightedCommits += weight(commit.Date, opts)
+}
+
+// addLines 记录一个文件的代码行变更和 CODEOWNERS 中的负责人
+func (t *tally) addLines(commit git.CommitInfo, stat git.FileStat, opts Options) {
+	o := t.get(commit)
+	lines := stat.Added + stat.Deleted
+	o.Lines += lines
+	o.weightedLines += float64(lines) * weight(commit.Date, opts)
+
+	if rules := opts.CodeOwners[commit.RepoPath]; len(rules) > 0 {
+		for _, name := range rules.Owners(stat.Path) {
+			t.declared[name] = true
+		}
+	}
+}
+
+// area 计算占比、熟悉程度、总线系数和与 CODEOWNERS 的差异
+func (t *tally) area(path string) Area {
+	a := Area{Path: path}
+	var weightedCommits, weightedLines float64
+	for _, o := range t.owners {
+		a.Commits += o.Commits
+		a.Lines += o.Lines
+		weightedCommits += o.weightedCommits
+		weightedLines += o.weightedLines
+	}
+
+	for _, name := range t.order {
+		o := t.owners[name]
+		o.CommitShare = ratio(float64(o.Commits), float64(a.Commits))
+		o.LineShare = ratio(float64(o.Lines), float64(a.Lines))
+		// 没有代码行变更（如只有二进制文件）时只按提交计算
+		if weightedLines > 0 {
+			o.Score = (ratio(o.weightedCommits, weightedCommits) + ratio(o.weightedLines, weightedLines)) / 2
+		} else {
+			o.Score = ratio(o.weightedCommits, weightedCommits)
+		}
+		for email := range o.emails {
+			o.Emails = append(o.Emails, email)
+		}
+		sort.Strings(o.Emails)
+		a.Owners = append(a.Owners, o.Owner)
+	}
+	sort.SliceStable(a.Owners, func(i, j int) bool {
+		if a.Owners[i].Score != a.Owners[j].Score {
+			return a.Owners[i].Score > a.Owners[j].Score
+		}
+		return a.Owners[i].Name < a.Owners[j].Name
+	})
+
+	covered := 0.0
+	for _, o := range a.Owners {
+		covered += o.Score
+		a.BusFactor++
+		if covered > 0.5 {
+			break
+		}
+	}
+
+	for name := range t.declared {
+		a.Declared = append(a.Declared, name)
+	}
+	sort.Strings(a.Declared)
+	a.Mismatch = mismatch(a.Declared, a.Owners[:a.BusFactor])
+	return a
+}
+
+// mismatch 判断声明的负责人是否都不在主要贡献者之中。
+// 声明了团队（如 @org/team）时无法确定成员，不视为不一致
+func mismatch(declared []string, experts []Owner) bool {
+	if len(declared) == 0 {
+		return false
+	}
+	for _, entry := range declared {
+		if strings.HasPrefix(entry, "@") && strings.Contains(entry, "/") {
+			return false
+		}
+		for _, expert := range experts {
+			if Matches(entry, expert) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Matches 判断 CODEOWNERS 中的一项（@用户名或邮箱）是否指向该作者。
+// 用户名与作者名、邮箱用户名或 GitHub noreply 邮箱中的用户名比较，忽略大小写、空格和标点
+func Matches(entry string, o Owner) bool {
+	entry = strings.TrimSpace(entry)
+	if !strings.HasPrefix(entry, "@") && strings.Contains(entry, "@") {
+		for _, email := range o.Emails {
+			if strings.EqualFold(email, entry) {
+				return true
+			}
+		}
+		return false
+	}
+
+	handle := normalize(strings.TrimPrefix(entry, "@"))
+	if handle == "" {
+		return false
+	}
+	if normalize(o.Name) == handle {
+		return true
+	}
+	for _, email := range o.Emails {
+		local, _, _ := strings.Cut(email, "@")
+		// GitHub noreply 邮箱：12345+handle@users.noreply.github.com
+		if _, name, ok := strings.Cut(local, "+"); ok {
+			local = name
+		}
+		if normalize(local) == handle {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize 转为小写，只保留字母和数字
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127 {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// weight 按半衰期计算提交的权重，Now 之后的提交权重为 1
+func weight(date time.Time, opts Options) float64 {
+	if opts.HalfLife < 1 || opts.Now.IsZero() || !date.Before(opts.Now) {
+		return 1
+	}
+	days := opts.Now.Sub(date).Hours() / 24
+	return math.Pow(0.5, days/float64(opts.HalfLife))
+}
+
+// fileStats 返回提交的文件统计，没有时按变更文件生成行数为 0 的统计
+func fileStats(commit git.CommitInfo) []git.FileStat {
+	if len(commit.FileStats) > 0 {
+		return commit.FileStats
+	}
+	stats := make([]git.FileStat, len(commit.ChangedFiles))
+	for i, file := range commit.ChangedFiles {
+		stats[i] = git.FileStat{Path: file}
+	}
+	return stats
+}
+
+// areaPath 返回文件所在的目录，最多保留 depth 层
+func areaPath(repoPath, file string, depth int, multiRepo bool) string {
+	dirs := strings.Split(file, "/")
+	dirs = dirs[:len(dirs)-1]
+	if len(dirs) > depth {
+		dirs = dirs[:depth]
+	}
+	path := "/"
+	if len(dirs) > 0 {
+		path = strings.Join(dirs, "/")
+	}
+	if multiRepo {
+		return repoName(repoPath) + ":" + path
+	}
+	return path
+}
+
+// repoCount 返回提交来自的仓库数
+func repoCount(commits []git.CommitInfo) int {
+	repos := make(map[string]bool)
+	for _, commit := range commits {
+		repos[commit.RepoPath] = true
+	}
+	return len(repos)
+}
+
+// repoName 返回仓库目录名
+func repoName(repoPath string) string {
+	if abs, err := filepath.Abs(repoPath); err == nil {
+		repoPath = abs
+	}
+	return filepath.Base(repoPath)
+}
+
+// ratio 返回 a/b，b 为 0 时返回 0
+func ratio(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
@@ -0,0 +1,119 @@
+package ownership
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// testCommits Alice 早期大量修改 api，Bob 近期少量修改 api 并负责 web
+func testCommits(now time.Time) []git.CommitInfo {
+	old := now.AddDate(0, 0, -360)
+	return []git.CommitInfo{
+		{Author: "Alice", Email: "alice@example.com", Date: old, FileStats: []git.FileStat{{Path: "api/v1/handler.go", Added: 100}}},
+		{Author: "Alice", Email: "alice@example.com", Date: old, FileStats: []git.FileStat{{Path: "api/v1/store.go", Added: 100}}},
+		{Author: "Bob", Email: "1234+bobby@users.noreply.github.com", Date: now, FileStats: []git.FileStat{{Path: "api/v1/handler.go", Added: 50}, {Path: "web/app.ts", Added: 10}}},
+		{Author: "Bob", Date: now, FileStats: []git.FileStat{{Path: "README.md", Added: 1}}},
+	}
+}
+
+// TestMap 测试目录层级、占比、时间衰减和总线系数
+func TestMap(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	areas := Map(testCommits(now), Options{HalfLife: 180, Now: now})
+
+	var paths []string
+	for _, area := range areas {
+		paths = append(paths, area.Path)
+	}
+	if want := []string{"api/v1", "/", "web"}; !reflect.DeepEqual(paths, want) {
+		t.Fatalf("代码区域为 %v，应为 %v", paths, want)
+	}
+
+	api := areas[0]
+	if api.Commits != 3 || api.Lines != 250 {
+		t.Errorf("api/v1 的提交数或代码行不正确: %+v", api)
+	}
+	// Alice 的提交数和代码行更多，但已过去两个半衰期，Bob 的熟悉程度更高
+	if api.Owners[0].Name != "Bob" || api.Owners[1].CommitShare < 0.66 {
+		t.Errorf("应按时间衰减排序: %+v", api.Owners)
+	}
+	if api.BusFactor != 1 {
+		t.Errorf("总线系数应为 1: %d", api.BusFactor)
+	}
+
+	undecayed := Map(testCommits(now), Options{})
+	if undecayed[0].Owners[0].Name != "Alice" {
+		t.Errorf("不衰减时 Alice 应排在第一: %+v", undecayed[0].Owners)
+	}
+}
+
+// TestExperts 测试按路径排名和 CODEOWNERS 差异
+func TestExperts(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	rules := ParseCodeOwners("# owners\n* @alice\n/web/ @bobby\n")
+	opts := Options{HalfLife: 180, Now: now, CodeOwners: map[string]CodeOwners{"": rules}}
+
+	api := Experts(testCommits(now), "./api/", opts)
+	if api.Path != "api" || len(api.Owners) != 2 || api.Commits != 3 {
+		t.Errorf("api 的专家不正确: %+v", api)
+	}
+	if !reflect.DeepEqual(api.Declared, []string{"@alice"}) || !api.Mismatch {
+		t.Errorf("声明的负责人 @alice 不是主要贡献者，应视为不一致: %+v", api)
+	}
+
+	web := Experts(testCommits(now), "web", opts)
+	if !reflect.DeepEqual(web.Declared, []string{"@bobby"}) || web.Mismatch {
+		t.Errorf("@bobby 对应 Bob 的 noreply 邮箱，不应视为不一致: %+v", web)
+	}
+	if got := web.TopExperts(3); got != "Bob 100%" {
+		t.Errorf("专家列表为 %q", got)
+	}
+	if none := Experts(testCommits(now), "docs", opts); none.Commits != 0 || len(none.Owners) != 0 {
+		t.Errorf("没有提交的路径应为空: %+v", none)
+	}
+}
+
+// TestCodeOwners 测试 CODEOWNERS 模式匹配，最后一条匹配的规则生效
+func TestCodeOwners(t *testing.T) {
+	rules := ParseCodeOwners(`
+[Backend]
+*.go @go-team
+/docs/ docs@example.com
+internal/**/testdata @qa # fixtures
+apps/*/config.yml @ops
+`)
+	cases := map[string][]string{
+		"main.go":                       {"@go-team"},
+		"internal/git/git.go":           {"@go-team"},
+		"docs/guide.md":                 {"docs@example.com"},
+		"sub/docs/guide.md":             nil,
+		"internal/git/testdata/log.txt": {"@qa"},
+		"apps/web/config.yml":           {"@ops"},
+		"apps/web/nested/config.yml":    nil,
+		"README.md":                     nil,
+	}
+	for file, want := range cases {
+		if got := rules.Owners(file); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s 的负责人为 %v，应为 %v", file, got, want)
+		}
+	}
+}
+
+// TestMatches 测试 CODEOWNERS 中的用户名和邮箱与作者的对应
+func TestMatches(t *testing.T) {
+	owner := Owner{Name: "Jane Doe", Emails: []string{"jane.doe@corp.com"}}
+	for entry, want := range map[string]bool{
+		"@janedoe":          true,
+		"@Jane-Doe":         true,
+		"jane.doe@corp.com": true,
+		"@jdoe":             false,
+		"other@corp.com":    false,
+	} {
+		if got := Matches(entry, owner); got != want {
+			t.Errorf("%s 的匹配结果为 %v，应为 %v", entry, got, want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package profile
 import (
 	"time"
 
+	"github.com/MyceliumGrid/git-work-profile/internal/classify"
 	"github.com/MyceliumGrid/git-work-profile/internal/git"
 )
 
@@ -35,6 +36,12 @@ type Statistics struct {
 	RepoStats      map[string]int `json:"repo_stats"`
 	CommitsByMonth map[string]int `json:"commits_by_month"`
 	CommitsByHour  map[int]int    `json:"commits_by_hour"`
+
+	CommitIntents       map[string]int `json:"commit_intents"`       // 按提交意图统计
+	ConventionalCommits int            `json:"conventional_commits"` // 符合约定式提交规范的提交数
+	BreakingChanges     int            `json:"breaking_changes"`     // 破坏性变更数
+
+	Survival *Survival `json:"survival,omitempty"` // 代码存活和返工统计，需要执行 git blame，由 AnalyzeSurvival 单独计算
 }
 
 // TechStack 技术栈
@@ -87,6 +94,7 @@ func calculateStatistics(commits []git.CommitInfo) Statistics {
 		RepoStats:      make(map[string]int),
 		CommitsByMonth: make(map[string]int),
 		CommitsByHour:  make(map[int]int),
+		CommitIntents:  make(map[string]int),
 	}
 
 	repoSet := make(map[string]bool)
@@ -99,6 +107,10 @@ func calculateStatistics(commits []git.CommitInfo) Statistics {
 			repoSet[commit.RepoPath] = true
 		}
 
+		// 代码行数统计
+		stats.LinesAdded += commit.LinesAdded
+		stats.LinesDeleted += commit.LinesDeleted
+
 		// 文件类型统计
 		for _, file := range commit.ChangedFiles {
 			filesSet[file] = true
@@ -119,9 +131,48 @@ func calculateStatistics(commits []git.CommitInfo) Statistics {
 	stats.TotalRepos = len(repoSet)
 	stats.FilesChanged = len(filesSet)
 
+	// 提交意图统计
+	breakdown := classify.Summarize(commits)
+	for intent, count := range breakdown.Counts {
+		stats.CommitIntents[string(intent)] = count
+	}
+	stats.ConventionalCommits = breakdown.Conventional
+	stats.BreakingChanges = breakdown.Breaking
+
 	return stats
 }
 
+// languageByExtension 文件扩展名对应的编程语言
+var languageByExtension = map[string]string{
+	".go":    "Go",
+	".js":    "JavaScript",
+	".ts":    "TypeScript",
+	".py":    "Python",
+	".java":  "Java",
+	".rb":    "Ruby",
+	".php":   "PHP",
+	".c":     "C",
+	".cpp":   "C++",
+	".cs":    "C#",
+	".swift": "Swift",
+	".kt":    "Kotlin",
+	".rs":    "Rust",
+	".scala": "Scala",
+	".sh":    "Shell",
+	".sql":   "SQL",
+	".html":  "HTML",
+	".css":   "CSS",
+	".vue":   "Vue",
+	".jsx":   "React",
+	".tsx":   "React",
+}
+
+// LanguageOf 根据文件扩展名判断编程语言
+func LanguageOf(filename string) (string, bool) {
+	lang, ok := languageByExtension[getFileExtension(filename)]
+	return lang, ok
+}
+
 // analyzeTechStack 分析技术栈
 func analyzeTechStack(commits []git.CommitInfo) TechStack {
 	techStack := TechStack{
@@ -131,35 +182,9 @@ func analyzeTechStack(commits []git.CommitInfo) TechStack {
 		Platforms:  []string{},
 	}
 
-	// 语言映射
-	langMap := map[string]string{
-		".go":    "Go",
-		".js":    "JavaScript",
-		".ts":    "TypeScript",
-		".py":    "Python",
-		".java":  "Java",
-		".rb":    "Ruby",
-		".php":   "PHP",
-		".c":     "C",
-		".cpp":   "C++",
-		".cs":    "C#",
-		".swift": "Swift",
-		".kt":    "Kotlin",
-		".rs":    "Rust",
-		".scala": "Scala",
-		".sh":    "Shell",
-		".sql":   "SQL",
-		".html":  "HTML",
-		".css":   "CSS",
-		".vue":   "Vue",
-		".jsx":   "React",
-		".tsx":   "React",
-	}
-
 	for _, commit := range commits {
 		for _, file := range commit.ChangedFiles {
-			ext := getFileExtension(file)
-			if lang, ok := langMap[ext]; ok {
+			if lang, ok := LanguageOf(file); ok {
 				techStack.Languages[lang]++
 			}
 		}
@@ -0,0 +1,304 @@
+package profile
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// DefaultBlameSample 每个仓库默认最多执行 blame 的文件数
+const DefaultBlameSample = 100
+
+// DefaultChurnDays 默认的返工窗口（天）：新增后在这段时间内被改写或删除的代码行计为返工
+const DefaultChurnDays = 21
+
+// SurvivalOptions 代码存活分析的选项
+type SurvivalOptions struct {
+	Sample    int // 每个仓库最多分析的文件数，按开发者新增的行数从多到少选取，0 表示全部
+	ChurnDays int // 返工窗口（天），0 表示不统计返工
+}
+
+// Survival 开发者新增的代码行在时间范围结束时的存活情况，只统计抽样的文件
+type Survival struct {
+	ChurnDays    int                     `json:"churn_days"`
+	Files        int                     `json:"files"`         // 新增过代码行的文件数
+	SampledFiles int                     `json:"sampled_files"` // 实际执行 blame 的文件数
+	Total        LineSurvival            `json:"total"`
+	ByRepo       map[string]LineSurvival `json:"by_repo"`     // 按仓库路径
+	ByLanguage   map[string]LineSurvival `json:"by_language"` // 按编程语言，无法识别语言的文件不计入
+}
+
+// LineSurvival 一组代码行的存活和返工统计
+type LineSurvival struct {
+	Added     int `json:"added"`     // 新增的行数
+	Surviving int `json:"surviving"` // 时间范围结束时仍然存在的行数
+	Settled   int `json:"settled"`   // 新增时间早于结束时间一个返工窗口以上、可以判断是否返工的行数
+	Churned   int `json:"churned"`   // 其中在返工窗口内被改写或删除的行数
+}
+
+// SurvivalRate 返回存活行数占新增行数的比例
+func (s LineSurvival) SurvivalRate() float64 {
+	if s.Added == 0 {
+		return 0
+	}
+	return float64(s.Surviving) / float64(s.Added)
+}
+
+// ChurnRate 返回返工行数占可判断行数的比例
+func (s LineSurvival) ChurnRate() float64 {
+	if s.Settled == 0 {
+		return 0
+	}
+	return float64(s.Churned) / float64(s.Settled)
+}
+
+// add 累加另一组统计
+func (s *LineSurvival) add(other LineSurvival) {
+	s.Added += other.Added
+	s.Surviving += other.Surviving
+	s.Settled += other.Settled
+	s.Churned += other.Churned
+}
+
+// Ranked 返回有新增行的分组名称，按新增行数从多到少排列
+func Ranked(groups map[string]LineSurvival) []string {
+	keys := make([]string, 0, len(groups))
+	for key, stats := range groups {
+		if stats.Added > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if groups[keys[i]].Added != groups[keys[j]].Added {
+			return groups[keys[i]].Added > groups[keys[j]].Added
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// blamer 代码存活分析需要的 git 操作，测试时替换
+type blamer interface {
+	revisionAt(repo string, t time.Time) (string, error)
+	reachable(repo, rev string, since time.Time) (map[string]bool, error)
+	blame(repo, rev, path string) (map[string]int, error)
+}
+
+// gitBlamer 在仓库中执行 git 命令
+type gitBlamer struct{}
+
+func (gitBlamer) revisionAt(repo string, t time.Time) (string, error) {
+	return git.RevisionAt(t, &git.Options{RepoPath: repo})
+}
+
+func (gitBlamer) reachable(repo, rev string, since time.Time) (map[string]bool, error) {
+	return git.Reachable(rev, since, &git.Options{RepoPath: repo})
+}
+
+func (gitBlamer) blame(repo, rev, path string) (map[string]int, error) {
+	return git.BlameLines(rev, path, &git.Options{RepoPath: repo})
+}
+
+// AnalyzeSurvival 在时间范围结束时对开发者修改过的文件执行 git blame，统计新增的代码行有多少仍然存在；
+// 对新增时间早于结束时间一个返工窗口以上的行，再在窗口结束时执行 blame，统计窗口内被改写或删除的行。
+// 只统计结束时已合并到 HEAD 的提交
+func AnalyzeSurvival(commits []git.CommitInfo, to time.Time, opts SurvivalOptions) (*Survival, error) {
+	return analyzeSurvival(commits, to, opts, gitBlamer{})
+}
+
+// fileChange 一个提交在文件中新增的行
+type fileChange struct {
+	hash  string
+	date  time.Time
+	added int
+}
+
+// survivalRepo 分析单个仓库中的存活情况
+type survivalRepo struct {
+	repo      string
+	to        time.Time
+	since     time.Time
+	b         blamer
+	revisions map[int64]string           // 时间（Unix 秒）对应的修订
+	reachable map[string]map[string]bool // 修订中已合并的提交
+	blames    map[string]map[string]int  // 修订和文件对应的 blame 结果
+}
+
+func analyzeSurvival(commits []git.CommitInfo, to time.Time, opts SurvivalOptions, b blamer) (*Survival, error) {
+	result := &Survival{
+		ChurnDays:  opts.ChurnDays,
+		ByRepo:     make(map[string]LineSurvival),
+		ByLanguage: make(map[string]LineSurvival),
+	}
+
+	byRepo := make(map[string][]git.CommitInfo)
+	var repos []string
+	for _, commit := range commits {
+		if _, ok := byRepo[commit.RepoPath]; !ok {
+			repos = append(repos, commit.RepoPath)
+		}
+		byRepo[commit.RepoPath] = append(byRepo[commit.RepoPath], commit)
+	}
+	sort.Strings(repos)
+
+	for _, repo := range repos {
+		r := &survivalRepo{
+			repo:      repo,
+			to:        to,
+			b:         b,
+			revisions: make(map[int64]string),
+			reachable: make(map[string]map[string]bool),
+			blames:    make(map[string]map[string]int),
+		}
+		// 早一天开始，避免提交时间与作者时间不同时漏掉提交
+		r.since = to
+		for _, commit := range byRepo[repo] {
+			if commit.Date.Before(r.since) {
+				r.since = commit.Date
+			}
+		}
+		r.since = r.since.AddDate(0, 0, -1)
+
+		end, err := r.revision(to)
+		if err != nil {
+			return nil, err
+		}
+		if end == "" {
+			continue
+		}
+		merged, err := r.merged(end)
+		if err != nil {
+			return nil, err
+		}
+
+		files := make(map[string][]fileChange)
+		added := make(map[string]int)
+		for _, commit := range byRepo[repo] {
+			if !merged[commit.Hash] {
+				continue
+			}
+			for _, stat := range commit.FileStats {
+				if stat.Added > 0 {
+					files[stat.Path] = append(files[stat.Path], fileChange{hash: commit.Hash, date: commit.Date, added: stat.Added})
+					added[stat.Path] += stat.Added
+				}
+			}
+		}
+
+		paths := make([]string, 0, len(files))
+		for path := range files {
+			paths = append(paths, path)
+		}
+		sort.Slice(paths, func(i, j int) bool {
+			if added[paths[i]] != added[paths[j]] {
+				return added[paths[i]] > added[paths[j]]
+			}
+			return paths[i] < paths[j]
+		})
+		result.Files += len(paths)
+		if opts.Sample > 0 && len(paths) > opts.Sample {
+			paths = paths[:opts.Sample]
+		}
+		result.SampledFiles += len(paths)
+
+		repoTotal := result.ByRepo[repo]
+		for _, path := range paths {
+			lines, err := r.file(path, end, files[path], opts.ChurnDays)
+			if err != nil {
+				return nil, err
+			}
+			result.Total.add(lines)
+			repoTotal.add(lines)
+			if lang, ok := LanguageOf(path); ok {
+				langTotal := result.ByLanguage[lang]
+				langTotal.add(lines)
+				result.ByLanguage[lang] = langTotal
+			}
+		}
+		result.ByRepo[repo] = repoTotal
+	}
+	return result, nil
+}
+
+// file 统计文件中开发者新增的行在结束时和返工窗口结束时的存活情况
+func (r *survivalRepo) file(path, end string, changes []fileChange, churnDays int) (LineSurvival, error) {
+	var lines LineSurvival
+	final, err := r.blame(end, path)
+	if err != nil {
+		return lines, err
+	}
+
+	for _, change := range changes {
+		lines.Added += change.added
+		lines.Surviving += min(change.added, final[change.hash])
+
+		// 返工窗口在时间范围结束之后才结束的行还无法判断
+		cutoff := change.date.AddDate(0, 0, churnDays)
+		if churnDays <= 0 || cutoff.After(r.to) {
+			continue
+		}
+		rev, err := r.revision(cutoff)
+		if err != nil {
+			return lines, err
+		}
+		// 窗口结束时提交还没有合并，无法判断
+		merged, err := r.merged(rev)
+		if err != nil {
+			return lines, err
+		}
+		if !merged[change.hash] {
+			continue
+		}
+		counts, err := r.blame(rev, path)
+		if err != nil {
+			return lines, err
+		}
+		lines.Settled += change.added
+		lines.Churned += change.added - min(change.added, counts[change.hash])
+	}
+	return lines, nil
+}
+
+// revision 返回时间之前的最后一个修订，结果会被缓存
+func (r *survivalRepo) revision(t time.Time) (string, error) {
+	if rev, ok := r.revisions[t.Unix()]; ok {
+		return rev, nil
+	}
+	rev, err := r.b.revisionAt(r.repo, t)
+	if err != nil {
+		return "", err
+	}
+	r.revisions[t.Unix()] = rev
+	return rev, nil
+}
+
+// merged 返回修订中已合并的提交，结果会被缓存
+func (r *survivalRepo) merged(rev string) (map[string]bool, error) {
+	if rev == "" {
+		return nil, nil
+	}
+	if hashes, ok := r.reachable[rev]; ok {
+		return hashes, nil
+	}
+	hashes, err := r.b.reachable(r.repo, rev, r.since)
+	if err != nil {
+		return nil, err
+	}
+	r.reachable[rev] = hashes
+	return hashes, nil
+}
+
+// blame 返回文件在修订中按提交统计的行数，结果会被缓存
+func (r *survivalRepo) blame(rev, path string) (map[string]int, error) {
+	key := rev + ":" + path
+	if counts, ok := r.blames[key]; ok {
+		return counts, nil
+	}
+	counts, err := r.b.blame(r.repo, rev, path)
+	if err != nil {
+		return nil, err
+	}
+	r.blames[key] = counts
+	return counts, nil
+}
@@ -0,0 +1,88 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// fakeBlamer 按预设的修订历史和 blame 结果返回数据
+type fakeBlamer struct {
+	history []fakeRevision             // 按时间排列的修订
+	merged  map[string]map[string]bool // 修订中已合并的提交
+	blames  map[string]map[string]int  // 修订:文件 对应的行数
+}
+
+// fakeRevision 修订及其提交时间
+type fakeRevision struct {
+	rev  string
+	date time.Time
+}
+
+func (f fakeBlamer) revisionAt(_ string, t time.Time) (string, error) {
+	rev := ""
+	for _, r := range f.history {
+		if !r.date.After(t) {
+			rev = r.rev
+		}
+	}
+	return rev, nil
+}
+
+func (f fakeBlamer) reachable(_, rev string, _ time.Time) (map[string]bool, error) {
+	return f.merged[rev], nil
+}
+
+func (f fakeBlamer) blame(_, rev, path string) (map[string]int, error) {
+	return f.blames[rev+":"+path], nil
+}
+
+// TestAnalyzeSurvival 测试存活行、返工行、未合并的提交和抽样
+func TestAnalyzeSurvival(t *testing.T) {
+	day := func(month, d int) time.Time { return time.Date(2026, time.Month(month), d, 0, 0, 0, 0, time.UTC) }
+	commits := []git.CommitInfo{
+		{Hash: "c1", Date: day(1, 1), RepoPath: "/r", FileStats: []git.FileStat{{Path: "api.go", Added: 10}}},
+		{Hash: "c2", Date: day(2, 20), RepoPath: "/r", FileStats: []git.FileStat{{Path: "web.ts", Added: 4}}},
+		{Hash: "c3", Date: day(1, 5), RepoPath: "/r", FileStats: []git.FileStat{{Path: "api.go", Added: 5}}},
+	}
+	// r2 中其他人改写了 c1 的3行，之后又删除了1行；c3 一直没有合并
+	b := fakeBlamer{
+		history: []fakeRevision{{"r1", day(1, 1)}, {"r2", day(1, 10)}, {"r3", day(2, 20)}},
+		merged: map[string]map[string]bool{
+			"r2": {"c1": true, "x": true},
+			"r3": {"c1": true, "x": true, "c2": true},
+		},
+		blames: map[string]map[string]int{
+			"r2:api.go": {"c1": 7, "x": 3},
+			"r3:api.go": {"c1": 6, "x": 3},
+			"r3:web.ts": {"c2": 4},
+		},
+	}
+
+	survival, err := analyzeSurvival(commits, day(3, 1), SurvivalOptions{ChurnDays: 21}, b)
+	if err != nil {
+		t.Fatalf("分析失败: %v", err)
+	}
+	if want := (LineSurvival{Added: 14, Surviving: 10, Settled: 10, Churned: 3}); survival.Total != want {
+		t.Errorf("总计为 %+v，应为 %+v", survival.Total, want)
+	}
+	if got := survival.ByLanguage["Go"]; got.SurvivalRate() != 0.6 || got.ChurnRate() != 0.3 {
+		t.Errorf("Go 的存活率和返工率不正确: %+v", got)
+	}
+	if got := survival.ByLanguage["TypeScript"]; got.Settled != 0 {
+		t.Errorf("返工窗口未结束的行不应参与返工统计: %+v", got)
+	}
+	if survival.ByRepo["/r"] != survival.Total || survival.Files != 2 || survival.SampledFiles != 2 {
+		t.Errorf("仓库统计或文件数不正确: %+v", survival)
+	}
+
+	// 只抽样新增行数最多的文件
+	sampled, err := analyzeSurvival(commits, day(3, 1), SurvivalOptions{Sample: 1, ChurnDays: 21}, b)
+	if err != nil {
+		t.Fatalf("分析失败: %v", err)
+	}
+	if sampled.Files != 2 || sampled.SampledFiles != 1 || sampled.Total.Added != 10 {
+		t.Errorf("抽样结果不正确: %+v", sampled)
+	}
+}
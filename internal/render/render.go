@@ -0,0 +1,432 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+)
+
+// Theme SVG图表的配色
+type Theme struct {
+	Name       string
+	Background string
+	Text       string
+	Muted      string
+	Accent     string
+	Levels     [5]string // 贡献日历从无到多的颜色
+	Palette    []string  // 环形图各部分的颜色
+}
+
+// 内置主题
+var (
+	// Light 浅色主题
+	Light = Theme{
+		Name:       "light",
+		Background: "#ffffff",
+		Text:       "#1f2328",
+		Muted:      "#656d76",
+		Accent:     "#0969da",
+		Levels:     [5]string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"},
+		Palette:    []string{"#0969da", "#1a7f37", "#bf3989", "#9a6700", "#8250df", "#cf222e", "#6e7781"},
+	}
+	// Dark 深色主题
+	Dark = Theme{
+		Name:       "dark",
+		Background: "#0d1117",
+		Text:       "#e6edf3",
+		Muted:      "#8d96a0",
+		Accent:     "#4493f8",
+		Levels:     [5]string{"#161b22", "#0e4429", "#006d32", "#26a641", "#39d353"},
+		Palette:    []string{"#4493f8", "#3fb950", "#db61a2", "#d29922", "#a371f7", "#f85149", "#8d96a0"},
+	}
+)
+
+// ThemeByName 根据名称查找内置主题
+func ThemeByName(name string) (Theme, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case Light.Name:
+		return Light, nil
+	case Dark.Name:
+		return Dark, nil
+	default:
+		return Theme{}, fmt.Errorf("unknown theme %q, expected light or dark", name)
+	}
+}
+
+// 环形图中单独显示的语言数量，其余合并为 Other
+const maxDonutSlices = 6
+
+// Day 贡献日历中的一天
+type Day struct {
+	Date  string
+	Count int
+	Level int  // 颜色深浅，0-4
+	Empty bool // 是否在时间范围之外
+}
+
+// Calendar 按周生成贡献日历，每周从周一开始
+func Calendar(commits []git.CommitInfo, fromDate, toDate time.Time) [][]Day {
+	counts := make(map[string]int)
+	maxCount := 0
+	for _, commit := range commits {
+		day := commit.Date.Format("2006-01-02")
+		counts[day]++
+		if counts[day] > maxCount {
+			maxCount = counts[day]
+		}
+	}
+
+	first := time.Date(fromDate.Year(), fromDate.Month(), fromDate.Day(), 0, 0, 0, 0, time.UTC)
+	last := time.Date(toDate.Year(), toDate.Month(), toDate.Day(), 0, 0, 0, 0, time.UTC)
+	start := first.AddDate(0, 0, -((int(first.Weekday()) + 6) % 7))
+
+	var weeks [][]Day
+	for day := start; !day.After(last); day = day.AddDate(0, 0, 7) {
+		week := make([]Day, 7)
+		for i := range week {
+			current := day.AddDate(0, 0, i)
+			key := current.Format("2006-01-02")
+			week[i] = Day{
+				Date:  key,
+				Count: counts[key],
+				Level: level(counts[key], maxCount),
+				Empty: current.Before(first) || current.After(last),
+			}
+		}
+		weeks = append(weeks, week)
+	}
+	return weeks
+}
+
+// level 将提交数量映射为0-4的颜色等级
+func level(count, maxCount int) int {
+	if count == 0 || maxCount == 0 {
+		return 0
+	}
+	l := int(math.Ceil(float64(count) * 4 / float64(maxCount)))
+	return min(max(l, 1), 4)
+}
+
+// Heatmap 生成贡献日历的SVG
+func Heatmap(weeks [][]Day, theme Theme) string {
+	msg := i18n.T()
+	const (
+		cell   = 10
+		step   = 13
+		left   = 30
+		top    = 44
+		legend = 24
+	)
+	width := left + len(weeks)*step + 10
+	height := top + 7*step + legend
+
+	var b strings.Builder
+	openSVG(&b, width, height, theme)
+	fmt.Fprintf(&b, `<text x="10" y="18" font-size="14" font-weight="600" fill="%s">%s</text>`+"\n", theme.Text, escape(msg.ReportContributionCalendar))
+
+	// 周一、周三、周五的标签
+	weekdays := strings.Fields(msg.ReportWeekdayNames)
+	for _, i := range []int{0, 2, 4} {
+		if i < len(weekdays) {
+			fmt.Fprintf(&b, `<text x="4" y="%d" font-size="9" fill="%s">%s</text>`+"\n", top+i*step+cell-1, theme.Muted, escape(weekdays[i]))
+		}
+	}
+
+	for w, week := range weeks {
+		x := left + w*step
+		// 在包含每月1日的那一周上方显示月份
+		for _, day := range week {
+			if !day.Empty && (day.Date[8:] == "01" || (w == 0 && day.Date == firstDay(week))) {
+				fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="9" fill="%s">%s</text>`+"\n", x, top-6, theme.Muted, day.Date[5:7])
+				break
+			}
+		}
+		for d, day := range week {
+			if day.Empty {
+				continue
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s: %d</title></rect>`+"\n",
+				x, top+d*step, cell, cell, theme.Levels[day.Level], day.Date, day.Count)
+		}
+	}
+
+	// 图例
+	lx := width - 10 - 5*step - 40
+	ly := top + 7*step + 8
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="9" fill="%s" text-anchor="end">%s</text>`+"\n", lx-4, ly+cell-1, theme.Muted, escape(msg.ReportLess))
+	for i, color := range theme.Levels {
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"/>`+"\n", lx+i*step, ly, cell, cell, color)
+	}
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="9" fill="%s">%s</text>`+"\n", lx+5*step+2, ly+cell-1, theme.Muted, escape(msg.ReportMore))
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// firstDay 返回一周中第一个在时间范围内的日期
+func firstDay(week []Day) string {
+	for _, day := range week {
+		if !day.Empty {
+			return day.Date
+		}
+	}
+	return ""
+}
+
+// slice 环形图的一部分
+type slice struct {
+	label string
+	value int
+}
+
+// LanguageDonut 生成编程语言占比的环形图SVG
+func LanguageDonut(languages map[string]int, theme Theme) string {
+	msg := i18n.T()
+	slices, total := donutSlices(languages)
+
+	const (
+		width  = 360
+		height = 210
+		cx     = 100
+		cy     = 115
+		radius = 62
+		stroke = 26
+	)
+	var b strings.Builder
+	openSVG(&b, width, height, theme)
+	fmt.Fprintf(&b, `<text x="10" y="18" font-size="14" font-weight="600" fill="%s">%s</text>`+"\n", theme.Text, escape(msg.ReportLanguages))
+
+	circumference := 2 * math.Pi * radius
+	if total == 0 {
+		fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="%s" stroke-width="%d"/>`+"\n", cx, cy, radius, theme.Levels[0], stroke)
+	}
+
+	offset := 0.0
+	for i, s := range slices {
+		color := theme.Palette[i%len(theme.Palette)]
+		share := float64(s.value) / float64(total)
+		dash := share * circumference
+		fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="%s" stroke-width="%d" stroke-dasharray="%.2f %.2f" stroke-dashoffset="%.2f" transform="rotate(-90 %d %d)"><title>%s: %.1f%%</title></circle>`+"\n",
+			cx, cy, radius, color, stroke, dash, circumference-dash, -offset, cx, cy, escape(s.label), share*100)
+		offset += dash
+
+		y := 50 + i*22
+		fmt.Fprintf(&b, `<rect x="200" y="%d" width="10" height="10" rx="2" fill="%s"/>`+"\n", y, color)
+		fmt.Fprintf(&b, `<text x="216" y="%d" font-size="12" fill="%s">%s <tspan fill="%s">%.1f%%</tspan></text>`+"\n",
+			y+9, theme.Text, escape(s.label), theme.Muted, share*100)
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// donutSlices 按数量排序语言，超出部分合并为 Other
+func donutSlices(languages map[string]int) ([]slice, int) {
+	var slices []slice
+	total := 0
+	for label, value := range languages {
+		if value > 0 {
+			slices = append(slices, slice{label, value})
+			total += value
+		}
+	}
+	sort.Slice(slices, func(i, j int) bool {
+		if slices[i].value != slices[j].value {
+			return slices[i].value > slices[j].value
+		}
+		return slices[i].label < slices[j].label
+	})
+
+	if len(slices) > maxDonutSlices {
+		other := 0
+		for _, s := range slices[maxDonutSlices-1:] {
+			other += s.value
+		}
+		slices = append(slices[:maxDonutSlices-1], slice{"Other", other})
+	}
+	return slices, total
+}
+
+// CommitsPerMonth 生成每月提交数的柱状图SVG，时间范围内没有提交的月份也会显示
+func CommitsPerMonth(byMonth map[string]int, fromDate, toDate time.Time, theme Theme) string {
+	msg := i18n.T()
+	months := MonthRange(fromDate, toDate)
+
+	const (
+		left      = 34
+		top       = 36
+		chart     = 120
+		barWidth  = 14
+		barStep   = 20
+		labelArea = 30
+	)
+	width := left + len(months)*barStep + 16
+	height := top + chart + labelArea
+
+	maxValue := 0
+	for _, month := range months {
+		maxValue = max(maxValue, byMonth[month])
+	}
+
+	var b strings.Builder
+	openSVG(&b, width, height, theme)
+	fmt.Fprintf(&b, `<text x="10" y="18" font-size="14" font-weight="600" fill="%s">%s</text>`+"\n", theme.Text, escape(msg.ChartCommitsPerMonth))
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="1"/>`+"\n", left, top+chart, width-10, top+chart, theme.Muted)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="9" fill="%s" text-anchor="end">%d</text>`+"\n", left-4, top+8, theme.Muted, maxValue)
+
+	for i, month := range months {
+		x := left + i*barStep + (barStep-barWidth)/2
+		count := byMonth[month]
+		h := 0
+		if maxValue > 0 {
+			h = int(math.Round(float64(count) / float64(maxValue) * chart))
+		}
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s: %d</title></rect>`+"\n",
+			x, top+chart-h, barWidth, h, theme.Accent, month, count)
+
+		// 月份标签，第一个月和每年一月同时显示年份
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="9" fill="%s" text-anchor="middle">%s</text>`+"\n",
+			x+barWidth/2, top+chart+12, theme.Muted, month[5:])
+		if i == 0 || month[5:] == "01" {
+			fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="9" fill="%s" text-anchor="middle">%s</text>`+"\n",
+				x+barWidth/2, top+chart+24, theme.Muted, month[:4])
+		}
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// MonthRange 返回时间范围内的所有月份，格式为 2006-01
+func MonthRange(fromDate, toDate time.Time) []string {
+	var months []string
+	current := time.Date(fromDate.Year(), fromDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(toDate.Year(), toDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for !current.After(last) {
+		months = append(months, current.Format("2006-01"))
+		current = current.AddDate(0, 1, 0)
+	}
+	return months
+}
+
+// Badge 生成 shields.io 风格的徽章SVG
+func Badge(label, value, color string) string {
+	labelWidth := textWidth(label) + 12
+	valueWidth := textWidth(value) + 12
+	width := labelWidth + valueWidth
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+"\n", width, escape(label), escape(value))
+	fmt.Fprintf(&b, `<title>%s: %s</title>`+"\n", escape(label), escape(value))
+	b.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` + "\n")
+	fmt.Fprintf(&b, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`+"\n", width)
+	b.WriteString(`<g clip-path="url(#r)">` + "\n")
+	fmt.Fprintf(&b, `<rect width="%d" height="20" fill="#555"/>`+"\n", labelWidth)
+	fmt.Fprintf(&b, `<rect x="%d" width="%d" height="20" fill="%s"/>`+"\n", labelWidth, valueWidth, color)
+	fmt.Fprintf(&b, `<rect width="%d" height="20" fill="url(#s)"/>`+"\n", width)
+	b.WriteString("</g>\n")
+	b.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` + "\n")
+	for _, part := range []struct {
+		x    int
+		text string
+	}{{labelWidth / 2, label}, {labelWidth + valueWidth/2, value}} {
+		fmt.Fprintf(&b, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text>`+"\n", part.x, escape(part.text))
+		fmt.Fprintf(&b, `<text x="%d" y="14">%s</text>`+"\n", part.x, escape(part.text))
+	}
+	b.WriteString("</g>\n</svg>\n")
+	return b.String()
+}
+
+// textWidth 估算文本在11px Verdana字体下的宽度
+func textWidth(text string) int {
+	width := 0.0
+	for _, r := range text {
+		if utf8.RuneLen(r) > 1 {
+			width += 11
+		} else {
+			width += 7
+		}
+	}
+	return int(math.Ceil(width))
+}
+
+// openSVG 输出SVG根元素和背景
+func openSVG(b *strings.Builder, width, height int, theme Theme) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="-apple-system,Segoe UI,Helvetica,Arial,sans-serif">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(b, `<rect width="100%%" height="100%%" rx="6" fill="%s"/>`+"\n", theme.Background)
+}
+
+// escape 转义SVG文本中的特殊字符
+func escape(text string) string {
+	return html.EscapeString(text)
+}
+
+// File 渲染得到的一个SVG文件
+type File struct {
+	Name    string
+	Content string
+}
+
+// Render 根据提交记录生成所有图表和徽章，图表按主题各生成一份
+func Render(commits []git.CommitInfo, fromDate, toDate time.Time, themes []Theme) []File {
+	msg := i18n.T()
+	dev := profile.AnalyzeProfile(commits, fromDate, toDate, "")
+	weeks := Calendar(commits, fromDate, toDate)
+
+	var files []File
+	for _, theme := range themes {
+		files = append(files,
+			File{fmt.Sprintf("heatmap-%s.svg", theme.Name), Heatmap(weeks, theme)},
+			File{fmt.Sprintf("languages-%s.svg", theme.Name), LanguageDonut(dev.TechStack.Languages, theme)},
+			File{fmt.Sprintf("commits-per-month-%s.svg", theme.Name), CommitsPerMonth(dev.Statistics.CommitsByMonth, fromDate, toDate, theme)},
+		)
+	}
+
+	topLanguage := "n/a"
+	if slices, _ := donutSlices(dev.TechStack.Languages); len(slices) > 0 {
+		topLanguage = slices[0].label
+	}
+	// 时间范围覆盖结束日期所在的整年时统计当年的提交，否则只能统计时间范围内的提交，标签改为起始日期
+	wholeYear := !fromDate.After(time.Date(toDate.Year(), 1, 1, 0, 0, 0, 0, toDate.Location()))
+	commitsLabel := fmt.Sprintf(msg.BadgeCommitsSince, fromDate.Format("2006-01-02"))
+	if wholeYear {
+		commitsLabel = fmt.Sprintf(msg.BadgeCommitsInYear, toDate.Year())
+	}
+	commitCount := 0
+	for _, commit := range commits {
+		if wholeYear && commit.Date.Year() == toDate.Year() || !wholeYear && !commit.Date.Before(fromDate) {
+			commitCount++
+		}
+	}
+
+	files = append(files,
+		File{"badge-top-language.svg", Badge(msg.BadgeTopLanguage, topLanguage, Light.Palette[0])},
+		File{"badge-commits-this-year.svg", Badge(commitsLabel, fmt.Sprintf("%d", commitCount), Light.Palette[1])},
+		File{"badge-repos.svg", Badge(msg.BadgeRepos, fmt.Sprintf("%d", dev.Statistics.TotalRepos), Light.Palette[4])},
+	)
+	return files
+}
+
+// WriteFiles 将SVG文件写入目录，目录不存在时自动创建
+func WriteFiles(dir string, files []File) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(dir, file.Name), []byte(file.Content), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
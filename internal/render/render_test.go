@@ -0,0 +1,115 @@
+package render
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// TestCalendar 测试贡献日历按周对齐
+func TestCalendar(t *testing.T) {
+	from := time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC) // 周三
+	to := time.Date(2024, 6, 11, 0, 0, 0, 0, time.UTC)  // 下周二
+	commits := []git.CommitInfo{
+		{Date: time.Date(2024, 6, 5, 10, 0, 0, 0, time.UTC)},
+		{Date: time.Date(2024, 6, 5, 11, 0, 0, 0, time.UTC)},
+		{Date: time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)},
+	}
+
+	weeks := Calendar(commits, from, to)
+	if len(weeks) != 2 {
+		t.Fatalf("应生成2周, 得到: %d", len(weeks))
+	}
+	if !weeks[0][0].Empty || weeks[0][0].Date != "2024-06-03" {
+		t.Errorf("第一周应从周一开始且周一在范围之外, 得到: %+v", weeks[0][0])
+	}
+	if weeks[0][2].Count != 2 || weeks[0][2].Level != 4 {
+		t.Errorf("6月5日应有2个提交且为最深颜色, 得到: %+v", weeks[0][2])
+	}
+	if weeks[1][0].Count != 1 || weeks[1][0].Level != 2 {
+		t.Errorf("6月10日应有1个提交, 得到: %+v", weeks[1][0])
+	}
+	if !weeks[1][2].Empty {
+		t.Errorf("6月12日应在范围之外, 得到: %+v", weeks[1][2])
+	}
+}
+
+// TestDonutSlices 测试语言过多时合并为 Other
+func TestDonutSlices(t *testing.T) {
+	languages := map[string]int{"Go": 50, "TypeScript": 20, "Python": 10, "Shell": 5, "SQL": 4, "CSS": 3, "HTML": 2, "Rust": 0}
+	slices, total := donutSlices(languages)
+
+	if total != 94 {
+		t.Errorf("总数应为94, 得到: %d", total)
+	}
+	if len(slices) != maxDonutSlices {
+		t.Fatalf("应有 %d 项, 得到: %+v", maxDonutSlices, slices)
+	}
+	if slices[0].label != "Go" || slices[len(slices)-1] != (slice{"Other", 5}) {
+		t.Errorf("排序或合并结果不正确: %+v", slices)
+	}
+}
+
+// TestRender 测试生成的所有文件都是合法的SVG
+func TestRender(t *testing.T) {
+	from := time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	commits := []git.CommitInfo{
+		{Date: time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC), ChangedFiles: []string{"main.go"}, RepoPath: "/work/a"},
+		{Date: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), ChangedFiles: []string{"web/app.tsx", "<odd>&.go"}, RepoPath: "/work/b"},
+	}
+
+	files := Render(commits, from, to, []Theme{Light, Dark})
+	if len(files) != 9 {
+		t.Fatalf("应生成9个文件, 得到: %d", len(files))
+	}
+
+	for _, file := range files {
+		decoder := xml.NewDecoder(strings.NewReader(file.Content))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s 不是合法的SVG: %v", file.Name, err)
+			}
+		}
+	}
+
+	byName := make(map[string]string)
+	for _, file := range files {
+		byName[file.Name] = file.Content
+	}
+	if !strings.Contains(byName["commits-per-month-dark.svg"], "2025-02: 0") {
+		t.Error("每月提交数应包含没有提交的月份")
+	}
+	if !strings.Contains(byName["badge-commits-this-year.svg"], ">1</text>") {
+		t.Error("今年提交数徽章应为1")
+	}
+	if !strings.Contains(byName["heatmap-dark.svg"], Dark.Background) {
+		t.Error("深色主题应使用深色背景")
+	}
+
+	// 时间范围不覆盖整年时，徽章统计时间范围内的提交并标注起始日期
+	partial := Render(commits[1:], time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), to, []Theme{Light})
+	for _, file := range partial {
+		if file.Name == "badge-commits-this-year.svg" && (!strings.Contains(file.Content, "2025-01-10") || !strings.Contains(file.Content, ">1</text>")) {
+			t.Errorf("徽章应为 2025-01-10 以来的1个提交: %s", file.Content)
+		}
+	}
+}
+
+// TestThemeByName 测试主题查找
+func TestThemeByName(t *testing.T) {
+	if theme, err := ThemeByName(" Dark "); err != nil || theme.Name != "dark" {
+		t.Errorf("应找到深色主题, 得到: %v, %v", theme.Name, err)
+	}
+	if _, err := ThemeByName("sepia"); err == nil {
+		t.Error("未知主题应返回错误")
+	}
+}
@@ -0,0 +1,99 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+)
+
+// ResumeStyle LaTeX 和 DOCX 简历的样式
+type ResumeStyle struct {
+	Name   string // moderncv 样式名
+	Color  string // moderncv 配色
+	Font   string // DOCX 正文字体
+	Accent string // DOCX 标题颜色（十六进制，不含 #）
+}
+
+// DefaultResumeStyle 默认的简历样式
+const DefaultResumeStyle = "classic"
+
+// resumeStyles 内置的简历样式，名称与 moderncv 的样式一致
+var resumeStyles = []ResumeStyle{
+	{Name: "classic", Color: "blue", Font: "Calibri", Accent: "1F4E79"},
+	{Name: "casual", Color: "green", Font: "Calibri", Accent: "2E7D32"},
+	{Name: "banking", Color: "black", Font: "Georgia", Accent: "000000"},
+	{Name: "oldstyle", Color: "burgundy", Font: "Garamond", Accent: "7B1E3A"},
+	{Name: "fancy", Color: "orange", Font: "Cambria", Accent: "C55A11"},
+}
+
+// ResumeStyleNames 返回所有内置简历样式的名称
+func ResumeStyleNames() []string {
+	names := make([]string, len(resumeStyles))
+	for i, style := range resumeStyles {
+		names[i] = style.Name
+	}
+	return names
+}
+
+// ResumeStyleByName 按名称查找简历样式，名称为空时返回默认样式
+func ResumeStyleByName(name string) (ResumeStyle, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = DefaultResumeStyle
+	}
+	for _, style := range resumeStyles {
+		if style.Name == name {
+			return style, nil
+		}
+	}
+	return resumeStyles[0], fmt.Errorf("unknown resume style %q (available: %s)", name, strings.Join(ResumeStyleNames(), ", "))
+}
+
+// latexReplacer 转义 LaTeX 特殊字符
+var latexReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// escapeLaTeX 转义文本中的 LaTeX 特殊字符
+func escapeLaTeX(text string) string {
+	return latexReplacer.Replace(text)
+}
+
+// escapeXML 转义文本中的 XML 特殊字符
+func escapeXML(text string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+// period 将简历中的起止日期格式化为 "2024-01 – 2024-03"，没有结束日期时显示为至今
+func (g *Generator) period(start, end string) string {
+	if start == "" && end == "" {
+		return ""
+	}
+	month := func(date string) string {
+		if len(date) > 7 {
+			return date[:7]
+		}
+		return date
+	}
+	if start == "" {
+		return month(end)
+	}
+	if end == "" {
+		end = i18n.T().ResumePresent
+	}
+	return month(start) + " – " + month(end)
+}
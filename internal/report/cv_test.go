@@ -0,0 +1,99 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// cvAnalysis 简历测试使用的项目经验分析结果
+const cvAnalysis = "## 项目经验总结\n\n### 项目1: 支付 & 结算\n**项目描述**: 100% 自研的 <结算> 服务\n\n**技术栈**: Go, C#\n\n**主要职责**:\n- 负责 {核心} 模块\n\n## 核心技能总结\n\n**编程语言**: Go\n"
+
+// TestGenerateLaTeX 测试生成的 LaTeX 简历会转义特殊字符
+func TestGenerateLaTeX(t *testing.T) {
+	var buf bytes.Buffer
+	generator := NewGenerator(FormatLaTeX, &buf)
+	generator.Author = "Li_Lei"
+	generator.ResumeStyle = "banking"
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := generator.GenerateProfileReport(cvAnalysis, testCommits(), from, from.AddDate(0, 6, 0), "experience"); err != nil {
+		t.Fatalf("生成LaTeX简历失败: %v", err)
+	}
+
+	tex := buf.String()
+	for _, want := range []string{
+		`\moderncvstyle{banking}`,
+		`\name{Li\_Lei}{}`,
+		`\cventry{}{支付 \& 结算}{}{Go, C\#}{}{100\% 自研的 <结算> 服务`,
+		`\item 负责 \{核心\} 模块`,
+		`\cvitem{编程语言}{Go}`,
+		`\end{document}`,
+	} {
+		if !strings.Contains(tex, want) {
+			t.Errorf("LaTeX 应包含 %q, 得到:\n%s", want, tex)
+		}
+	}
+}
+
+// TestGenerateDOCX 测试生成的 DOCX 是包含合法XML部件的压缩包
+func TestGenerateDOCX(t *testing.T) {
+	var buf bytes.Buffer
+	generator := NewGenerator(FormatDOCX, &buf)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := generator.GenerateProfileReport(cvAnalysis, testCommits(), from, from.AddDate(0, 6, 0), "experience"); err != nil {
+		t.Fatalf("生成DOCX简历失败: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("DOCX 不是合法的压缩包: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(r)
+		r.Close()
+		parts[file.Name] = string(content)
+
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s 不是合法的XML: %v", file.Name, err)
+			}
+		}
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml", "word/styles.xml", "word/numbering.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("DOCX 应包含 %s", name)
+		}
+	}
+	document := parts["word/document.xml"]
+	for _, want := range []string{"支付 &amp; 结算", "100% 自研的 &lt;结算&gt; 服务", `<w:pStyle w:val="ListBullet"/></w:pPr><w:r><w:t>负责 {核心} 模块`} {
+		if !strings.Contains(document, want) {
+			t.Errorf("正文应包含 %q, 得到:\n%s", want, document)
+		}
+	}
+	if !strings.Contains(parts["word/styles.xml"], "Calibri") {
+		t.Error("默认样式应使用 Calibri 字体")
+	}
+}
+
+// TestResumeStyleByName 测试简历样式查找
+func TestResumeStyleByName(t *testing.T) {
+	if style, err := ResumeStyleByName(""); err != nil || style.Name != DefaultResumeStyle {
+		t.Errorf("空名称应返回默认样式, 得到: %v, %v", style.Name, err)
+	}
+	if _, err := ResumeStyleByName("modern"); err == nil {
+		t.Error("未知样式应返回错误")
+	}
+}
@@ -0,0 +1,290 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/cite"
+	"github.com/MyceliumGrid/git-work-profile/internal/classify"
+	"github.com/MyceliumGrid/git-work-profile/internal/cluster"
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/MyceliumGrid/git-work-profile/internal/ownership"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+	"github.com/MyceliumGrid/git-work-profile/internal/render"
+	"github.com/MyceliumGrid/git-work-profile/internal/resume"
+	"github.com/MyceliumGrid/git-work-profile/internal/tickets"
+	"github.com/MyceliumGrid/git-work-profile/internal/verify"
+)
+
+// 图表中最多显示的语言和仓库数量
+const maxChartBars = 10
+
+// Data 报告模板使用的数据模型，内置模板和 --template 指定的自定义模板都使用该结构，
+// 字段说明见 TEMPLATES.md
+type Data struct {
+	Msg          i18n.Messages             // 当前语言的界面文本
+	Meta         Metadata                  // 报告元数据
+	Title        string                    // 报告标题
+	AnalysisType string                    // 分析类型，如 profile
+	From         time.Time                 // 分析开始时间
+	To           time.Time                 // 分析结束时间
+	Analysis     string                    // AI分析结果（Markdown）
+	Stats        Stats                     // 汇总统计
+	Profile      *profile.DeveloperProfile // 本地计算的开发者画像
+	Repos        []RepoStat                // 按提交数排序的仓库
+	Commits      []git.CommitInfo          // 参与分析的全部提交
+	Workstreams  []cluster.Workstream      // 本地聚类得到的工作流
+	Tickets      []tickets.Ticket          // 按工单分组的提交
+	Knowledge    []ownership.Area          // 知识地图：参与过的代码区域及各作者的熟悉程度
+	Verification *verify.Result            // AI分析结果的核查结果，可为空
+	Citations    *cite.Result              // AI分析结果中引用的提交，可为空
+	Unsupported  []verify.Claim            // 无法找到依据的声明
+	Charts       Charts                    // 图表数据
+	Resume       *resume.Resume            // 从项目经验分析中提取的简历数据
+	Style        ResumeStyle               // LaTeX 和 DOCX 简历的样式
+}
+
+// Metadata 报告元数据
+type Metadata struct {
+	GeneratedAt time.Time // 生成时间
+	Language    string    // 界面语言，如 zh
+	Format      string    // 输出格式
+	Author      string    // 分析的作者，为空表示所有作者
+	Version     string    // 工具版本
+}
+
+// Stats 汇总统计
+type Stats struct {
+	TotalCommits int
+	TotalRepos   int
+	TotalFiles   int // 变更过的不同文件数
+	LinesAdded   int
+	LinesDeleted int
+	ActiveDays   int            // 有提交的天数
+	FileTypes    map[string]int // 按扩展名统计的文件变更次数
+	Intents      classify.Breakdown
+	Survival     *profile.Survival // 代码存活和返工统计，可为空
+}
+
+// RepoStat 单个仓库的统计
+type RepoStat struct {
+	Name    string // 仓库目录名
+	Path    string // 仓库路径
+	Commits int
+}
+
+// Charts 图表数据
+type Charts struct {
+	Heatmap     [][]render.Day // 贡献日历，每周从周一开始
+	Weekdays    []string       // 周一至周日的名称
+	Hours       []ChartBar     // 按小时的提交分布
+	WeekdayBars []ChartBar     // 按星期的提交分布
+	Months      []ChartBar     // 每月提交数，包括没有提交的月份
+	Languages   []ChartBar     // 编程语言占比
+	Repos       []ChartBar     // 仓库提交数
+	Timeline    []TimelineItem // 工作流时间线
+}
+
+// ChartBar 柱状图中的一项
+type ChartBar struct {
+	Label   string
+	Value   int
+	Percent float64 // 相对于最大值的百分比，用于柱子长度
+	Share   float64 // 占总数的百分比
+}
+
+// TimelineItem 工作流时间线中的一项
+type TimelineItem struct {
+	Workstream cluster.Workstream
+	Offset     float64 // 起点在时间范围中的位置百分比
+	Width      float64 // 持续时间占时间范围的百分比
+}
+
+// BuildData 根据提交记录和生成器中的补充信息构建模板数据
+func (g *Generator) BuildData(analysis string, commits []git.CommitInfo, fromDate, toDate time.Time, analysisType string) Data {
+	msg := i18n.T()
+	dev := profile.AnalyzeProfile(commits, fromDate, toDate, g.Author)
+	dev.AIAnalysis = analysis
+	dev.Statistics.Survival = g.Survival
+	stats := dev.Statistics
+
+	repos := make(map[string]int)
+	var repoStats []RepoStat
+	for path, count := range stats.RepoStats {
+		repos[filepath.Base(path)] += count
+		repoStats = append(repoStats, RepoStat{Name: filepath.Base(path), Path: path, Commits: count})
+	}
+	sort.Slice(repoStats, func(i, j int) bool {
+		if repoStats[i].Commits != repoStats[j].Commits {
+			return repoStats[i].Commits > repoStats[j].Commits
+		}
+		return repoStats[i].Path < repoStats[j].Path
+	})
+
+	hours := make([]int, 24)
+	weekdays := make([]int, 7)
+	activeDays := make(map[string]bool)
+	for _, commit := range commits {
+		hours[commit.Date.Hour()]++
+		// 周一为一周的第一天
+		weekdays[(int(commit.Date.Weekday())+6)%7]++
+		activeDays[commit.Date.Format("2006-01-02")] = true
+	}
+
+	weekdayNames := strings.Fields(msg.ReportWeekdayNames)
+	hourLabels := make([]string, 24)
+	for h := range hourLabels {
+		hourLabels[h] = fmt.Sprintf("%02d", h)
+	}
+
+	// 时间范围内的每个月，没有提交的月份为 0
+	monthLabels := render.MonthRange(fromDate, toDate)
+	months := make([]int, len(monthLabels))
+	for i, month := range monthLabels {
+		months[i] = stats.CommitsByMonth[month]
+	}
+
+	data := Data{
+		Msg: msg,
+		Meta: Metadata{
+			GeneratedAt: time.Now(),
+			Language:    string(i18n.GetLanguage()),
+			Format:      string(g.Format),
+			Author:      g.Author,
+			Version:     g.Version,
+		},
+		Title:        g.getAnalysisTitle(analysisType),
+		AnalysisType: analysisType,
+		From:         fromDate,
+		To:           toDate,
+		Analysis:     analysis,
+		Stats: Stats{
+			TotalCommits: stats.TotalCommits,
+			TotalRepos:   stats.TotalRepos,
+			TotalFiles:   stats.FilesChanged,
+			LinesAdded:   stats.LinesAdded,
+			LinesDeleted: stats.LinesDeleted,
+			ActiveDays:   len(activeDays),
+			FileTypes:    stats.FileTypeStats,
+			Intents:      classify.Summarize(commits),
+			Survival:     g.Survival,
+		},
+		Profile:      dev,
+		Repos:        repoStats,
+		Commits:      commits,
+		Workstreams:  g.Workstreams,
+		Tickets:      g.Tickets,
+		Knowledge:    g.Knowledge,
+		Verification: g.Verification,
+		Citations:    g.Citations,
+		Charts: Charts{
+			Heatmap:     render.Calendar(commits, fromDate, toDate),
+			Weekdays:    weekdayNames,
+			Hours:       seriesBars(hourLabels, hours),
+			WeekdayBars: seriesBars(weekdayNames, weekdays),
+			Months:      seriesBars(monthLabels, months),
+			Languages:   rankedBars(dev.TechStack.Languages, maxChartBars),
+			Repos:       rankedBars(repos, maxChartBars),
+			Timeline:    buildTimeline(g.Workstreams, fromDate, toDate),
+		},
+	}
+	if g.Verification != nil {
+		data.Unsupported = g.Verification.Unsupported()
+	}
+	// 简历不需要引用的提交哈希
+	data.Resume = resume.FromAnalysis(cite.Strip(analysis), resume.Options{
+		Author:           g.Author,
+		From:             fromDate,
+		To:               toDate,
+		Workstreams:      g.Workstreams,
+		Languages:        dev.TechStack.Languages,
+		UnverifiedMarker: msg.ReportUnverifiedMarker,
+		Now:              data.Meta.GeneratedAt,
+	})
+	data.Style, _ = ResumeStyleByName(g.ResumeStyle)
+	return data
+}
+
+// seriesBars 按固定顺序生成柱状图数据
+func seriesBars(labels []string, values []int) []ChartBar {
+	total, maxValue := 0, 0
+	for _, v := range values {
+		total += v
+		maxValue = max(maxValue, v)
+	}
+
+	bars := make([]ChartBar, len(values))
+	for i, v := range values {
+		bars[i] = ChartBar{Value: v, Percent: ratio(v, maxValue), Share: ratio(v, total)}
+		if i < len(labels) {
+			bars[i].Label = labels[i]
+		}
+	}
+	return bars
+}
+
+// rankedBars 按数量从多到少生成柱状图数据，最多保留 limit 项
+func rankedBars(counts map[string]int, limit int) []ChartBar {
+	labels := make([]string, 0, len(counts))
+	for label := range counts {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if counts[labels[i]] != counts[labels[j]] {
+			return counts[labels[i]] > counts[labels[j]]
+		}
+		return labels[i] < labels[j]
+	})
+	if len(labels) > limit {
+		labels = labels[:limit]
+	}
+
+	values := make([]int, len(labels))
+	for i, label := range labels {
+		values[i] = counts[label]
+	}
+	bars := seriesBars(labels, values)
+
+	// 占比按全部数据计算，而不只是显示出来的部分
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	for i := range bars {
+		bars[i].Share = ratio(bars[i].Value, total)
+	}
+	return bars
+}
+
+// buildTimeline 计算工作流在时间范围中的位置
+func buildTimeline(workstreams []cluster.Workstream, fromDate, toDate time.Time) []TimelineItem {
+	span := toDate.Sub(fromDate).Hours()
+	if span <= 0 {
+		span = 24
+	}
+
+	items := make([]TimelineItem, 0, len(workstreams))
+	for _, ws := range workstreams {
+		offset := math.Min(math.Max(ws.From.Sub(fromDate).Hours()/span*100, 0), 99)
+		width := math.Max(ws.To.Sub(ws.From).Hours()/span*100, 1)
+		items = append(items, TimelineItem{
+			Workstream: ws,
+			Offset:     offset,
+			Width:      math.Min(width, 100-offset),
+		})
+	}
+	return items
+}
+
+// ratio 计算百分比
+func ratio(value, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(value) * 100 / float64(total)
+}
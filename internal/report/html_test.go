@@ -0,0 +1,58 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// TestRenderMarkdown 测试将AI分析结果转换为HTML
+func TestRenderMarkdown(t *testing.T) {
+	source := "## 项目1: 支付\n\n**技术栈**: Go, `gRPC`\n\n- 负责 <script>\n  续行\n  - 嵌套\n- [文档](https://example.com) 与 [坏链接](javascript:alert)\n\n3. 第三项\n\n| A | B |\n|---|---|\n| 1 | 2 |\n"
+	got := renderMarkdown(source)
+
+	expected := []string{
+		"<h2>项目1: 支付</h2>",
+		"<p><strong>技术栈</strong>: Go, <code>gRPC</code></p>",
+		"<li>负责 &lt;script&gt; 续行",
+		"<ul>\n<li>嵌套</li>\n</ul>",
+		`<a href="https://example.com">文档</a> 与 坏链接`,
+		`<ol start="3">`,
+		"<th>A</th><th>B</th>",
+		"<td>1</td><td>2</td>",
+	}
+	for _, want := range expected {
+		if !strings.Contains(got, want) {
+			t.Errorf("输出应包含 %q, 得到:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "<script>") || strings.Contains(got, "javascript:") {
+		t.Errorf("输出不应包含未转义的脚本, 得到:\n%s", got)
+	}
+}
+
+// TestGenerateHTMLReport 测试生成的HTML报告是自包含的
+func TestGenerateHTMLReport(t *testing.T) {
+	var buf bytes.Buffer
+	generator := NewGenerator(FormatHTML, &buf)
+	commits := []git.CommitInfo{
+		{Hash: "abc12345", Date: time.Now(), Message: "feat: add", ChangedFiles: []string{"main.go"}, RepoPath: "/work/app"},
+	}
+
+	if err := generator.GenerateProfileReport("# 总结\n\n内容", commits, time.Now().AddDate(0, -1, 0), time.Now(), "profile"); err != nil {
+		t.Fatalf("生成HTML报告失败: %v", err)
+	}
+
+	html := buf.String()
+	for _, want := range []string{"<!DOCTYPE html>", "<style>", "<script>", "<h1>总结</h1>", "Go"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML报告应包含 %q", want)
+		}
+	}
+	if strings.Contains(html, "http://") || strings.Contains(html, "src=\"https://") {
+		t.Error("HTML报告不应引用外部资源")
+	}
+}
@@ -0,0 +1,374 @@
+package report
+
+import (
+	"embed"
+	"encoding/json"
+	"math"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/classify"
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+	"github.com/MyceliumGrid/git-work-profile/internal/tickets"
+	"github.com/MyceliumGrid/git-work-profile/internal/verify"
+)
+
+// JSONSchemaVersion JSON 报告的 schema 版本。
+// 同一主版本内只会新增可选字段，不会删除、重命名字段或改变字段类型；
+// 不兼容的修改会增加主版本号并发布新的 schema 文件。
+const JSONSchemaVersion = "1.3.0"
+
+// JSONSchemaURL JSON 报告 schema 的地址，写入报告的 $schema 字段
+const JSONSchemaURL = "https://github.com/MyceliumGrid/git-work-profile/blob/main/internal/report/schema/report.v1.json"
+
+// jsonSchemaFiles 发布的 JSON Schema 文件
+//
+//go:embed schema/report.v1.json
+var jsonSchemaFiles embed.FS
+
+// JSONSchema 返回 JSON 报告的 JSON Schema
+func JSONSchema() string {
+	content, _ := jsonSchemaFiles.ReadFile("schema/report.v1.json")
+	return string(content)
+}
+
+// JSONReport JSON 格式报告的结构，字段说明见 schema/report.v1.json
+type JSONReport struct {
+	Schema        string              `json:"$schema"`
+	SchemaVersion string              `json:"schema_version"`
+	Generator     JSONGenerator       `json:"generator"`
+	GeneratedAt   string              `json:"generated_at"`
+	AnalysisType  string              `json:"analysis_type"`
+	Author        string              `json:"author,omitempty"`
+	Model         string              `json:"model,omitempty"`
+	Language      string              `json:"language"`
+	TimeRange     JSONTimeRange       `json:"time_range"`
+	Statistics    JSONStatistics      `json:"statistics"`
+	Repos         []JSONRepo          `json:"repos"`
+	AIAnalysis    string              `json:"ai_analysis"`
+	Citations     []JSONCitation      `json:"citations,omitempty"`
+	Evidence      map[string][]string `json:"evidence,omitempty"`
+	Invalid       []string            `json:"invalid_citations,omitempty"`
+	Verification  *verify.Result      `json:"verification,omitempty"`
+	Workstreams   []JSONWorkstream    `json:"workstreams,omitempty"`
+	Tickets       []tickets.Ticket    `json:"tickets,omitempty"`
+	KnowledgeMap  []JSONArea          `json:"knowledge_map,omitempty"`
+	Commits       []JSONCommit        `json:"commits,omitempty"`
+}
+
+// JSONGenerator 生成报告的工具
+type JSONGenerator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// JSONTimeRange 分析的时间范围
+type JSONTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// JSONStatistics 汇总统计
+type JSONStatistics struct {
+	TotalCommits  int                `json:"total_commits"`
+	TotalRepos    int                `json:"total_repos"`
+	TotalFiles    int                `json:"total_files"`
+	LinesAdded    int                `json:"lines_added"`
+	LinesDeleted  int                `json:"lines_deleted"`
+	ActiveDays    int                `json:"active_days"`
+	FileTypes     map[string]int     `json:"file_types"`
+	Languages     map[string]int     `json:"languages"`
+	CommitIntents classify.Breakdown `json:"commit_intents"`
+	Survival      *JSONSurvival      `json:"survival,omitempty"`
+}
+
+// JSONSurvival 开发者新增代码的存活和返工统计
+type JSONSurvival struct {
+	ChurnDays    int `json:"churn_days"`
+	Files        int `json:"files"`
+	SampledFiles int `json:"sampled_files"`
+	JSONLineSurvival
+	ByRepo     []JSONSurvivalGroup `json:"by_repo"`
+	ByLanguage []JSONSurvivalGroup `json:"by_language"`
+}
+
+// JSONSurvivalGroup 单个仓库或语言的存活和返工统计
+type JSONSurvivalGroup struct {
+	Name string `json:"name"`
+	JSONLineSurvival
+}
+
+// JSONLineSurvival 一组代码行的存活和返工统计
+type JSONLineSurvival struct {
+	Added        int     `json:"added"`
+	Surviving    int     `json:"surviving"`
+	SurvivalRate float64 `json:"survival_rate"`
+	Settled      int     `json:"settled"`
+	Churned      int     `json:"churned"`
+	ChurnRate    float64 `json:"churn_rate"`
+}
+
+// JSONRepo 仓库统计
+type JSONRepo struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Commits int    `json:"commits"`
+}
+
+// JSONWorkstream 工作流
+type JSONWorkstream struct {
+	Label           string       `json:"label"`
+	Keywords        []string     `json:"keywords"`
+	From            string       `json:"from"`
+	To              string       `json:"to"`
+	Repos           []string     `json:"repos"`
+	Branches        []string     `json:"branches"`
+	CommitCount     int          `json:"commit_count"`
+	Hashes          []string     `json:"hashes"`
+	Representatives []JSONCommit `json:"representative_commits"`
+}
+
+// JSONArea 知识地图中的代码区域
+type JSONArea struct {
+	Path      string       `json:"path"`
+	Commits   int          `json:"commits"`
+	Lines     int          `json:"lines"`
+	BusFactor int          `json:"bus_factor"`
+	Experts   []JSONExpert `json:"experts"`
+	Declared  []string     `json:"codeowners,omitempty"`
+	Mismatch  bool         `json:"codeowners_mismatch,omitempty"`
+}
+
+// JSONExpert 代码区域中的一个作者
+type JSONExpert struct {
+	Name        string  `json:"name"`
+	Score       float64 `json:"score"`
+	Commits     int     `json:"commits"`
+	Lines       int     `json:"lines"`
+	CommitShare float64 `json:"commit_share"`
+	LineShare   float64 `json:"line_share"`
+	LastCommit  string  `json:"last_commit"`
+}
+
+// JSONCitation AI分析结果中引用的提交
+type JSONCitation struct {
+	Hash     string   `json:"hash"`
+	Short    string   `json:"short"`
+	Repo     string   `json:"repo"`
+	Date     string   `json:"date"`
+	Subject  string   `json:"subject"`
+	URL      string   `json:"url,omitempty"`
+	Sections []string `json:"sections"`
+}
+
+// JSONCommit 单个提交，工作流中的代表性提交只包含哈希、仓库、日期和标题
+type JSONCommit struct {
+	Hash           string                   `json:"hash"`
+	Repo           string                   `json:"repo"`
+	Date           string                   `json:"date"`
+	Author         string                   `json:"author,omitempty"`
+	Subject        string                   `json:"subject"`
+	Branches       []string                 `json:"branches,omitempty"`
+	Files          []string                 `json:"files,omitempty"`
+	LinesAdded     int                      `json:"lines_added"`
+	LinesDeleted   int                      `json:"lines_deleted"`
+	Classification *classify.Classification `json:"classification,omitempty"`
+	Tickets        []string                 `json:"tickets,omitempty"`
+}
+
+// BuildJSONReport 根据模板数据构建 JSON 报告，所有列表都按固定顺序排列
+func (g *Generator) BuildJSONReport(data Data) JSONReport {
+	result := JSONReport{
+		Schema:        JSONSchemaURL,
+		SchemaVersion: JSONSchemaVersion,
+		Generator:     JSONGenerator{Name: "git-work-profile", Version: data.Meta.Version},
+		GeneratedAt:   data.Meta.GeneratedAt.Format(time.RFC3339),
+		AnalysisType:  data.AnalysisType,
+		Author:        data.Meta.Author,
+		Model:         g.Model,
+		Language:      data.Meta.Language,
+		TimeRange: JSONTimeRange{
+			From: data.From.Format("2006-01-02"),
+			To:   data.To.Format("2006-01-02"),
+		},
+		Statistics: JSONStatistics{
+			TotalCommits:  data.Stats.TotalCommits,
+			TotalRepos:    data.Stats.TotalRepos,
+			TotalFiles:    data.Stats.TotalFiles,
+			LinesAdded:    data.Stats.LinesAdded,
+			LinesDeleted:  data.Stats.LinesDeleted,
+			ActiveDays:    data.Stats.ActiveDays,
+			FileTypes:     nonNilMap(data.Stats.FileTypes),
+			Languages:     nonNilMap(data.Profile.TechStack.Languages),
+			CommitIntents: data.Stats.Intents,
+		},
+		Repos:        make([]JSONRepo, 0, len(data.Repos)),
+		AIAnalysis:   data.Analysis,
+		Verification: data.Verification,
+		Tickets:      data.Tickets,
+	}
+	if result.Generator.Version == "" {
+		result.Generator.Version = "dev"
+	}
+
+	for _, repo := range data.Repos {
+		result.Repos = append(result.Repos, JSONRepo{Name: repo.Name, Path: repo.Path, Commits: repo.Commits})
+	}
+
+	for _, ws := range data.Workstreams {
+		item := JSONWorkstream{
+			Label:       ws.Label,
+			Keywords:    nonNilSlice(ws.Keywords),
+			From:        ws.From.Format(time.RFC3339),
+			To:          ws.To.Format(time.RFC3339),
+			Repos:       nonNilSlice(ws.Repos),
+			Branches:    nonNilSlice(ws.Branches),
+			CommitCount: ws.CommitCount,
+			Hashes:      nonNilSlice(ws.Hashes),
+		}
+		for _, commit := range ws.Representatives {
+			item.Representatives = append(item.Representatives, JSONCommit{
+				Hash:    commit.Hash,
+				Repo:    filepath.Base(commit.RepoPath),
+				Date:    commit.Date.Format(time.RFC3339),
+				Subject: commit.Message,
+			})
+		}
+		if item.Representatives == nil {
+			item.Representatives = []JSONCommit{}
+		}
+		result.Workstreams = append(result.Workstreams, item)
+	}
+
+	if data.Citations != nil {
+		for _, citation := range data.Citations.Citations {
+			result.Citations = append(result.Citations, JSONCitation{
+				Hash:     citation.Hash,
+				Short:    citation.Short,
+				Repo:     citation.Repo,
+				Date:     citation.Date.Format(time.RFC3339),
+				Subject:  citation.Subject,
+				URL:      citation.URL,
+				Sections: nonNilSlice(citation.Sections),
+			})
+		}
+		if len(data.Citations.Evidence) > 0 {
+			result.Evidence = data.Citations.Evidence
+		}
+		result.Invalid = data.Citations.Invalid
+	}
+
+	if survival := data.Stats.Survival; survival != nil {
+		result.Statistics.Survival = &JSONSurvival{
+			ChurnDays:        survival.ChurnDays,
+			Files:            survival.Files,
+			SampledFiles:     survival.SampledFiles,
+			JSONLineSurvival: jsonLineSurvival(survival.Total),
+			ByRepo:           []JSONSurvivalGroup{},
+			ByLanguage:       []JSONSurvivalGroup{},
+		}
+		for _, repo := range profile.Ranked(survival.ByRepo) {
+			result.Statistics.Survival.ByRepo = append(result.Statistics.Survival.ByRepo,
+				JSONSurvivalGroup{Name: filepath.Base(repo), JSONLineSurvival: jsonLineSurvival(survival.ByRepo[repo])})
+		}
+		for _, lang := range profile.Ranked(survival.ByLanguage) {
+			result.Statistics.Survival.ByLanguage = append(result.Statistics.Survival.ByLanguage,
+				JSONSurvivalGroup{Name: lang, JSONLineSurvival: jsonLineSurvival(survival.ByLanguage[lang])})
+		}
+	}
+
+	for _, area := range data.Knowledge {
+		item := JSONArea{Path: area.Path, Commits: area.Commits, Lines: area.Lines, BusFactor: area.BusFactor, Declared: area.Declared, Mismatch: area.Mismatch, Experts: []JSONExpert{}}
+		for _, owner := range area.Owners {
+			item.Experts = append(item.Experts, JSONExpert{
+				Name:        owner.Name,
+				Score:       round(owner.Score),
+				Commits:     owner.Commits,
+				Lines:       owner.Lines,
+				CommitShare: round(owner.CommitShare),
+				LineShare:   round(owner.LineShare),
+				LastCommit:  owner.Last.Format(time.RFC3339),
+			})
+		}
+		result.KnowledgeMap = append(result.KnowledgeMap, item)
+	}
+
+	if g.IncludeCommits {
+		result.Commits = buildJSONCommits(data.Commits, tickets.ByCommit(data.Tickets))
+	}
+	return result
+}
+
+// buildJSONCommits 构建提交列表，按时间和哈希排序
+func buildJSONCommits(commits []git.CommitInfo, ticketsByCommit map[string][]string) []JSONCommit {
+	sorted := append([]git.CommitInfo(nil), commits...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Date.Equal(sorted[j].Date) {
+			return sorted[i].Date.Before(sorted[j].Date)
+		}
+		return sorted[i].Hash < sorted[j].Hash
+	})
+
+	result := make([]JSONCommit, 0, len(sorted))
+	for _, commit := range sorted {
+		classification := classify.Classify(commit)
+		files := append([]string(nil), commit.ChangedFiles...)
+		sort.Strings(files)
+		result = append(result, JSONCommit{
+			Hash:           commit.Hash,
+			Repo:           filepath.Base(commit.RepoPath),
+			Date:           commit.Date.Format(time.RFC3339),
+			Author:         commit.Author,
+			Subject:        commit.Message,
+			Branches:       commit.Branches,
+			Files:          files,
+			LinesAdded:     commit.LinesAdded,
+			LinesDeleted:   commit.LinesDeleted,
+			Classification: &classification,
+			Tickets:        ticketsByCommit[commit.Hash],
+		})
+	}
+	return result
+}
+
+// generateJSONReport 生成JSON格式的分析报告
+func (g *Generator) generateJSONReport(data Data) error {
+	encoder := json.NewEncoder(g.Output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g.BuildJSONReport(data))
+}
+
+// nonNilMap 将空映射输出为 {} 而不是 null
+func nonNilMap(m map[string]int) map[string]int {
+	if m == nil {
+		return map[string]int{}
+	}
+	return m
+}
+
+// nonNilSlice 将空列表输出为 [] 而不是 null
+func nonNilSlice(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// round 保留三位小数，使输出稳定
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
+
+// jsonLineSurvival 转换一组代码行的存活统计，比例保留三位小数
+func jsonLineSurvival(s profile.LineSurvival) JSONLineSurvival {
+	return JSONLineSurvival{
+		Added:        s.Added,
+		Surviving:    s.Surviving,
+		SurvivalRate: round(s.SurvivalRate()),
+		Settled:      s.Settled,
+		Churned:      s.Churned,
+		ChurnRate:    round(s.ChurnRate()),
+	}
+}
@@ -0,0 +1,212 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/cite"
+	"github.com/MyceliumGrid/git-work-profile/internal/cluster"
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/ownership"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+	"github.com/MyceliumGrid/git-work-profile/internal/tickets"
+	"github.com/MyceliumGrid/git-work-profile/internal/verify"
+)
+
+// generateTestJSON 生成包含所有可选部分的 JSON 报告
+func generateTestJSON(t *testing.T, commits []git.CommitInfo) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	generator := NewGenerator(FormatJSON, &buf)
+	generator.Author = "alice"
+	generator.Model = "gemini-2.5-pro"
+	generator.IncludeCommits = true
+	generator.Verification = &verify.Result{Claims: []verify.Claim{{Kind: verify.ClaimTechnology, Text: "Go", Supported: true, Evidence: []string{"abc1234567"}}}}
+	sample := testCommits()
+	generator.Workstreams = []cluster.Workstream{{Label: "api", From: sample[0].Date, To: sample[1].Date, CommitCount: 2, Representatives: sample[:1]}}
+	generator.Tickets = []tickets.Ticket{{ID: "#12", Tracker: tickets.TrackerGitHub, Hashes: []string{"def7654321"}}}
+	generator.Knowledge = []ownership.Area{{Path: "api", Commits: 2, Lines: 30, BusFactor: 1, Owners: []ownership.Owner{{Name: "alice", Commits: 2, Lines: 30, CommitShare: 1, LineShare: 1, Score: 1, Last: sample[1].Date}}, Declared: []string{"@bob"}, Mismatch: true}}
+	generator.Survival = testSurvival()
+	analysis := "# 总结\n- 新增接口 [abc12345] [fff99999]"
+	generator.Citations = cite.Resolve(analysis, sample, map[string]string{"/work/app": "https://github.com/acme/app"})
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := generator.GenerateProfileReport(cite.Clean(analysis, generator.Citations), commits, from, from.AddDate(0, 1, 0), "profile"); err != nil {
+		t.Fatalf("生成JSON报告失败: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// testSurvival 一个仓库、两种语言的代码存活统计
+func testSurvival() *profile.Survival {
+	return &profile.Survival{
+		ChurnDays:    21,
+		Files:        3,
+		SampledFiles: 2,
+		Total:        profile.LineSurvival{Added: 40, Surviving: 30, Settled: 20, Churned: 5},
+		ByRepo:       map[string]profile.LineSurvival{"/work/app": {Added: 40, Surviving: 30, Settled: 20, Churned: 5}},
+		ByLanguage: map[string]profile.LineSurvival{
+			"Go":         {Added: 30, Surviving: 25, Settled: 20, Churned: 5},
+			"TypeScript": {Added: 10, Surviving: 5},
+		},
+	}
+}
+
+// TestJSONReportMatchesSchema 测试生成的 JSON 报告符合发布的 JSON Schema，且没有未声明的字段
+func TestJSONReportMatchesSchema(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(JSONSchema()), &schema); err != nil {
+		t.Fatalf("JSON Schema 不是合法的JSON: %v", err)
+	}
+
+	var report any
+	if err := json.Unmarshal(generateTestJSON(t, testCommits()), &report); err != nil {
+		t.Fatalf("JSON报告不是合法的JSON: %v", err)
+	}
+
+	for _, problem := range checkSchema(schema, schema, report, "$") {
+		t.Error(problem)
+	}
+}
+
+// TestJSONReportDeterministic 测试提交顺序不影响输出
+func TestJSONReportDeterministic(t *testing.T) {
+	commits := testCommits()
+	reversed := []git.CommitInfo{commits[1], commits[0]}
+
+	first := string(generateTestJSON(t, commits))
+	second := string(generateTestJSON(t, reversed))
+	// 生成时间不同，比较前去掉
+	strip := func(s string) string {
+		i := strings.Index(s, `"generated_at"`)
+		j := strings.Index(s[i:], "\n")
+		return s[:i] + s[i+j:]
+	}
+	if strip(first) != strip(second) {
+		t.Errorf("输出应与提交顺序无关:\n%s\n---\n%s", first, second)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal([]byte(first), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.KnowledgeMap) != 1 || report.KnowledgeMap[0].Experts[0].Name != "alice" || !report.KnowledgeMap[0].Mismatch {
+		t.Errorf("知识地图不正确: %+v", report.KnowledgeMap)
+	}
+	if s := report.Statistics.Survival; s == nil || s.SurvivalRate != 0.75 || len(s.ByRepo) != 1 || s.ByRepo[0].Name != "app" || s.ByLanguage[0].Name != "Go" {
+		t.Errorf("代码存活统计不正确: %+v", report.Statistics.Survival)
+	}
+	if report.SchemaVersion != JSONSchemaVersion || len(report.Commits) != 2 || report.Commits[0].Hash != "abc1234567" {
+		t.Errorf("版本或提交列表不正确: %+v", report)
+	}
+	if report.Commits[1].Classification.Intent != "fix" || strings.Join(report.Commits[1].Files, ",") != "main.go,web/app.ts" {
+		t.Errorf("提交详情不正确: %+v", report.Commits[1])
+	}
+	if len(report.Commits[1].Tickets) != 1 || report.Commits[1].Tickets[0] != "#12" {
+		t.Errorf("提交应关联工单: %+v", report.Commits[1].Tickets)
+	}
+	if len(report.Citations) != 1 || report.Citations[0].URL != "https://github.com/acme/app/commit/abc1234567" || len(report.Evidence["总结"]) != 1 {
+		t.Errorf("引用或证据映射不正确: %+v %v", report.Citations, report.Evidence)
+	}
+	if strings.Join(report.Invalid, ",") != "fff99999" || strings.Contains(report.AIAnalysis, "fff99999") {
+		t.Errorf("无效引用应被记录并从分析结果中删除: %v %q", report.Invalid, report.AIAnalysis)
+	}
+}
+
+// checkSchema 按 JSON Schema 的常用关键字校验数据，返回发现的问题
+func checkSchema(root, schema map[string]any, value any, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/$defs/")
+		return checkSchema(root, root["$defs"].(map[string]any)[name].(map[string]any), value, path)
+	}
+
+	var problems []string
+	if types, ok := schema["type"]; ok && !matchesType(types, value) {
+		return []string{fmt.Sprintf("%s: 类型应为 %v, 得到: %T", path, types, value)}
+	}
+	if c, ok := schema["const"]; ok && c != value {
+		problems = append(problems, fmt.Sprintf("%s: 应为 %v", path, c))
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			found = found || e == value
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: %v 不在 %v 中", path, value, enum))
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		for _, key := range anySlice(schema["required"]) {
+			if _, ok := v[key.(string)]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: 缺少必需字段 %s", path, key))
+			}
+		}
+		for key, field := range v {
+			if sub, ok := properties[key].(map[string]any); ok {
+				problems = append(problems, checkSchema(root, sub, field, path+"."+key)...)
+			} else if sub, ok := schema["additionalProperties"].(map[string]any); ok {
+				problems = append(problems, checkSchema(root, sub, field, path+"."+key)...)
+			} else if properties != nil {
+				problems = append(problems, fmt.Sprintf("%s: 字段 %s 未在 schema 中声明", path, key))
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				problems = append(problems, checkSchema(root, items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return problems
+}
+
+// matchesType 判断值是否符合 JSON Schema 的 type
+func matchesType(types any, value any) bool {
+	for _, t := range append(anySlice(types), types) {
+		switch t {
+		case "object":
+			if _, ok := value.(map[string]any); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]any); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := value.(float64); ok && n == float64(int64(n)) {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "null":
+			if value == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// anySlice 将 JSON 数组转换为切片，其他值返回空
+func anySlice(value any) []any {
+	list, _ := value.([]any)
+	return list
+}
@@ -0,0 +1,243 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Markdown 语法的匹配规则，只覆盖AI分析结果中常见的写法
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	listItemPattern    = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	rulePattern        = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	tableRulePattern   = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	boldPattern        = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	italicPattern      = regexp.MustCompile(`(^|[^*\w])\*([^*\s][^*]*?)\*`)
+	strikePattern      = regexp.MustCompile(`~~(.+?)~~`)
+	linkPattern        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	safeURLPattern     = regexp.MustCompile(`(?i)^(https?://|mailto:|#|/|\./|\.\./|[\w.-]+(/|$))`)
+	fencePattern       = regexp.MustCompile("^\\s*(```|~~~)\\s*([\\w+-]*)")
+	blockquotePattern  = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	orderedNumberStart = regexp.MustCompile(`^\d+`)
+)
+
+// listLevel 一层未关闭的列表
+type listLevel struct {
+	indent  int
+	ordered bool
+}
+
+// markdownRenderer 将 Markdown 转换为 HTML 的状态
+type markdownRenderer struct {
+	out       strings.Builder
+	paragraph []string
+	lists     []listLevel
+}
+
+// renderMarkdown 将 Markdown 转换为 HTML，所有文本都会被转义
+func renderMarkdown(source string) string {
+	r := &markdownRenderer{}
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			r.flushParagraph()
+			r.closeLists(0)
+
+		case fencePattern.MatchString(line):
+			r.flushParagraph()
+			r.closeLists(0)
+			match := fencePattern.FindStringSubmatch(line)
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), match[1]); i++ {
+				code = append(code, lines[i])
+			}
+			if match[2] != "" {
+				fmt.Fprintf(&r.out, "<pre><code class=\"language-%s\">", html.EscapeString(match[2]))
+			} else {
+				r.out.WriteString("<pre><code>")
+			}
+			r.out.WriteString(html.EscapeString(strings.Join(code, "\n")))
+			r.out.WriteString("</code></pre>\n")
+
+		case headingPattern.MatchString(trimmed):
+			r.flushParagraph()
+			r.closeLists(0)
+			match := headingPattern.FindStringSubmatch(trimmed)
+			fmt.Fprintf(&r.out, "<h%d>%s</h%d>\n", len(match[1]), renderInline(match[2]), len(match[1]))
+
+		case rulePattern.MatchString(line):
+			r.flushParagraph()
+			r.closeLists(0)
+			r.out.WriteString("<hr>\n")
+
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && tableRulePattern.MatchString(lines[i+1]):
+			r.flushParagraph()
+			r.closeLists(0)
+			rows := []string{line}
+			for i += 2; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				rows = append(rows, lines[i])
+			}
+			i--
+			r.writeTable(rows)
+
+		case blockquotePattern.MatchString(line):
+			r.flushParagraph()
+			r.closeLists(0)
+			var quote []string
+			for ; i < len(lines) && blockquotePattern.MatchString(lines[i]); i++ {
+				quote = append(quote, blockquotePattern.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			fmt.Fprintf(&r.out, "<blockquote>\n%s</blockquote>\n", renderMarkdown(strings.Join(quote, "\n")))
+
+		case listItemPattern.MatchString(line):
+			r.flushParagraph()
+			match := listItemPattern.FindStringSubmatch(line)
+			r.writeListItem(indentWidth(match[1]), match[2], match[3])
+
+		case len(r.lists) > 0 && indentWidth(line) > 0:
+			// 列表项的续行
+			r.out.WriteString(" " + renderInline(trimmed))
+
+		default:
+			r.closeLists(0)
+			r.paragraph = append(r.paragraph, trimmed)
+		}
+	}
+
+	r.flushParagraph()
+	r.closeLists(0)
+	return r.out.String()
+}
+
+// flushParagraph 输出累积的段落
+func (r *markdownRenderer) flushParagraph() {
+	if len(r.paragraph) == 0 {
+		return
+	}
+	fmt.Fprintf(&r.out, "<p>%s</p>\n", renderInline(strings.Join(r.paragraph, " ")))
+	r.paragraph = nil
+}
+
+// writeListItem 输出一个列表项，根据缩进打开或关闭嵌套列表
+func (r *markdownRenderer) writeListItem(indent int, marker, text string) {
+	ordered := orderedNumberStart.MatchString(marker)
+	r.closeLists(indent + 1)
+
+	if n := len(r.lists); n > 0 && r.lists[n-1].indent == indent {
+		if r.lists[n-1].ordered == ordered {
+			r.out.WriteString("</li>\n<li>" + renderInline(text))
+			return
+		}
+		r.closeLists(indent)
+	}
+
+	switch {
+	case !ordered:
+		r.out.WriteString("<ul>\n")
+	case marker[:len(marker)-1] != "1":
+		start, _ := strconv.Atoi(marker[:len(marker)-1])
+		fmt.Fprintf(&r.out, "<ol start=\"%d\">\n", start)
+	default:
+		r.out.WriteString("<ol>\n")
+	}
+	r.lists = append(r.lists, listLevel{indent: indent, ordered: ordered})
+	r.out.WriteString("<li>" + renderInline(text))
+}
+
+// closeLists 关闭缩进不小于 indent 的列表
+func (r *markdownRenderer) closeLists(indent int) {
+	for n := len(r.lists); n > 0 && r.lists[n-1].indent >= indent; n = len(r.lists) {
+		if r.lists[n-1].ordered {
+			r.out.WriteString("</li>\n</ol>\n")
+		} else {
+			r.out.WriteString("</li>\n</ul>\n")
+		}
+		r.lists = r.lists[:n-1]
+	}
+}
+
+// writeTable 输出表格，第一行为表头
+func (r *markdownRenderer) writeTable(rows []string) {
+	r.out.WriteString("<table>\n<thead><tr>")
+	for _, cell := range splitTableRow(rows[0]) {
+		fmt.Fprintf(&r.out, "<th>%s</th>", renderInline(cell))
+	}
+	r.out.WriteString("</tr></thead>\n<tbody>\n")
+	for _, row := range rows[1:] {
+		r.out.WriteString("<tr>")
+		for _, cell := range splitTableRow(row) {
+			fmt.Fprintf(&r.out, "<td>%s</td>", renderInline(cell))
+		}
+		r.out.WriteString("</tr>\n")
+	}
+	r.out.WriteString("</tbody>\n</table>\n")
+}
+
+// splitTableRow 拆分表格行中的单元格
+func splitTableRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+	cells := strings.Split(row, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// indentWidth 计算行首缩进宽度，制表符按4个空格计算
+func indentWidth(line string) int {
+	width := 0
+	for _, ch := range line {
+		switch ch {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// renderInline 转换行内语法：代码、链接、粗体、斜体和删除线
+func renderInline(text string) string {
+	var builder strings.Builder
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		// 奇数位置为代码片段，末尾未闭合的反引号按普通文本处理
+		if i%2 == 1 && i < len(parts)-1 {
+			builder.WriteString("<code>" + html.EscapeString(part) + "</code>")
+			continue
+		}
+		if i%2 == 1 {
+			builder.WriteString("`")
+		}
+		builder.WriteString(renderEmphasis(html.EscapeString(part)))
+	}
+	return builder.String()
+}
+
+// renderEmphasis 转换已转义文本中的链接和强调语法
+func renderEmphasis(escaped string) string {
+	escaped = linkPattern.ReplaceAllStringFunc(escaped, func(link string) string {
+		match := linkPattern.FindStringSubmatch(link)
+		if !safeURLPattern.MatchString(html.UnescapeString(match[2])) {
+			return match[1]
+		}
+		return fmt.Sprintf(`<a href="%s">%s</a>`, match[2], match[1])
+	})
+	escaped = boldPattern.ReplaceAllString(escaped, "<strong>$1$2</strong>")
+	escaped = italicPattern.ReplaceAllString(escaped, "$1<em>$2</em>")
+	return strikePattern.ReplaceAllString(escaped, "<del>$1</del>")
+}
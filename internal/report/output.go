@@ -0,0 +1,92 @@
+package report
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultFileNameTemplate 一次输出多种格式时默认的文件名模板，不含扩展名
+const DefaultFileNameTemplate = "{author}-{analysis}-{from}-{to}"
+
+// formatAliases 格式的简写
+var formatAliases = map[string]Format{
+	"md":  FormatMarkdown,
+	"txt": FormatText,
+	"tex": FormatLaTeX,
+}
+
+// formatExtensions 各格式输出文件的扩展名
+var formatExtensions = map[Format]string{
+	FormatText:       "txt",
+	FormatMarkdown:   "md",
+	FormatJSON:       "json",
+	FormatHTML:       "html",
+	FormatJSONResume: "resume.json",
+	FormatLaTeX:      "tex",
+	FormatDOCX:       "docx",
+}
+
+// ParseFormat 解析格式名称，支持 md、txt、tex 等简写，不区分大小写
+func ParseFormat(name string) (Format, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if format, ok := formatAliases[name]; ok {
+		return format, true
+	}
+	format := Format(name)
+	_, ok := formatExtensions[format]
+	return format, ok
+}
+
+// Extension 返回格式输出文件的扩展名，不含点
+func (f Format) Extension() string {
+	if ext, ok := formatExtensions[f]; ok {
+		return ext
+	}
+	return string(f)
+}
+
+// FileNameFields 文件名模板中可用的字段
+type FileNameFields struct {
+	Author   string // 作者，为空时使用 all
+	Analysis string // 分析类型
+	Format   string // 输出格式
+	From, To time.Time
+}
+
+// FileName 按模板生成文件名，支持 {author}、{analysis}、{format}、{from} 和 {to}，
+// 字段中的空格和路径分隔符等字符会被替换为 -，扩展名需由调用方添加
+func FileName(pattern string, fields FileNameFields) string {
+	if pattern == "" {
+		pattern = DefaultFileNameTemplate
+	}
+	author := fields.Author
+	if author == "" {
+		author = "all"
+	}
+	replacer := strings.NewReplacer(
+		"{author}", fileNamePart(author),
+		"{analysis}", fileNamePart(fields.Analysis),
+		"{format}", fileNamePart(fields.Format),
+		"{from}", fields.From.Format("2006-01-02"),
+		"{to}", fields.To.Format("2006-01-02"),
+	)
+	return replacer.Replace(pattern)
+}
+
+// fileNamePart 将字段转换为适合文件名的形式：小写，只保留字母、数字、点和下划线，其他连续的字符替换为一个 -
+func fileNamePart(value string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(value)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_':
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.Trim(b.String(), "-.")
+}
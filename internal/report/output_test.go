@@ -0,0 +1,59 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseFormat 测试格式名称和简写的解析
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		want Format
+		ok   bool
+	}{
+		{"markdown", FormatMarkdown, true},
+		{" MD ", FormatMarkdown, true},
+		{"txt", FormatText, true},
+		{"tex", FormatLaTeX, true},
+		{"jsonresume", FormatJSONResume, true},
+		{"pdf", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseFormat(tt.name)
+		if ok != tt.ok || ok && got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, %v, 期望: %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if FormatJSONResume.Extension() != "resume.json" || FormatMarkdown.Extension() != "md" || Format("csv").Extension() != "csv" {
+		t.Error("扩展名不正确")
+	}
+}
+
+// TestFileName 测试文件名模板的替换和字段清理
+func TestFileName(t *testing.T) {
+	fields := FileNameFields{
+		Author:   "Alice Smith <alice@example.com>",
+		Analysis: "profile",
+		Format:   "markdown",
+		From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 6, 30, 23, 59, 59, 0, time.UTC),
+	}
+
+	if got := FileName("", fields); got != "alice-smith-alice-example.com-profile-2024-01-01-2024-06-30" {
+		t.Errorf("默认模板的文件名不正确: %s", got)
+	}
+	if got := FileName("{analysis}/{format}_{to}", fields); got != "profile/markdown_2024-06-30" {
+		t.Errorf("自定义模板的文件名不正确: %s", got)
+	}
+
+	fields.Author = "../张三"
+	if got := FileName("{author}", fields); got != "张三" {
+		t.Errorf("作者中的路径分隔符应被替换: %s", got)
+	}
+	fields.Author = ""
+	if got := FileName("{author}", fields); got != "all" {
+		t.Errorf("未指定作者时应为 all: %s", got)
+	}
+}
@@ -2,13 +2,19 @@ package report
 
 import (
 	"encoding/json"
-	"fmt"
 	"io"
 	"os"
 	"time"
 
+	"github.com/MyceliumGrid/git-work-profile/internal/cite"
+	"github.com/MyceliumGrid/git-work-profile/internal/cluster"
 	"github.com/MyceliumGrid/git-work-profile/internal/git"
 	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+	"github.com/MyceliumGrid/git-work-profile/internal/ownership"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+	"github.com/MyceliumGrid/git-work-profile/internal/resume"
+	"github.com/MyceliumGrid/git-work-profile/internal/tickets"
+	"github.com/MyceliumGrid/git-work-profile/internal/verify"
 )
 
 // Format 表示报告输出格式
@@ -21,12 +27,38 @@ const (
 	FormatMarkdown Format = "markdown"
 	// FormatJSON JSON格式
 	FormatJSON Format = "json"
+	// FormatHTML 自包含的HTML格式
+	FormatHTML Format = "html"
+	// FormatJSONResume JSON Resume 格式，由项目经验分析结果生成
+	FormatJSONResume Format = "jsonresume"
+	// FormatLaTeX moderncv 风格的 LaTeX 简历源文件
+	FormatLaTeX Format = "latex"
+	// FormatDOCX Word 简历文档
+	FormatDOCX Format = "docx"
 )
 
+// IsResume 判断是否为简历格式，简历格式默认使用项目经验分析
+func (f Format) IsResume() bool {
+	return f == FormatJSONResume || f == FormatLaTeX || f == FormatDOCX
+}
+
 // Generator 报告生成器
 type Generator struct {
-	Format Format
-	Output io.Writer // 输出目标，可以是文件或标准输出
+	Format         Format
+	Output         io.Writer            // 输出目标，可以是文件或标准输出
+	Template       string               // 自定义模板文件路径，为空时使用内置模板
+	Author         string               // 分析的作者，写入报告元数据
+	Version        string               // 工具版本，写入报告元数据
+	Verification   *verify.Result       // AI分析结果的核查结果，可为空
+	Citations      *cite.Result         // AI分析结果中引用的提交，可为空
+	Workstreams    []cluster.Workstream // 本地聚类得到的工作流，可为空
+	Tickets        []tickets.Ticket     // 按工单分组的提交，可为空
+	Knowledge      []ownership.Area     // 开发者参与过的代码区域及各作者的熟悉程度，可为空
+	Survival       *profile.Survival    // 开发者新增代码的存活和返工统计，可为空
+	ResumeBase     []byte               // 已有的 resume.json 内容，JSON Resume 格式会合并到其中，可为空
+	ResumeStyle    string               // LaTeX 和 DOCX 简历的样式，为空时使用 classic
+	Model          string               // 使用的AI模型，写入 JSON 报告
+	IncludeCommits bool                 // JSON 报告是否包含每个提交的详细数据
 }
 
 // NewGenerator 创建一个新的报告生成器
@@ -40,100 +72,49 @@ func NewGenerator(format Format, output io.Writer) *Generator {
 
 // GenerateProfileReport 生成分析报告（支持开发者画像、项目经验、技术栈等类型）
 func (g *Generator) GenerateProfileReport(analysis string, commits []git.CommitInfo, fromDate, toDate time.Time, analysisType string) error {
-	// 根据格式生成报告
-	switch g.Format {
-	case FormatMarkdown:
-		return g.generateMarkdownReport(analysis, commits, fromDate, toDate, analysisType)
-	case FormatJSON:
-		return g.generateJSONReport(analysis, commits, fromDate, toDate, analysisType)
-	case FormatText:
-		return g.generateTextReport(analysis, commits, fromDate, toDate, analysisType)
-	default: // 默认使用文本格式
-		return g.generateTextReport(analysis, commits, fromDate, toDate, analysisType)
-	}
-}
-
-// generateTextReport 生成纯文本格式的分析报告
-func (g *Generator) generateTextReport(analysis string, commits []git.CommitInfo, fromDate, toDate time.Time, analysisType string) error {
-	msg := i18n.T()
-	reportTitle := g.getAnalysisTitle(analysisType)
-
-	fmt.Fprintf(g.Output, "%s\n", reportTitle)
-	fmt.Fprintf(g.Output, msg.ReportTimeRange+": %s %s %s\n", fromDate.Format("2006-01-02"), msg.ReportTo, toDate.Format("2006-01-02"))
-	fmt.Fprintln(g.Output, "==================================")
-	fmt.Fprintln(g.Output)
-
-	// 统计信息
-	stats := g.calculateStats(commits)
-	fmt.Fprintf(g.Output, "## %s\n", msg.ReportDataStats)
-	fmt.Fprintf(g.Output, "- %s: %d\n", msg.ReportTotalCommits, stats["total_commits"])
-	fmt.Fprintf(g.Output, "- %s: %d %s\n", msg.ReportTotalRepos, stats["total_repos"], msg.ReportRepoUnit)
-	fmt.Fprintf(g.Output, "- %s: %d %s\n", msg.ReportTotalFiles, stats["total_files"], msg.ReportFileUnit)
-	fmt.Fprintln(g.Output)
+	data := g.BuildData(analysis, commits, fromDate, toDate, analysisType)
 
-	// AI分析结果
-	fmt.Fprintf(g.Output, "## %s\n", msg.ReportAIAnalysis)
-	fmt.Fprintln(g.Output, analysis)
-	fmt.Fprintln(g.Output)
-
-	return nil
-}
-
-// generateMarkdownReport 生成Markdown格式的分析报告
-func (g *Generator) generateMarkdownReport(analysis string, commits []git.CommitInfo, fromDate, toDate time.Time, analysisType string) error {
-	msg := i18n.T()
-	reportTitle := g.getAnalysisTitle(analysisType)
-
-	fmt.Fprintf(g.Output, "# %s\n\n", reportTitle)
-	fmt.Fprintf(g.Output, "**%s**: %s %s %s\n\n", msg.ReportTimeRange, fromDate.Format("2006-01-02"), msg.ReportTo, toDate.Format("2006-01-02"))
-	fmt.Fprintf(g.Output, "**%s**: %s\n\n", msg.ReportGeneratedAt, time.Now().Format("2006-01-02 15:04:05"))
-
-	// 统计信息
-	stats := g.calculateStats(commits)
-	fmt.Fprintf(g.Output, "## 📊 %s\n\n", msg.ReportDataStats)
-	fmt.Fprintf(g.Output, "- **%s**: %d\n", msg.ReportTotalCommits, stats["total_commits"])
-	fmt.Fprintf(g.Output, "- **%s**: %d %s\n", msg.ReportTotalRepos, stats["total_repos"], msg.ReportRepoUnit)
-	fmt.Fprintf(g.Output, "- **%s**: %d %s\n", msg.ReportTotalFiles, stats["total_files"], msg.ReportFileUnit)
-
-	// 文件类型分布
-	if fileTypes, ok := stats["file_types"].(map[string]int); ok && len(fileTypes) > 0 {
-		fmt.Fprintf(g.Output, "- **%s**:\n", msg.ReportFileTypeDistribution)
-		for ext, count := range fileTypes {
-			fmt.Fprintf(g.Output, "  - `%s`: %d %s\n", ext, count, msg.ReportFileUnit)
-		}
+	// JSON 格式默认直接编码，指定自定义模板时同样通过模板渲染
+	if g.Format == FormatJSON && g.Template == "" {
+		return g.generateJSONReport(data)
+	}
+	if g.Format == FormatJSONResume && g.Template == "" {
+		return g.generateJSONResume(data)
 	}
-	fmt.Fprintln(g.Output)
-
-	// AI分析结果
-	fmt.Fprintf(g.Output, "## 🤖 %s\n\n", msg.ReportAIAnalysis)
-	fmt.Fprintln(g.Output, analysis)
-	fmt.Fprintln(g.Output)
-
-	// 页脚
-	fmt.Fprintln(g.Output, "---")
-	fmt.Fprintf(g.Output, "*%s*\n", msg.ReportFooter)
 
-	return nil
+	tmpl, err := g.loadTemplate()
+	if err != nil {
+		return err
+	}
+	if g.Format == FormatDOCX {
+		return g.generateDOCX(tmpl, data)
+	}
+	return tmpl.Execute(g.Output, data)
 }
 
-// generateJSONReport 生成JSON格式的分析报告
-func (g *Generator) generateJSONReport(analysis string, commits []git.CommitInfo, fromDate, toDate time.Time, analysisType string) error {
-	stats := g.calculateStats(commits)
-
-	result := map[string]any{
-		"analysis_type": analysisType,
-		"time_range": map[string]string{
-			"from": fromDate.Format("2006-01-02"),
-			"to":   toDate.Format("2006-01-02"),
-		},
-		"statistics":   stats,
-		"ai_analysis":  analysis,
-		"generated_at": time.Now().Format(time.RFC3339),
+// generateJSONResume 将项目经验分析结果转换为 JSON Resume，并在校验后输出
+func (g *Generator) generateJSONResume(data Data) error {
+	doc, err := resume.Merge(g.ResumeBase, data.Resume)
+	if err != nil {
+		return err
+	}
+	if err := resume.Validate(doc); err != nil {
+		return err
 	}
 
 	encoder := json.NewEncoder(g.Output)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(result)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(doc)
+}
+
+// ticketStatus 返回工单状态的显示文本
+func (g *Generator) ticketStatus(ticket tickets.Ticket) string {
+	msg := i18n.T()
+	if ticket.Closed {
+		return msg.ReportTicketClosed
+	}
+	return msg.ReportTicketOpen
 }
 
 // getAnalysisTitle 根据分析类型获取标题
@@ -146,47 +127,23 @@ func (g *Generator) getAnalysisTitle(analysisType string) string {
 		return msg.ReportTitleExperience
 	case "techstack":
 		return msg.ReportTitleTechStack
+	case "weekly":
+		return msg.ReportTitleWeekly
+	case "daily":
+		return msg.ReportTitleDaily
+	case "review":
+		return msg.ReportTitleReview
+	case "match":
+		return msg.ReportTitleMatch
 	default:
 		return msg.ReportTitleDefault
 	}
 }
 
-// calculateStats 计算统计数据
-func (g *Generator) calculateStats(commits []git.CommitInfo) map[string]any {
-	repoSet := make(map[string]bool)
-	filesSet := make(map[string]bool)
-	fileTypes := make(map[string]int)
-
-	for _, commit := range commits {
-		if commit.RepoPath != "" {
-			repoSet[commit.RepoPath] = true
-		}
-		for _, file := range commit.ChangedFiles {
-			filesSet[file] = true
-			ext := getFileExtension(file)
-			if ext != "" {
-				fileTypes[ext]++
-			}
-		}
-	}
-
-	return map[string]any{
-		"total_commits": len(commits),
-		"total_repos":   len(repoSet),
-		"total_files":   len(filesSet),
-		"file_types":    fileTypes,
-	}
-}
-
-// getFileExtension 获取文件扩展名
-func getFileExtension(filename string) string {
-	for i := len(filename) - 1; i >= 0; i-- {
-		if filename[i] == '.' {
-			return filename[i:]
-		}
-		if filename[i] == '/' {
-			return ""
-		}
+// shortHash 返回提交哈希的前8位
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
 	}
-	return ""
+	return hash
 }
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/cite"
+	"github.com/MyceliumGrid/git-work-profile/internal/classify"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+	"github.com/MyceliumGrid/git-work-profile/internal/term"
+)
+
+// defaultTemplates 内置的报告模板，每种格式一个
+//
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// defaultTemplateFiles 各格式对应的内置模板文件
+var defaultTemplateFiles = map[Format]string{
+	FormatText:     "templates/report.txt.tmpl",
+	FormatMarkdown: "templates/report.md.tmpl",
+	FormatHTML:     "templates/report.html.tmpl",
+	FormatLaTeX:    "templates/resume.tex.tmpl",
+	FormatDOCX:     "templates/document.xml.tmpl",
+}
+
+// DefaultTemplate 返回指定格式的内置模板内容，可作为自定义模板的起点
+func DefaultTemplate(format Format) (string, error) {
+	name, ok := defaultTemplateFiles[format]
+	if !ok {
+		return "", fmt.Errorf("no built-in template for format %q", format)
+	}
+	content, err := defaultTemplates.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// executor 可执行的模板，统一 text/template 和 html/template 的接口
+type executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// parseTemplate 解析模板。HTML 格式或 .html/.htm 模板使用 html/template 以自动转义，其余使用 text/template
+func (g *Generator) parseTemplate(name, content string) (executor, error) {
+	ext := strings.ToLower(filepath.Ext(strings.TrimSuffix(name, ".tmpl")))
+	if g.Format == FormatHTML || ext == ".html" || ext == ".htm" {
+		funcs := g.templateFuncs()
+		funcs["markdown"] = func(s string) htmltemplate.HTML {
+			return htmltemplate.HTML(renderMarkdown(s)) //nolint:gosec // renderMarkdown 会转义所有文本
+		}
+		return htmltemplate.New(filepath.Base(name)).Funcs(funcs).Parse(content)
+	}
+
+	funcs := g.templateFuncs()
+	funcs["markdown"] = renderMarkdown
+	return texttemplate.New(filepath.Base(name)).Funcs(funcs).Parse(content)
+}
+
+// loadTemplate 加载自定义模板，未指定时使用当前格式的内置模板
+func (g *Generator) loadTemplate() (executor, error) {
+	if g.Template != "" {
+		content, err := os.ReadFile(g.Template)
+		if err != nil {
+			return nil, err
+		}
+		return g.parseTemplate(g.Template, string(content))
+	}
+
+	// 未知格式使用文本模板
+	format := g.Format
+	if _, ok := defaultTemplateFiles[format]; !ok {
+		format = FormatText
+	}
+	content, err := DefaultTemplate(format)
+	if err != nil {
+		return nil, err
+	}
+	return g.parseTemplate(defaultTemplateFiles[format], content)
+}
+
+// templateFuncs 模板中可用的函数
+func (g *Generator) templateFuncs() map[string]any {
+	return map[string]any{
+		"date":     func(t time.Time) string { return t.Format("2006-01-02") },
+		"datetime": func(t time.Time) string { return t.Format("2006-01-02 15:04:05") },
+		"short":    shortHash,
+		"base":     filepath.Base,
+		"ranked":   profile.Ranked,
+		"join":     strings.Join,
+		"upper":    strings.ToUpper,
+		"lower":    strings.ToLower,
+		"repeat":   strings.Repeat,
+		"add":      func(a, b int) int { return a + b },
+		"mod":      func(a, b int) int { return a % b },
+		"pct":      func(v float64) float64 { return math.Round(v*10) / 10 },
+		"percent":  func(v float64) string { return fmt.Sprintf("%.1f%%", v*100) },
+		"status":   g.ticketStatus,
+		"latex":    escapeLaTeX,
+		"xml":      escapeXML,
+		"period":   g.period,
+		"cite":     g.linkCitations,
+		"sparkline": func(bars []ChartBar) string {
+			values := make([]int, len(bars))
+			for i, bar := range bars {
+				values[i] = bar.Value
+			}
+			return term.Sparkline(values)
+		},
+		"intentShare": func(b classify.Breakdown, intent classify.Intent) float64 {
+			return b.Ratio(intent) * 100
+		},
+	}
+}
+
+// linkCitations 将分析结果中的引用转换为提交链接，没有引用信息时保持原样
+func (g *Generator) linkCitations(analysis string) string {
+	if g.Citations == nil {
+		return analysis
+	}
+	return cite.Link(analysis, g.Citations)
+}
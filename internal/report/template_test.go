@@ -0,0 +1,106 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/i18n"
+)
+
+// testCommits 模板测试使用的提交
+func testCommits() []git.CommitInfo {
+	return []git.CommitInfo{
+		{Hash: "abc1234567", Date: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), Message: "feat: add <api>", ChangedFiles: []string{"main.go"}, RepoPath: "/work/app"},
+		{Hash: "def7654321", Date: time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC), Message: "fix: crash", ChangedFiles: []string{"main.go", "web/app.ts"}, RepoPath: "/work/app"},
+	}
+}
+
+// TestCustomTextTemplate 测试使用自定义文本模板生成报告
+func TestCustomTextTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md.tmpl")
+	content := `{{.Meta.Author}} {{date .From}}~{{date .To}} commits={{.Stats.TotalCommits}} files={{.Stats.TotalFiles}}` +
+		` feature={{pct (intentShare .Stats.Intents "feature")}}{{range .Repos}} {{.Name}}:{{.Commits}}{{end}} {{.Analysis}}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	generator := NewGenerator(FormatMarkdown, &buf)
+	generator.Template = path
+	generator.Author = "alice"
+	from, to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	if err := generator.GenerateProfileReport("<b>ok</b>", testCommits(), from, to, "profile"); err != nil {
+		t.Fatalf("生成报告失败: %v", err)
+	}
+
+	want := "alice 2024-03-01~2024-03-31 commits=2 files=2 feature=50 app:2 <b>ok</b>"
+	if buf.String() != want {
+		t.Errorf("期望: %q, 得到: %q", want, buf.String())
+	}
+}
+
+// TestCustomHTMLTemplate 测试 .html 模板会自动转义
+func TestCustomHTMLTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.html")
+	content := `<ul>{{range .Commits}}<li title="{{.Message}}">{{.Message}}</li>{{end}}</ul>{{markdown .Analysis}}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	generator := NewGenerator(FormatText, &buf)
+	generator.Template = path
+	if err := generator.GenerateProfileReport("**粗体** <script>", testCommits(), time.Now(), time.Now(), "profile"); err != nil {
+		t.Fatalf("生成报告失败: %v", err)
+	}
+
+	html := buf.String()
+	for _, want := range []string{"<li title=\"feat: add &lt;api&gt;\">feat: add &lt;api&gt;</li>", "<strong>粗体</strong> &lt;script&gt;"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("输出应包含 %q, 得到:\n%s", want, html)
+		}
+	}
+}
+
+// TestDefaultTemplate 测试内置模板
+func TestDefaultTemplate(t *testing.T) {
+	for _, format := range []Format{FormatText, FormatMarkdown, FormatHTML} {
+		content, err := DefaultTemplate(format)
+		if err != nil || !strings.Contains(content, "{{") {
+			t.Errorf("%s 应有内置模板, 错误: %v", format, err)
+		}
+	}
+	if _, err := DefaultTemplate(FormatJSON); err == nil {
+		t.Error("JSON 格式不应有内置模板")
+	}
+}
+
+// TestSurvivalSection 测试内置模板中的代码存活统计
+func TestSurvivalSection(t *testing.T) {
+	want := map[Format][]string{
+		FormatMarkdown: {"- **Survival Rate**: 75.0% (30 / 40)", "- **Churn Rate** (rewritten within 21 days): 25.0% (5 / 20)", "| app | 40 | 30 | 75.0% | 25.0% |", "| TypeScript | 10 | 5 | 50.0% | - |"},
+		FormatText:     {"- Survival Rate: 75.0% (30 / 40)", "  - app: 75.0% (30 / 40), Churn Rate 25.0%", "  - TypeScript: 50.0% (5 / 10)\n"},
+		FormatHTML:     {"<td>app</td><td>40</td><td>30</td><td>75.0%</td><td>25.0%</td>"},
+	}
+
+	defer i18n.SetLanguage(i18n.GetLanguage())
+	i18n.SetLanguage(i18n.English)
+	for format, expected := range want {
+		var buf bytes.Buffer
+		generator := NewGenerator(format, &buf)
+		generator.Survival = testSurvival()
+		if err := generator.GenerateProfileReport("# 总结", testCommits(), time.Now().AddDate(0, -1, 0), time.Now(), "profile"); err != nil {
+			t.Fatalf("生成 %s 报告失败: %v", format, err)
+		}
+		for _, w := range expected {
+			if !strings.Contains(buf.String(), w) {
+				t.Errorf("%s 报告应包含 %q, 得到:\n%s", format, w, buf.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package resume
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// listFields 合并时取并集的字符串列表字段
+var listFields = map[string]bool{"highlights": true, "keywords": true, "roles": true, "courses": true}
+
+// Merge 将生成的内容合并到已有的 resume.json 中。
+// 已有文档中的其他字段和条目原样保留；同名的项目和技能合并列表字段，
+// 与分析时间范围重叠的工作经历追加亮点，其余生成的条目追加到末尾。
+// base 为空时直接返回生成的文档。
+func Merge(base []byte, generated *Resume) (map[string]any, error) {
+	gen, err := toMap(generated)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(base)) == 0 {
+		return gen, nil
+	}
+
+	var doc map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(base))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid resume.json: %w", err)
+	}
+	if doc == nil {
+		return gen, nil
+	}
+
+	if _, ok := doc["$schema"]; !ok {
+		doc["$schema"] = gen["$schema"]
+	}
+	if basics, ok := gen["basics"].(map[string]any); ok {
+		existing, _ := doc["basics"].(map[string]any)
+		if existing == nil {
+			existing = make(map[string]any)
+		}
+		doc["basics"] = mergeEntry(existing, basics)
+	}
+
+	doc["projects"] = mergeByName(entries(doc["projects"]), entries(gen["projects"]), "name")
+	doc["skills"] = mergeByName(entries(doc["skills"]), entries(gen["skills"]), "name")
+	doc["work"] = mergeWork(entries(doc["work"]), entries(gen["work"]))
+	for _, key := range []string{"projects", "skills", "work"} {
+		if list, _ := doc[key].([]any); len(list) == 0 {
+			delete(doc, key)
+		}
+	}
+
+	if meta, ok := gen["meta"].(map[string]any); ok {
+		existing, _ := doc["meta"].(map[string]any)
+		if existing == nil {
+			existing = make(map[string]any)
+		}
+		// 最后修改时间总是更新
+		existing["lastModified"] = meta["lastModified"]
+		doc["meta"] = mergeEntry(existing, meta)
+	}
+
+	return doc, nil
+}
+
+// mergeByName 按名称合并条目，名称比较不区分大小写
+func mergeByName(existing, generated []map[string]any, key string) []any {
+	result := make([]any, 0, len(existing)+len(generated))
+	index := make(map[string]map[string]any)
+	for _, entry := range existing {
+		if name, ok := entry[key].(string); ok {
+			index[normalizeName(name)] = entry
+		}
+		result = append(result, entry)
+	}
+
+	for _, entry := range generated {
+		name, _ := entry[key].(string)
+		if match, ok := index[normalizeName(name)]; ok && name != "" {
+			mergeEntry(match, entry)
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// mergeWork 将生成的工作经历合并到时间范围重叠的已有经历中，重叠多条时选择开始时间最晚的
+func mergeWork(existing, generated []map[string]any) []any {
+	result := make([]any, 0, len(existing)+len(generated))
+	for _, entry := range existing {
+		result = append(result, entry)
+	}
+
+	for _, entry := range generated {
+		start, _ := entry["startDate"].(string)
+		end, _ := entry["endDate"].(string)
+
+		var match map[string]any
+		for _, candidate := range existing {
+			candidateStart, _ := candidate["startDate"].(string)
+			candidateEnd, _ := candidate["endDate"].(string)
+			// 结束时间为空表示至今
+			overlaps := candidateStart <= end && (candidateEnd == "" || candidateEnd >= start)
+			if !overlaps {
+				continue
+			}
+			if matchStart, _ := match["startDate"].(string); match == nil || candidateStart > matchStart {
+				match = candidate
+			}
+		}
+
+		if match == nil {
+			result = append(result, entry)
+			continue
+		}
+		match["highlights"] = unionList(match["highlights"], entry["highlights"])
+	}
+	return result
+}
+
+// mergeEntry 合并单个条目：已有的值优先，列表字段取并集，起止时间取更大的范围
+func mergeEntry(existing, generated map[string]any) map[string]any {
+	for key, value := range generated {
+		current, ok := existing[key]
+		switch {
+		case !ok || isEmpty(current):
+			existing[key] = value
+		case listFields[key]:
+			existing[key] = unionList(current, value)
+		case key == "startDate":
+			if s, ok := value.(string); ok && s < fmt.Sprint(current) {
+				existing[key] = s
+			}
+		case key == "endDate":
+			if s, ok := value.(string); ok && s > fmt.Sprint(current) {
+				existing[key] = s
+			}
+		}
+	}
+	return existing
+}
+
+// unionList 合并两个字符串列表，保持原有顺序并去重
+func unionList(current, added any) []any {
+	var result []any
+	seen := make(map[string]bool)
+	for _, list := range []any{current, added} {
+		items, _ := list.([]any)
+		for _, item := range items {
+			key := strings.ToLower(strings.TrimSpace(fmt.Sprint(item)))
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// entries 将 JSON 数组转换为对象列表，忽略非对象的元素
+func entries(value any) []map[string]any {
+	list, _ := value.([]any)
+	result := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		if entry, ok := item.(map[string]any); ok {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// toMap 将结构体转换为通用的 JSON 对象
+func toMap(resume *Resume) (map[string]any, error) {
+	data, err := json.Marshal(resume)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// isEmpty 判断 JSON 值是否为空
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// normalizeName 规范化名称用于比较
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
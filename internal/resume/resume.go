@@ -0,0 +1,473 @@
+// Package resume 将项目经验分析结果转换为 JSON Resume（https://jsonresume.org/schema）格式
+package resume
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/cluster"
+)
+
+// SchemaURL JSON Resume 的 schema 地址
+const SchemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// dateLayout JSON Resume 使用的日期格式
+const dateLayout = "2006-01-02"
+
+// Resume JSON Resume 文档中本工具生成的部分
+type Resume struct {
+	Schema   string    `json:"$schema,omitempty"`
+	Basics   *Basics   `json:"basics,omitempty"`
+	Work     []Work    `json:"work,omitempty"`
+	Projects []Project `json:"projects,omitempty"`
+	Skills   []Skill   `json:"skills,omitempty"`
+	Meta     *Meta     `json:"meta,omitempty"`
+}
+
+// Basics 基本信息
+type Basics struct {
+	Name string `json:"name,omitempty"`
+}
+
+// Work 工作经历
+type Work struct {
+	Name       string   `json:"name,omitempty"`
+	Position   string   `json:"position,omitempty"`
+	StartDate  string   `json:"startDate,omitempty"`
+	EndDate    string   `json:"endDate,omitempty"`
+	Summary    string   `json:"summary,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+}
+
+// Project 项目经历
+type Project struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Highlights  []string `json:"highlights,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+}
+
+// Skill 技能
+type Skill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// Meta 文档元数据
+type Meta struct {
+	Version      string `json:"version,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// Options 生成 JSON Resume 时使用的补充信息
+type Options struct {
+	Author           string               // 写入 basics.name，为空则不写
+	From, To         time.Time            // 分析的时间范围，用于工作经历
+	Workstreams      []cluster.Workstream // 本地聚类的工作流，用于确定项目时间
+	Languages        map[string]int       // 本地统计的编程语言，分析结果中没有技能时使用
+	UnverifiedMarker string               // 核查时插入的无依据标记，带标记的条目不会写入简历
+	Now              time.Time            // 写入 meta.lastModified 的时间
+}
+
+// 分析结果中的字段名，同时支持中英文
+var (
+	descriptionLabels = []string{"项目描述", "描述", "description", "project description", "summary"}
+	keywordLabels     = []string{"技术栈", "technologies", "tech stack", "technology stack", "stack"}
+	roleLabels        = []string{"角色", "role", "roles"}
+	highlightLabels   = []string{"主要职责", "职责", "项目成果", "成果", "responsibilities", "key responsibilities", "achievements", "results", "outcomes"}
+	periodLabels      = []string{"时间", "项目时间", "period", "duration", "dates"}
+)
+
+var (
+	// boldFieldPattern 匹配 "**字段**: 内容" 形式的行，冒号可以在加粗内外
+	boldFieldPattern = regexp.MustCompile(`^\*\*([^*]+?)\*\*\s*[:：]?\s*(.*)$`)
+	// projectHeadingPattern 匹配 "项目1: 名称" 或 "Project 1: Name" 形式的标题前缀
+	projectHeadingPattern = regexp.MustCompile(`(?i)^(?:项目|project)\s*\d*\s*[:：.、-]\s*`)
+	// monthPattern 匹配 YYYY-MM 或 YYYY-MM-DD 形式的日期
+	monthPattern = regexp.MustCompile(`\b(\d{4})[-/.](\d{1,2})(?:[-/.](\d{1,2}))?\b`)
+	// tokenPattern 用于匹配项目和工作流的词
+	tokenPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+)
+
+// section 分析结果中的二级标题段落
+type section int
+
+const (
+	sectionOther section = iota
+	sectionProjects
+	sectionSkills
+)
+
+// FromAnalysis 从项目经验分析结果中提取项目、技能和工作经历
+func FromAnalysis(analysis string, opts Options) *Resume {
+	resume := &Resume{Schema: SchemaURL}
+	if opts.Author != "" {
+		resume.Basics = &Basics{Name: opts.Author}
+	}
+
+	var (
+		current      section
+		project      *Project
+		skill        *Skill
+		listTarget   *[]string // 当前列表项写入的位置
+		hasHeadings  bool      // 分析结果是否有可识别的项目段落
+		projectLines []*Project
+	)
+
+	lines := strings.Split(analysis, "\n")
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "## ") {
+			title := strings.ToLower(trimmed[3:])
+			switch {
+			case containsAny(title, "项目经验", "project experience", "projects"):
+				current, hasHeadings = sectionProjects, true
+			case containsAny(title, "技能", "skills"):
+				current = sectionSkills
+			default:
+				current = sectionOther
+			}
+			project, skill, listTarget = nil, nil, nil
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "### ") {
+			heading := strings.TrimSpace(trimmed[4:])
+			isProject := projectHeadingPattern.MatchString(cleanInline(heading))
+			if current == sectionProjects || (!hasHeadings && isProject) {
+				name := cleanInline(projectHeadingPattern.ReplaceAllString(cleanInline(heading), ""))
+				if name == "" || opts.unverified(name) {
+					project = nil
+				} else {
+					project = &Project{Name: opts.clean(name)}
+					projectLines = append(projectLines, project)
+				}
+			} else {
+				project = nil
+			}
+			skill, listTarget = nil, nil
+			continue
+		}
+
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+
+		switch {
+		case project != nil:
+			listTarget = parseProjectLine(project, trimmed, listTarget, opts)
+		case current == sectionSkills:
+			skill = parseSkillLine(resume, skill, trimmed, opts)
+		}
+	}
+
+	for _, p := range projectLines {
+		resume.Projects = append(resume.Projects, *p)
+	}
+	assignDates(resume.Projects, opts.Workstreams)
+
+	if len(resume.Skills) == 0 && len(opts.Languages) > 0 {
+		resume.Skills = append(resume.Skills, Skill{Name: "Languages", Keywords: topLanguages(opts.Languages)})
+	}
+
+	if !opts.From.IsZero() && !opts.To.IsZero() && len(resume.Projects) > 0 {
+		work := Work{StartDate: opts.From.Format(dateLayout), EndDate: opts.To.Format(dateLayout)}
+		for _, p := range resume.Projects {
+			highlight := p.Name
+			if p.Description != "" {
+				highlight += ": " + p.Description
+			}
+			work.Highlights = append(work.Highlights, highlight)
+		}
+		resume.Work = append(resume.Work, work)
+	}
+
+	if !opts.Now.IsZero() {
+		resume.Meta = &Meta{Version: "v1.0.0", LastModified: opts.Now.Format("2006-01-02T15:04:05")}
+	}
+	return resume
+}
+
+// parseProjectLine 解析项目段落中的一行，返回后续列表项应写入的位置
+func parseProjectLine(project *Project, line string, listTarget *[]string, opts Options) *[]string {
+	if item, ok := listItem(line); ok {
+		if listTarget != nil && item != "" && !opts.unverified(item) {
+			*listTarget = append(*listTarget, opts.clean(item))
+		}
+		return listTarget
+	}
+
+	label, value, ok := boldField(line)
+	if !ok {
+		// 字段后的续行补充到描述中
+		if listTarget == nil && project.Description != "" {
+			project.Description += " " + opts.clean(cleanInline(line))
+		}
+		return nil
+	}
+
+	switch {
+	case matchesLabel(label, descriptionLabels):
+		project.Description = opts.clean(value)
+		return nil
+	case matchesLabel(label, keywordLabels):
+		project.Keywords = appendUnique(project.Keywords, opts.keywords(value)...)
+		return nil
+	case matchesLabel(label, roleLabels):
+		project.Roles = appendUnique(project.Roles, opts.keywords(value)...)
+		return nil
+	case matchesLabel(label, periodLabels):
+		project.StartDate, project.EndDate = parsePeriod(value)
+		return nil
+	case matchesLabel(label, highlightLabels):
+		if value != "" && !opts.unverified(value) {
+			project.Highlights = append(project.Highlights, opts.clean(value))
+		}
+		return &project.Highlights
+	}
+	return nil
+}
+
+// parseSkillLine 解析技能段落中的一行
+func parseSkillLine(resume *Resume, skill *Skill, line string, opts Options) *Skill {
+	if item, ok := listItem(line); ok {
+		if skill != nil && item != "" && !opts.unverified(item) {
+			// 列表项中 " - " 之后通常是补充说明
+			if name, _, found := strings.Cut(item, " - "); found {
+				item = name
+			}
+			skill.Keywords = appendUnique(skill.Keywords, opts.clean(item))
+		}
+		return skill
+	}
+
+	label, value, ok := boldField(line)
+	if !ok {
+		return skill
+	}
+	resume.Skills = append(resume.Skills, Skill{Name: opts.clean(label), Keywords: opts.keywords(value)})
+	return &resume.Skills[len(resume.Skills)-1]
+}
+
+// assignDates 根据本地聚类的工作流确定项目的起止时间
+func assignDates(projects []Project, workstreams []cluster.Workstream) {
+	if len(workstreams) == 0 {
+		return
+	}
+
+	used := make(map[int]bool)
+	for i := range projects {
+		best, bestScore := -1, 0
+		words := tokens(projects[i].Name + " " + strings.Join(projects[i].Keywords, " ") + " " + projects[i].Description)
+		for j, ws := range workstreams {
+			if used[j] {
+				continue
+			}
+			score := 0
+			for word := range tokens(ws.Label + " " + strings.Join(ws.Keywords, " ")) {
+				if words[word] {
+					score++
+				}
+			}
+			if score > bestScore {
+				best, bestScore = j, score
+			}
+		}
+		// 没有匹配的词时，项目数与工作流数相同则按顺序对应（提示词要求按工作流划分项目）
+		if best < 0 && len(projects) == len(workstreams) && !used[i] {
+			best = i
+		}
+		if best < 0 {
+			continue
+		}
+		used[best] = true
+		projects[i].StartDate = workstreams[best].From.Format(dateLayout)
+		projects[i].EndDate = workstreams[best].To.Format(dateLayout)
+	}
+}
+
+// parsePeriod 从 "2024-03 ~ 2024-06" 这样的文本中解析起止日期
+func parsePeriod(value string) (string, string) {
+	matches := monthPattern.FindAllStringSubmatch(value, 2)
+	var dates []string
+	for _, m := range matches {
+		date := m[1] + "-" + pad2(m[2])
+		if m[3] != "" {
+			date += "-" + pad2(m[3])
+		}
+		dates = append(dates, date)
+	}
+	switch len(dates) {
+	case 0:
+		return "", ""
+	case 1:
+		return dates[0], ""
+	default:
+		return dates[0], dates[1]
+	}
+}
+
+// topLanguages 按变更次数返回最多8种语言
+func topLanguages(languages map[string]int) []string {
+	names := make([]string, 0, len(languages))
+	for name := range languages {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if languages[names[i]] != languages[names[j]] {
+			return languages[names[i]] > languages[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > 8 {
+		names = names[:8]
+	}
+	return names
+}
+
+// unverified 判断文本是否包含核查时标记的无依据声明
+func (opts Options) unverified(text string) bool {
+	return opts.UnverifiedMarker != "" && strings.Contains(text, opts.UnverifiedMarker)
+}
+
+// clean 清理行内 Markdown 标记和描述中的无依据标记
+func (opts Options) clean(text string) string {
+	if opts.UnverifiedMarker != "" {
+		text = strings.ReplaceAll(text, " "+opts.UnverifiedMarker, "")
+	}
+	return cleanInline(text)
+}
+
+// keywords 将逗号、顿号等分隔的列表拆分为关键词，括号内的分隔符不拆分
+func (opts Options) keywords(value string) []string {
+	var (
+		result []string
+		depth  int
+		start  int
+	)
+	runes := []rune(value)
+	flush := func(end int) {
+		word := cleanInline(string(runes[start:end]))
+		if word != "" && !opts.unverified(word) {
+			result = appendUnique(result, word)
+		}
+	}
+	for i, r := range runes {
+		switch r {
+		case '(', '（', '[':
+			depth++
+		case ')', '）', ']':
+			depth = max(depth-1, 0)
+		case ',', '，', '、', ';', '；':
+			if depth == 0 {
+				flush(i)
+				start = i + 1
+			}
+		}
+	}
+	flush(len(runes))
+	return result
+}
+
+// boldField 解析 "**字段**: 内容" 形式的行
+func boldField(line string) (string, string, bool) {
+	m := boldFieldPattern.FindStringSubmatch(line)
+	if m == nil {
+		return "", "", false
+	}
+	label := strings.TrimRight(strings.TrimSpace(m[1]), ":：")
+	return label, strings.TrimSpace(m[2]), true
+}
+
+// listItem 解析无序或有序列表项
+func listItem(line string) (string, bool) {
+	for _, prefix := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(line[len(prefix):]), true
+		}
+	}
+	if i := strings.IndexAny(line, ".)"); i > 0 && i < 4 && strings.Trim(line[:i], "0123456789") == "" && strings.HasPrefix(line[i+1:], " ") {
+		return strings.TrimSpace(line[i+1:]), true
+	}
+	return "", false
+}
+
+// cleanInline 去掉加粗、代码等行内标记和首尾空白
+func cleanInline(text string) string {
+	text = strings.NewReplacer("**", "", "__", "", "`", "").Replace(text)
+	text = strings.TrimSpace(text)
+	return strings.Trim(text, "[]")
+}
+
+// matchesLabel 判断字段名是否属于某一类
+func matchesLabel(label string, labels []string) bool {
+	label = strings.ToLower(strings.TrimSpace(label))
+	for _, l := range labels {
+		if label == l {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny 判断文本是否包含任意一个子串
+func containsAny(text string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(text, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokens 将文本拆分为小写的词集合，忽略过短的英文词
+func tokens(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range tokenPattern.FindAllString(strings.ToLower(text), -1) {
+		if len(word) >= 3 || !isASCII(word) {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// isASCII 判断字符串是否只包含ASCII字符
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// appendUnique 追加不重复的字符串（不区分大小写）
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		duplicate := false
+		for _, existing := range list {
+			if strings.EqualFold(existing, item) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// pad2 将一位数补齐为两位
+func pad2(s string) string {
+	if len(s) == 1 {
+		return "0" + s
+	}
+	return s
+}
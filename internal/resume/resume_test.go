@@ -0,0 +1,203 @@
+package resume
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/cluster"
+)
+
+const sampleAnalysis = `## 项目经验总结
+
+### 项目1: 支付网关重构
+**项目描述**: 重构支付网关，统一多渠道接入。
+
+**技术栈**: Go, gRPC, Redis（缓存、分布式锁）、Kafka
+
+**角色**: 核心开发者
+
+**主要职责**:
+- 负责 **支付路由** 模块的设计与实现
+- 实现幂等性校验 ⚠️[未核实]
+
+**项目成果**:
+- 接口延迟降低 40%
+
+---
+
+### 项目2: 运营后台
+**项目描述**: 面向运营的 React 管理后台
+
+**技术栈**: TypeScript, React
+
+**主要职责**:
+1. 开发报表页面
+
+## 核心技能总结
+
+**编程语言**: Go、TypeScript
+
+**专业技能**:
+- 分布式系统设计 - 熟悉一致性与幂等
+- 前端工程化
+
+## 项目亮点（面试话术）
+
+**亮点1**: 支付网关 - 幂等
+- **情境(Situation)**: 不应被解析
+`
+
+// TestFromAnalysis 测试从项目经验分析中提取项目和技能
+func TestFromAnalysis(t *testing.T) {
+	day := func(m, d int) time.Time { return time.Date(2024, time.Month(m), d, 0, 0, 0, 0, time.UTC) }
+	opts := Options{
+		Author: "alice",
+		From:   day(1, 1),
+		To:     day(6, 30),
+		Workstreams: []cluster.Workstream{
+			{Label: "admin dashboard", Keywords: []string{"react", "typescript"}, From: day(4, 1), To: day(6, 1)},
+			{Label: "payment gateway", Keywords: []string{"grpc", "redis"}, From: day(1, 5), To: day(3, 20)},
+		},
+		UnverifiedMarker: "⚠️[未核实]",
+		Now:              day(7, 1),
+	}
+
+	r := FromAnalysis(sampleAnalysis, opts)
+	if len(r.Projects) != 2 {
+		t.Fatalf("应提取2个项目, 得到: %+v", r.Projects)
+	}
+
+	payment := r.Projects[0]
+	if payment.Name != "支付网关重构" || payment.Description != "重构支付网关，统一多渠道接入。" {
+		t.Errorf("项目名称或描述不正确: %+v", payment)
+	}
+	if strings.Join(payment.Keywords, "|") != "Go|gRPC|Redis（缓存、分布式锁）|Kafka" {
+		t.Errorf("技术栈拆分不正确: %q", payment.Keywords)
+	}
+	if strings.Join(payment.Highlights, "|") != "负责 支付路由 模块的设计与实现|接口延迟降低 40%" {
+		t.Errorf("亮点不正确（无依据的条目应被去掉）: %q", payment.Highlights)
+	}
+	if len(payment.Roles) != 1 || payment.Roles[0] != "核心开发者" {
+		t.Errorf("角色不正确: %q", payment.Roles)
+	}
+	if payment.StartDate != "2024-01-05" || payment.EndDate != "2024-03-20" {
+		t.Errorf("项目时间应来自匹配的工作流, 得到: %s ~ %s", payment.StartDate, payment.EndDate)
+	}
+	if r.Projects[1].StartDate != "2024-04-01" || len(r.Projects[1].Highlights) != 1 {
+		t.Errorf("第二个项目不正确: %+v", r.Projects[1])
+	}
+
+	if len(r.Skills) != 2 || strings.Join(r.Skills[1].Keywords, "|") != "分布式系统设计|前端工程化" {
+		t.Errorf("技能不正确: %+v", r.Skills)
+	}
+	if len(r.Work) != 1 || r.Work[0].StartDate != "2024-01-01" || len(r.Work[0].Highlights) != 2 {
+		t.Errorf("工作经历不正确: %+v", r.Work)
+	}
+	if r.Basics == nil || r.Basics.Name != "alice" || r.Meta.LastModified != "2024-07-01T00:00:00" {
+		t.Errorf("基本信息或元数据不正确: %+v %+v", r.Basics, r.Meta)
+	}
+
+	doc, err := Merge(nil, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(doc); err != nil {
+		t.Errorf("生成的文档应通过校验: %v", err)
+	}
+}
+
+// TestFromAnalysisFallback 测试没有技能段落时使用本地统计的语言
+func TestFromAnalysisFallback(t *testing.T) {
+	r := FromAnalysis("### Project 1: CLI tool\n**Description**: A tool\n**Period**: 2023-2 ~ 2023-05-10\n", Options{Languages: map[string]int{"Go": 5, "Shell": 1}})
+	if len(r.Projects) != 1 || r.Projects[0].Name != "CLI tool" {
+		t.Fatalf("应识别英文项目标题, 得到: %+v", r.Projects)
+	}
+	if r.Projects[0].StartDate != "2023-02" || r.Projects[0].EndDate != "2023-05-10" {
+		t.Errorf("时间解析不正确: %+v", r.Projects[0])
+	}
+	if len(r.Skills) != 1 || strings.Join(r.Skills[0].Keywords, ",") != "Go,Shell" {
+		t.Errorf("应使用本地统计的语言作为技能, 得到: %+v", r.Skills)
+	}
+}
+
+// TestMerge 测试合并到已有的 resume.json
+func TestMerge(t *testing.T) {
+	base := `{
+  "basics": {"name": "Alice Zhang", "email": "alice@example.com"},
+  "work": [
+    {"name": "Acme", "position": "Engineer", "startDate": "2022-03", "highlights": ["Led migration"]},
+    {"name": "Old Co", "startDate": "2018-01", "endDate": "2021-12"}
+  ],
+  "projects": [{"name": "Payment Gateway", "url": "https://example.com", "keywords": ["Go"], "startDate": "2024-02-01"}],
+  "education": [{"institution": "Uni", "score": "4.0"}]
+}`
+	generated := &Resume{
+		Schema:   SchemaURL,
+		Basics:   &Basics{Name: "alice"},
+		Work:     []Work{{StartDate: "2024-01-01", EndDate: "2024-06-30", Highlights: []string{"Payment Gateway: rewrite", "Led migration"}}},
+		Projects: []Project{{Name: "payment gateway", Keywords: []string{"go", "Redis"}, StartDate: "2024-01-05", EndDate: "2024-03-20"}, {Name: "Admin"}},
+		Meta:     &Meta{Version: "v1.0.0", LastModified: "2024-07-01T00:00:00"},
+	}
+
+	doc, err := Merge([]byte(base), generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(doc); err != nil {
+		t.Fatalf("合并后的文档应通过校验: %v", err)
+	}
+
+	data, _ := json.Marshal(doc)
+	got := string(data)
+	for _, want := range []string{
+		`"name":"Alice Zhang"`,
+		`"institution":"Uni"`,
+		`"highlights":["Led migration","Payment Gateway: rewrite"]`,
+		`"keywords":["Go","Redis"]`,
+		`"startDate":"2024-01-05"`,
+		`"url":"https://example.com"`,
+		`{"name":"Admin"}`,
+		`"$schema":"` + SchemaURL + `"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("合并结果应包含 %s, 得到:\n%s", want, got)
+		}
+	}
+	if work := doc["work"].([]any); len(work) != 2 {
+		t.Errorf("重叠的工作经历应合并而不是追加, 得到: %v", work)
+	}
+
+	if _, err := Merge([]byte("[1, 2]"), generated); err == nil {
+		t.Error("非对象的 resume.json 应返回错误")
+	}
+}
+
+// TestValidate 测试 schema 校验
+func TestValidate(t *testing.T) {
+	doc := map[string]any{
+		"basics":   map[string]any{"email": "not-an-email", "url": "example.com"},
+		"projects": []any{map[string]any{"name": "x", "startDate": "2024/01", "keywords": []any{"Go", 1}, "custom": true}},
+		"skills":   "Go",
+		"hobbies":  []any{},
+	}
+
+	err := Validate(doc)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("应返回 ValidationError, 得到: %v", err)
+	}
+	want := []string{
+		`basics.email: "not-an-email" is not an email address`,
+		`basics.url: "example.com" is not a URI`,
+		`hobbies: unknown property`,
+		`projects[0].keywords[1]: must be a string`,
+		`projects[0].startDate: "2024/01" is not a date (YYYY, YYYY-MM or YYYY-MM-DD)`,
+		`skills: must be an array`,
+	}
+	if strings.Join(validationErr.Problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("校验问题不正确:\n%s", strings.Join(validationErr.Problems, "\n"))
+	}
+}
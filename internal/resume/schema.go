@@ -0,0 +1,187 @@
+package resume
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// fieldKind 字段在 JSON Resume schema 中的类型
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindDate
+	kindURI
+	kindEmail
+	kindStrings
+	kindLocation
+	kindProfiles
+)
+
+// datePattern JSON Resume schema 中 iso8601 日期的格式
+var datePattern = regexp.MustCompile(`^([1-2][0-9]{3}-[0-1][0-9]-[0-3][0-9]|[1-2][0-9]{3}-[0-1][0-9]|[1-2][0-9]{3})$`)
+
+// 各部分的字段定义，对应 JSON Resume schema v1.0.0
+var (
+	basicsFields = map[string]fieldKind{
+		"name": kindString, "label": kindString, "image": kindString, "email": kindEmail, "phone": kindString,
+		"url": kindURI, "summary": kindString, "location": kindLocation, "profiles": kindProfiles,
+	}
+	locationFields = map[string]fieldKind{
+		"address": kindString, "postalCode": kindString, "city": kindString, "countryCode": kindString, "region": kindString,
+	}
+	profileFields = map[string]fieldKind{"network": kindString, "username": kindString, "url": kindURI}
+	metaFields    = map[string]fieldKind{"canonical": kindURI, "version": kindString, "lastModified": kindString}
+
+	sectionFields = map[string]map[string]fieldKind{
+		"work": {
+			"name": kindString, "location": kindString, "description": kindString, "position": kindString, "url": kindURI,
+			"startDate": kindDate, "endDate": kindDate, "summary": kindString, "highlights": kindStrings,
+		},
+		"volunteer": {
+			"organization": kindString, "position": kindString, "url": kindURI, "startDate": kindDate, "endDate": kindDate,
+			"summary": kindString, "highlights": kindStrings,
+		},
+		"education": {
+			"institution": kindString, "url": kindURI, "area": kindString, "studyType": kindString, "startDate": kindDate,
+			"endDate": kindDate, "score": kindString, "courses": kindStrings,
+		},
+		"awards":       {"title": kindString, "date": kindDate, "awarder": kindString, "summary": kindString},
+		"certificates": {"name": kindString, "date": kindDate, "url": kindURI, "issuer": kindString},
+		"publications": {"name": kindString, "publisher": kindString, "releaseDate": kindDate, "url": kindURI, "summary": kindString},
+		"skills":       {"name": kindString, "level": kindString, "keywords": kindStrings},
+		"languages":    {"language": kindString, "fluency": kindString},
+		"interests":    {"name": kindString, "keywords": kindStrings},
+		"references":   {"name": kindString, "reference": kindString},
+		"projects": {
+			"name": kindString, "description": kindString, "highlights": kindStrings, "keywords": kindStrings,
+			"startDate": kindDate, "endDate": kindDate, "url": kindURI, "roles": kindStrings, "entity": kindString, "type": kindString,
+		},
+	}
+)
+
+// ValidationError 文档不符合 JSON Resume schema
+type ValidationError struct {
+	Problems []string
+}
+
+// Error 实现 error 接口
+func (e *ValidationError) Error() string {
+	return "resume does not match the JSON Resume schema: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate 按 JSON Resume schema v1.0.0 校验文档。
+// 顶层不允许未知字段，各部分条目中的未知字段允许存在（与 schema 一致）。
+func Validate(doc map[string]any) error {
+	v := &validator{}
+	for key, value := range doc {
+		switch key {
+		case "$schema":
+			v.check(key, value, kindURI)
+		case "basics":
+			v.object(key, value, basicsFields)
+		case "meta":
+			v.object(key, value, metaFields)
+		default:
+			fields, ok := sectionFields[key]
+			if !ok {
+				v.add(key, "unknown property")
+				continue
+			}
+			list, ok := value.([]any)
+			if !ok {
+				v.add(key, "must be an array")
+				continue
+			}
+			for i, item := range list {
+				v.object(fmt.Sprintf("%s[%d]", key, i), item, fields)
+			}
+		}
+	}
+
+	if len(v.problems) == 0 {
+		return nil
+	}
+	sort.Strings(v.problems)
+	return &ValidationError{Problems: v.problems}
+}
+
+// validator 收集校验问题
+type validator struct {
+	problems []string
+}
+
+// add 记录一个问题
+func (v *validator) add(path, problem string) {
+	v.problems = append(v.problems, path+": "+problem)
+}
+
+// object 校验对象中已知字段的类型
+func (v *validator) object(path string, value any, fields map[string]fieldKind) {
+	entry, ok := value.(map[string]any)
+	if !ok {
+		v.add(path, "must be an object")
+		return
+	}
+	for key, field := range entry {
+		if kind, ok := fields[key]; ok {
+			v.check(path+"."+key, field, kind)
+		}
+	}
+}
+
+// check 校验单个字段
+func (v *validator) check(path string, value any, kind fieldKind) {
+	switch kind {
+	case kindLocation:
+		v.object(path, value, locationFields)
+		return
+	case kindProfiles:
+		list, ok := value.([]any)
+		if !ok {
+			v.add(path, "must be an array")
+			return
+		}
+		for i, item := range list {
+			v.object(fmt.Sprintf("%s[%d]", path, i), item, profileFields)
+		}
+		return
+	case kindStrings:
+		list, ok := value.([]any)
+		if !ok {
+			v.add(path, "must be an array of strings")
+			return
+		}
+		for i, item := range list {
+			if _, ok := item.(string); !ok {
+				v.add(fmt.Sprintf("%s[%d]", path, i), "must be a string")
+			}
+		}
+		return
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		v.add(path, "must be a string")
+		return
+	}
+
+	switch kind {
+	case kindDate:
+		if !datePattern.MatchString(s) {
+			v.add(path, fmt.Sprintf("%q is not a date (YYYY, YYYY-MM or YYYY-MM-DD)", s))
+		}
+	case kindURI:
+		if u, err := url.Parse(s); err != nil || u.Scheme == "" {
+			v.add(path, fmt.Sprintf("%q is not a URI", s))
+		}
+	case kindEmail:
+		if _, err := mail.ParseAddress(s); err != nil {
+			v.add(path, fmt.Sprintf("%q is not an email address", s))
+		}
+	}
+}
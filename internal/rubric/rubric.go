@@ -0,0 +1,199 @@
+// Package rubric 读取用户提供的能力模型（能力项、等级及其描述），
+// 并按能力项的关键词在提交记录和工作流中查找证据，用于绩效自评
+package rubric
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/cluster"
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"gopkg.in/yaml.v3"
+)
+
+// MinEvidence 能力项至少需要的匹配提交数，少于该数量视为证据不足
+const MinEvidence = 3
+
+// Level 能力等级
+type Level struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// Area 能力项
+type Area struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Keywords    []string `yaml:"keywords"` // 用于在提交中查找证据的关键词
+	Levels      []Level  `yaml:"levels"`   // 该能力项各等级的描述
+}
+
+// Rubric 能力模型
+type Rubric struct {
+	Name   string  `yaml:"name"`
+	Levels []Level `yaml:"levels"` // 所有能力项共用的等级
+	Areas  []Area  `yaml:"areas"`
+}
+
+// Evidence 一个能力项在提交记录中的证据
+type Evidence struct {
+	Area        string
+	Keywords    []string // 使用的关键词，为空表示没有在本地查找证据
+	Hashes      []string // 匹配的提交哈希
+	Workstreams []string // 匹配的工作流
+}
+
+// Weak 是否证据不足。没有关键词的能力项无法在本地判断，不视为证据不足
+func (e Evidence) Weak() bool {
+	return len(e.Keywords) > 0 && len(e.Hashes) < MinEvidence
+}
+
+// Load 读取能力模型文件，.yaml 和 .yml 按 YAML 解析，其他按 Markdown 解析
+func Load(path string) (*Rubric, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r *Rubric
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		r, err = ParseYAML(content)
+	default:
+		r, err = ParseMarkdown(string(content))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
+}
+
+// ParseYAML 解析 YAML 格式的能力模型
+func ParseYAML(content []byte) (*Rubric, error) {
+	var r Rubric
+	if err := yaml.Unmarshal(content, &r); err != nil {
+		return nil, err
+	}
+	return &r, r.validate()
+}
+
+// ParseMarkdown 解析 Markdown 格式的能力模型：一级标题为名称，二级标题为能力项，
+// 能力项下的三级标题为等级，以 "Keywords:" 或 "关键词:" 开头的行为关键词，其余文本为描述
+func ParseMarkdown(content string) (*Rubric, error) {
+	r := &Rubric{}
+	var area *Area
+	var level *Level
+	appendText := func(text *string, line string) {
+		if *text != "" {
+			*text += " "
+		}
+		*text += line
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "### "):
+			if area == nil {
+				continue
+			}
+			area.Levels = append(area.Levels, Level{Name: strings.TrimSpace(line[4:])})
+			level = &area.Levels[len(area.Levels)-1]
+		case strings.HasPrefix(line, "## "):
+			r.Areas = append(r.Areas, Area{Name: strings.TrimSpace(line[3:])})
+			area, level = &r.Areas[len(r.Areas)-1], nil
+		case strings.HasPrefix(line, "# "):
+			r.Name = strings.TrimSpace(line[2:])
+		case area != nil && keywordLine.MatchString(line):
+			for _, keyword := range strings.FieldsFunc(keywordLine.ReplaceAllString(line, ""), isSeparator) {
+				area.Keywords = append(area.Keywords, strings.TrimSpace(keyword))
+			}
+		case level != nil:
+			appendText(&level.Description, strings.TrimLeft(line, "-* "))
+		case area != nil:
+			appendText(&area.Description, strings.TrimLeft(line, "-* "))
+		}
+	}
+	return r, r.validate()
+}
+
+// keywordLine 关键词行的前缀
+var keywordLine = regexp.MustCompile(`(?i)^(keywords|关键词)\s*[:：]\s*`)
+
+// isSeparator 关键词之间的分隔符
+func isSeparator(r rune) bool {
+	return r == ',' || r == '，' || r == '、' || r == ';'
+}
+
+// validate 检查能力模型至少有一个能力项，且每个能力项都有名称
+func (r *Rubric) validate() error {
+	if len(r.Areas) == 0 {
+		return fmt.Errorf("rubric has no competency areas")
+	}
+	for i, area := range r.Areas {
+		if strings.TrimSpace(area.Name) == "" {
+			return fmt.Errorf("competency area %d has no name", i+1)
+		}
+	}
+	return nil
+}
+
+// Match 按能力项的关键词查找匹配的提交和工作流。提交消息或变更文件路径包含关键词即视为匹配，
+// 英文关键词需要出现在单词的开头，如 test 匹配 tests 但不匹配 latest
+func Match(r *Rubric, commits []git.CommitInfo, workstreams []cluster.Workstream) []Evidence {
+	evidence := make([]Evidence, 0, len(r.Areas))
+	for _, area := range r.Areas {
+		item := Evidence{Area: area.Name}
+		var patterns []*regexp.Regexp
+		for _, keyword := range area.Keywords {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				item.Keywords = append(item.Keywords, keyword)
+				patterns = append(patterns, keywordPattern(keyword))
+			}
+		}
+		if len(patterns) == 0 {
+			evidence = append(evidence, item)
+			continue
+		}
+
+		for _, commit := range commits {
+			text := commit.Message + "\n" + strings.Join(commit.ChangedFiles, "\n")
+			if matchAny(patterns, text) {
+				item.Hashes = append(item.Hashes, commit.Hash)
+			}
+		}
+		for _, ws := range workstreams {
+			if matchAny(patterns, ws.Label+"\n"+strings.Join(ws.Keywords, "\n")) {
+				item.Workstreams = append(item.Workstreams, ws.Label)
+			}
+		}
+		evidence = append(evidence, item)
+	}
+	return evidence
+}
+
+// keywordPattern 返回关键词的匹配规则，不区分大小写
+func keywordPattern(keyword string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(keyword)
+	if isWord(keyword[0]) {
+		quoted = `(^|[^a-zA-Z0-9])` + quoted
+	}
+	return regexp.MustCompile(`(?i)` + quoted)
+}
+
+// isWord 是否为英文字母或数字
+func isWord(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func matchAny(patterns []*regexp.Regexp, text string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
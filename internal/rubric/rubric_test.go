@@ -0,0 +1,124 @@
+package rubric
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/cluster"
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// TestParseYAML 测试解析 YAML 格式的能力模型
+func TestParseYAML(t *testing.T) {
+	content := `
+name: Engineering Competencies
+levels:
+  - name: L3
+    description: Works independently on features
+areas:
+  - name: Quality
+    description: Writes well-tested code
+    keywords: [test, refactor]
+  - name: Delivery
+`
+	r, err := ParseYAML([]byte(content))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if r.Name != "Engineering Competencies" || len(r.Levels) != 1 || len(r.Areas) != 2 {
+		t.Fatalf("解析结果不正确: %+v", r)
+	}
+	if !reflect.DeepEqual(r.Areas[0].Keywords, []string{"test", "refactor"}) {
+		t.Errorf("关键词不正确: %v", r.Areas[0].Keywords)
+	}
+
+	if _, err := ParseYAML([]byte("name: empty\n")); err == nil {
+		t.Error("没有能力项时应返回错误")
+	}
+	if _, err := ParseYAML([]byte("areas:\n  - description: x\n")); err == nil {
+		t.Error("能力项没有名称时应返回错误")
+	}
+}
+
+// TestParseMarkdown 测试解析 Markdown 格式的能力模型
+func TestParseMarkdown(t *testing.T) {
+	content := `# 工程师能力模型
+
+## 技术能力
+编写可维护的代码
+关键词: 测试、重构, perf
+
+### P6
+独立负责模块
+
+### P7
+- 主导跨团队的技术方案
+
+## 影响力
+`
+	r, err := ParseMarkdown(content)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if r.Name != "工程师能力模型" || len(r.Areas) != 2 {
+		t.Fatalf("解析结果不正确: %+v", r)
+	}
+	area := r.Areas[0]
+	if area.Description != "编写可维护的代码" {
+		t.Errorf("描述不正确: %q", area.Description)
+	}
+	if !reflect.DeepEqual(area.Keywords, []string{"测试", "重构", "perf"}) {
+		t.Errorf("关键词不正确: %v", area.Keywords)
+	}
+	if len(area.Levels) != 2 || area.Levels[1].Description != "主导跨团队的技术方案" {
+		t.Errorf("等级不正确: %+v", area.Levels)
+	}
+}
+
+// TestMatch 测试按关键词查找证据
+func TestMatch(t *testing.T) {
+	r := &Rubric{Areas: []Area{
+		{Name: "Quality", Keywords: []string{"test"}},
+		{Name: "Docs", Keywords: []string{"文档"}},
+		{Name: "Leadership"},
+	}}
+	commits := []git.CommitInfo{
+		{Hash: "a1", Message: "Add tests for parser"},
+		{Hash: "b2", Message: "Use latest API"},
+		{Hash: "c3", Message: "Fix bug", ChangedFiles: []string{"internal/parser_test.go"}},
+		{Hash: "d4", Message: "更新文档"},
+	}
+	workstreams := []cluster.Workstream{{Label: "parser", Keywords: []string{"test", "parser"}}}
+
+	evidence := Match(r, commits, workstreams)
+	if len(evidence) != 3 {
+		t.Fatalf("应返回每个能力项的证据: %+v", evidence)
+	}
+	if !reflect.DeepEqual(evidence[0].Hashes, []string{"a1", "c3"}) {
+		t.Errorf("test 应匹配单词开头和变更文件路径，不匹配 latest: %v", evidence[0].Hashes)
+	}
+	if !reflect.DeepEqual(evidence[0].Workstreams, []string{"parser"}) {
+		t.Errorf("应匹配工作流: %v", evidence[0].Workstreams)
+	}
+	if !reflect.DeepEqual(evidence[1].Hashes, []string{"d4"}) {
+		t.Errorf("中文关键词应匹配: %v", evidence[1].Hashes)
+	}
+	if !evidence[1].Weak() || evidence[2].Weak() {
+		t.Error("证据少于最低数量的能力项应视为证据不足，没有关键词的能力项不应视为证据不足")
+	}
+}
+
+// TestLoad 测试按扩展名选择解析方式
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rubric.md")
+	if err := os.WriteFile(path, []byte("## Quality\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(path)
+	if err != nil || len(r.Areas) != 1 {
+		t.Errorf("应按 Markdown 解析: %+v %v", r, err)
+	}
+}
@@ -0,0 +1,324 @@
+// Package skillmatch 从职级要求或职位描述中识别所需的技能，
+// 并根据提交记录、技术栈和工作流为每项技能评估证据，计算匹配度
+package skillmatch
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/cluster"
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+)
+
+// MinEvidence 技能至少需要的匹配提交数，达到该数量视为优势
+const MinEvidence = 3
+
+// Skill 一项可以识别的技能
+type Skill struct {
+	Name     string
+	Aliases  []string // 在目标描述中识别该技能的写法，不区分大小写，两个字符以内的写法区分大小写
+	Keywords []string // 在提交消息和变更文件路径中查找证据的关键词，为空表示只根据技术栈判断
+	Domain   string   // 对应的专业领域，与 profile.Expertise 中的领域相同
+}
+
+// Catalog 内置的技能列表。编程语言的名称与 profile 中根据文件扩展名判断的语言相同
+var Catalog = []Skill{
+	{Name: "Go", Aliases: []string{"Go", "Golang"}},
+	{Name: "JavaScript", Aliases: []string{"JavaScript", "JS", "ES6"}},
+	{Name: "TypeScript", Aliases: []string{"TypeScript", "TS"}},
+	{Name: "Python", Aliases: []string{"Python"}},
+	{Name: "Java", Aliases: []string{"Java"}},
+	{Name: "Ruby", Aliases: []string{"Ruby"}},
+	{Name: "PHP", Aliases: []string{"PHP"}},
+	{Name: "C++", Aliases: []string{"C++", "cpp"}},
+	{Name: "C#", Aliases: []string{"C#", ".NET", "dotnet"}},
+	{Name: "Swift", Aliases: []string{"Swift"}},
+	{Name: "Kotlin", Aliases: []string{"Kotlin"}},
+	{Name: "Rust", Aliases: []string{"Rust"}},
+	{Name: "Scala", Aliases: []string{"Scala"}},
+	{Name: "Shell", Aliases: []string{"Shell", "Bash"}},
+	{Name: "SQL", Aliases: []string{"SQL"}},
+	{Name: "React", Aliases: []string{"React", "React.js", "ReactJS"}, Keywords: []string{"react"}},
+	{Name: "Vue", Aliases: []string{"Vue", "Vue.js"}},
+	{Name: "Angular", Aliases: []string{"Angular"}, Keywords: []string{"angular"}},
+	{Name: "Node.js", Aliases: []string{"Node.js", "NodeJS"}, Keywords: []string{"node", "npm", "package.json"}},
+	{Name: "Django", Aliases: []string{"Django"}, Keywords: []string{"django"}},
+	{Name: "Flask", Aliases: []string{"Flask"}, Keywords: []string{"flask"}},
+	{Name: "Spring", Aliases: []string{"Spring", "Spring Boot"}, Keywords: []string{"spring"}},
+	{Name: "Rails", Aliases: []string{"Rails", "Ruby on Rails"}, Keywords: []string{"rails"}},
+	{Name: "GraphQL", Aliases: []string{"GraphQL"}, Keywords: []string{"graphql", ".graphql"}},
+	{Name: "gRPC", Aliases: []string{"gRPC", "Protobuf", "Protocol Buffers"}, Keywords: []string{"grpc", "protobuf", ".proto"}},
+	{Name: "REST API", Aliases: []string{"REST API", "RESTful"}, Keywords: []string{"api", "endpoint", "rest"}},
+	{Name: "PostgreSQL", Aliases: []string{"PostgreSQL", "Postgres"}, Keywords: []string{"postgres", "pg"}},
+	{Name: "MySQL", Aliases: []string{"MySQL"}, Keywords: []string{"mysql"}},
+	{Name: "MongoDB", Aliases: []string{"MongoDB", "Mongo"}, Keywords: []string{"mongo"}},
+	{Name: "Redis", Aliases: []string{"Redis"}, Keywords: []string{"redis"}},
+	{Name: "Kafka", Aliases: []string{"Kafka"}, Keywords: []string{"kafka"}},
+	{Name: "Docker", Aliases: []string{"Docker", "容器"}, Keywords: []string{"docker", "dockerfile", "container", "容器"}},
+	{Name: "Kubernetes", Aliases: []string{"Kubernetes", "K8s", "Helm"}, Keywords: []string{"kubernetes", "k8s", "helm", "kubectl"}},
+	{Name: "Terraform", Aliases: []string{"Terraform"}, Keywords: []string{"terraform", ".tf"}},
+	{Name: "AWS", Aliases: []string{"AWS", "Amazon Web Services"}, Keywords: []string{"aws", "s3", "lambda", "ec2"}},
+	{Name: "GCP", Aliases: []string{"GCP", "Google Cloud"}, Keywords: []string{"gcp", "gcloud", "bigquery"}},
+	{Name: "Azure", Aliases: []string{"Azure"}, Keywords: []string{"azure"}},
+	{Name: "CI/CD", Aliases: []string{"CI/CD", "CI", "Continuous Integration", "持续集成"}, Keywords: []string{"pipeline", ".github/workflows", ".gitlab-ci", "jenkins"}},
+	{Name: "Testing", Aliases: []string{"unit test", "unit testing", "TDD", "test automation", "测试"}, Keywords: []string{"test", "spec", "测试"}},
+	{Name: "Observability", Aliases: []string{"observability", "monitoring", "Prometheus", "Grafana", "监控"}, Keywords: []string{"metric", "monitor", "prometheus", "grafana", "tracing", "alert", "监控", "告警"}},
+	{Name: "Performance", Aliases: []string{"performance", "scalability", "性能"}, Keywords: []string{"perf", "optimiz", "latency", "cache", "性能", "优化"}},
+	{Name: "Security", Aliases: []string{"security", "安全"}, Keywords: []string{"security", "oauth", "authn", "authz", "csrf", "xss", "cve", "vulnerab", "安全"}},
+	{Name: "Frontend", Aliases: []string{"frontend", "front-end", "前端"}, Domain: "前端开发"},
+	{Name: "Backend", Aliases: []string{"backend", "back-end", "后端"}, Domain: "后端开发"},
+	{Name: "Full Stack", Aliases: []string{"full stack", "full-stack", "fullstack", "全栈"}, Domain: "全栈开发"},
+	{Name: "DevOps", Aliases: []string{"DevOps", "SRE", "运维"}, Domain: "DevOps"},
+}
+
+// Level 技能的证据等级
+type Level string
+
+const (
+	// Strong 有充分的证据
+	Strong Level = "strong"
+	// Weak 有少量证据
+	Weak Level = "weak"
+	// Missing 没有证据
+	Missing Level = "missing"
+)
+
+// Evidence 一项技能在提交记录中的证据
+type Evidence struct {
+	Skill       string
+	Level       Level
+	Changes     int      // 技术栈中该语言的文件变更数，非编程语言为 0
+	Hashes      []string // 匹配的提交哈希
+	Workstreams []string // 匹配的工作流
+	Domain      bool     // 是否为主要或次要的专业领域
+}
+
+// Result 匹配结果
+type Result struct {
+	Score     int        // 匹配度，0 到 100
+	Skills    []Evidence // 按目标描述中出现的顺序排列的所有技能
+	Strengths []Evidence // 有充分证据的技能
+	Gaps      []Evidence // 证据不足或没有证据的技能
+}
+
+// LoadTarget 读取目标描述文件，level 不为空时只保留标题包含 level 的章节，
+// 用于从包含多个职级的文件中选出一个职级
+func LoadTarget(path, level string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	text := string(content)
+	if level == "" {
+		return text, nil
+	}
+	section, ok := Section(text, level)
+	if !ok {
+		return "", fmt.Errorf("%s: no heading contains %q", path, level)
+	}
+	return section, nil
+}
+
+// headingPattern Markdown 标题
+var headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+
+// Section 返回标题包含 heading 的第一个 Markdown 章节（含标题），到下一个同级或更高级的标题为止
+func Section(text, heading string) (string, bool) {
+	heading = strings.ToLower(strings.TrimSpace(heading))
+	lines := strings.Split(text, "\n")
+	start, depth := -1, 0
+	for i, line := range lines {
+		m := headingPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		if start >= 0 && len(m[1]) <= depth {
+			return strings.Join(lines[start:i], "\n"), true
+		}
+		if start < 0 && strings.Contains(strings.ToLower(m[2]), heading) {
+			start, depth = i, len(m[1])
+		}
+	}
+	if start < 0 {
+		return "", false
+	}
+	return strings.Join(lines[start:], "\n"), true
+}
+
+// skillsLine 列出额外技能的行的前缀
+var skillsLine = regexp.MustCompile(`(?i)^[-*\s]*(skills|技能)\s*[:：]\s*`)
+
+// Extract 从目标描述中识别所需的技能，按第一次出现的位置排列。内置技能列表中的技能按别名识别，
+// 以 "Skills:" 或 "技能:" 开头的行中列出的其他技能也会加入，并以技能名称作为查找证据的关键词
+func Extract(text string) []Skill {
+	type found struct {
+		skill Skill
+		pos   int
+	}
+	var skills []found
+	seen := make(map[string]bool)
+	add := func(skill Skill, pos int) {
+		key := strings.ToLower(skill.Name)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		skills = append(skills, found{skill, pos})
+	}
+
+	for _, skill := range Catalog {
+		pos := -1
+		for _, alias := range skill.Aliases {
+			if loc := aliasPattern(alias).FindStringIndex(text); loc != nil && (pos < 0 || loc[0] < pos) {
+				pos = loc[0]
+			}
+		}
+		if pos >= 0 {
+			add(skill, pos)
+		}
+	}
+
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if prefix := skillsLine.FindString(line); prefix != "" {
+			for _, name := range strings.FieldsFunc(line[len(prefix):], isSeparator) {
+				if name = strings.TrimSpace(name); name != "" {
+					add(Skill{Name: name, Keywords: []string{name}}, offset)
+				}
+			}
+		}
+		offset += len(line)
+	}
+
+	// 按出现位置排序，位置相同时保持原有顺序
+	sort.SliceStable(skills, func(i, j int) bool {
+		return skills[i].pos < skills[j].pos
+	})
+	result := make([]Skill, len(skills))
+	for i, item := range skills {
+		result[i] = item.skill
+	}
+	return result
+}
+
+// isSeparator 技能之间的分隔符
+func isSeparator(r rune) bool {
+	return r == ',' || r == '，' || r == '、' || r == ';' || r == '；' || r == '\n'
+}
+
+// Score 根据开发者画像的技术栈和专业领域、提交记录和工作流为每项技能评估证据。
+// 有充分证据的技能计 1 分，有少量证据的计 0.5 分，匹配度为得分占技能数的百分比
+func Score(skills []Skill, dev *profile.DeveloperProfile, commits []git.CommitInfo, workstreams []cluster.Workstream) Result {
+	var result Result
+	total := 0.0
+	for _, skill := range skills {
+		item := Evidence{Skill: skill.Name, Changes: dev.TechStack.Languages[skill.Name]}
+		patterns := make([]*regexp.Regexp, len(skill.Keywords))
+		for i, keyword := range skill.Keywords {
+			patterns[i] = keywordPattern(keyword)
+		}
+
+		for _, commit := range commits {
+			if usesLanguage(commit, skill.Name) || matchAny(patterns, commit.Message+"\n"+strings.Join(commit.ChangedFiles, "\n")) {
+				item.Hashes = append(item.Hashes, commit.Hash)
+			}
+		}
+		for _, ws := range workstreams {
+			if matchAny(patterns, ws.Label+"\n"+strings.Join(ws.Keywords, "\n")) {
+				item.Workstreams = append(item.Workstreams, ws.Label)
+			}
+		}
+		item.Domain = hasSkill(dev, skill)
+
+		switch {
+		case len(item.Hashes) >= MinEvidence || item.Domain && skill.Domain != "":
+			item.Level = Strong
+			total++
+			result.Strengths = append(result.Strengths, item)
+		case len(item.Hashes) > 0 || item.Domain || len(item.Workstreams) > 0:
+			item.Level = Weak
+			total += 0.5
+			result.Gaps = append(result.Gaps, item)
+		default:
+			item.Level = Missing
+			result.Gaps = append(result.Gaps, item)
+		}
+		result.Skills = append(result.Skills, item)
+	}
+	if len(skills) > 0 {
+		result.Score = int(math.Round(total / float64(len(skills)) * 100))
+	}
+	return result
+}
+
+// usesLanguage 提交是否修改了该编程语言的文件
+func usesLanguage(commit git.CommitInfo, name string) bool {
+	for _, file := range commit.ChangedFiles {
+		if lang, ok := profile.LanguageOf(file); ok && lang == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSkill 技能是否为开发者画像中的专业领域，或出现在技术栈和关键技能中
+func hasSkill(dev *profile.DeveloperProfile, skill Skill) bool {
+	expertise := dev.Expertise
+	if skill.Domain != "" {
+		if expertise.PrimaryDomain == skill.Domain || expertise.PrimaryDomain == "全栈开发" && (skill.Name == "Frontend" || skill.Name == "Backend") {
+			return true
+		}
+	}
+	lists := [][]string{expertise.SecondaryDomains, expertise.KeySkills, dev.TechStack.Frameworks, dev.TechStack.Tools, dev.TechStack.Platforms}
+	for _, list := range lists {
+		for _, name := range list {
+			if strings.EqualFold(name, skill.Name) || skill.Domain != "" && name == skill.Domain {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// aliasPattern 返回在目标描述中识别别名的规则，别名前后不能紧接英文字母或数字
+func aliasPattern(alias string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(alias)
+	if isWord(alias[0]) {
+		quoted = `(?:^|[^a-zA-Z0-9])` + quoted
+	}
+	if isWord(alias[len(alias)-1]) {
+		quoted += `(?:$|[^a-zA-Z0-9+#])`
+	}
+	if len(alias) > 2 {
+		quoted = `(?i)` + quoted
+	}
+	return regexp.MustCompile(quoted)
+}
+
+// keywordPattern 返回在提交中查找关键词的规则，不区分大小写，英文关键词需要出现在单词的开头
+func keywordPattern(keyword string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(keyword)
+	if isWord(keyword[0]) {
+		quoted = `(^|[^a-zA-Z0-9])` + quoted
+	}
+	return regexp.MustCompile(`(?i)` + quoted)
+}
+
+// isWord 是否为英文字母或数字
+func isWord(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func matchAny(patterns []*regexp.Regexp, text string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package skillmatch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/cluster"
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+)
+
+// names 返回技能名称
+func names(skills []Skill) []string {
+	result := make([]string, len(skills))
+	for i, skill := range skills {
+		result[i] = skill.Name
+	}
+	return result
+}
+
+// TestExtract 测试从职位描述中识别技能
+func TestExtract(t *testing.T) {
+	text := `Senior Backend Engineer
+We use Golang and PostgreSQL, deployed on Kubernetes.
+Experience with JavaScript is a plus; rest of the stack is documented.
+Skills: 技术方案评审, mentoring
+`
+	got := names(Extract(text))
+	want := []string{"Backend", "Go", "PostgreSQL", "Kubernetes", "JavaScript", "技术方案评审", "mentoring"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("识别的技能为 %v，应为 %v", got, want)
+	}
+
+	// 短别名区分大小写，别名不能是其他单词的一部分
+	if got := names(Extract("Let's go to Google and use the Javadoc tool")); len(got) != 0 {
+		t.Errorf("不应识别任何技能: %v", got)
+	}
+}
+
+// TestSection 测试按职级选出章节
+func TestSection(t *testing.T) {
+	ladder := `# Engineering Ladder
+## L4
+Writes Go services
+### Scope
+Team
+## L5 Senior
+Leads Kubernetes migrations
+`
+	section, ok := Section(ladder, "l4")
+	if !ok || section != "## L4\nWrites Go services\n### Scope\nTeam" {
+		t.Errorf("应返回 L4 章节及其子章节: %q", section)
+	}
+	if section, ok := Section(ladder, "senior"); !ok || section != "## L5 Senior\nLeads Kubernetes migrations\n" {
+		t.Errorf("应返回到文件结尾: %q", section)
+	}
+	if _, ok := Section(ladder, "L6"); ok {
+		t.Error("找不到标题时应返回 false")
+	}
+}
+
+// TestScore 测试证据等级和匹配度
+func TestScore(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	commits := []git.CommitInfo{
+		{Hash: "a1", Message: "Add handler", ChangedFiles: []string{"api/handler.go"}, Date: now},
+		{Hash: "b2", Message: "Fix handler", ChangedFiles: []string{"api/handler.go"}, Date: now},
+		{Hash: "c3", Message: "Add helm chart", ChangedFiles: []string{"deploy/values.yaml"}, Date: now},
+		{Hash: "d4", Message: "Refactor store", ChangedFiles: []string{"store/store.go"}, Date: now},
+	}
+	dev := profile.AnalyzeProfile(commits, now, now, "")
+	skills := Extract("Backend engineer with Go, Kubernetes and Kafka")
+	result := Score(skills, dev, commits, []cluster.Workstream{{Label: "helm", Keywords: []string{"helm"}}})
+
+	levels := make(map[string]Level)
+	for _, item := range result.Skills {
+		levels[item.Skill] = item.Level
+	}
+	want := map[string]Level{"Backend": Strong, "Go": Strong, "Kubernetes": Weak, "Kafka": Missing}
+	if !reflect.DeepEqual(levels, want) {
+		t.Errorf("证据等级为 %v，应为 %v", levels, want)
+	}
+	if result.Skills[1].Changes != 3 {
+		t.Errorf("Go 的文件变更数应来自技术栈: %d", result.Skills[1].Changes)
+	}
+	if len(result.Strengths) != 2 || len(result.Gaps) != 2 {
+		t.Errorf("优势和差距不正确: %+v", result)
+	}
+	// (1 + 1 + 0.5 + 0) / 4
+	if result.Score != 63 {
+		t.Errorf("匹配度为 %d，应为 63", result.Score)
+	}
+
+	if empty := Score(nil, dev, commits, nil); empty.Score != 0 {
+		t.Errorf("没有技能时匹配度应为 0: %d", empty.Score)
+	}
+}
@@ -0,0 +1,391 @@
+// Package team 将多个作者的提交按贡献者拆分，为每个贡献者计算开发者画像，
+// 并汇总团队的专业领域、代码区域的覆盖情况和成员之间的协作
+package team
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/ownership"
+	"github.com/MyceliumGrid/git-work-profile/internal/profile"
+)
+
+// Member 一个贡献者
+type Member struct {
+	Name    string // 最常用的作者名，与其他成员同名时加上邮箱
+	Email   string // 经过 .mailmap 解析的邮箱，可为空
+	Commits []git.CommitInfo
+	Profile *profile.DeveloperProfile
+}
+
+// Share 一个贡献者在代码区域中的提交数
+type Share struct {
+	Name    string
+	Commits int
+}
+
+// Coverage 一个代码区域（仓库中的顶层目录）的贡献者分布
+type Coverage struct {
+	Area         string
+	Commits      int
+	Contributors []Share // 按提交数从多到少排列
+	BusFactor    int     // 提交数合计超过一半所需的最少贡献者数
+}
+
+// Pair 两个贡献者之间的协作
+type Pair struct {
+	A, B        string
+	SharedFiles int // 两人都修改过的文件数
+	CoAuthored  int // 通过 Co-authored-by 共同完成的提交数
+}
+
+// Summary 团队汇总
+type Summary struct {
+	From, To      time.Time
+	Members       []Member
+	Coverage      []Coverage       // 按提交数从多到少排列
+	Knowledge     []ownership.Area // 知识地图，由调用方按需计算，为空时不输出
+	Collaboration []Pair           // 按共同修改的文件数和共同完成的提交数从多到少排列
+}
+
+// Members 按经过 .mailmap 解析的作者邮箱拆分提交，没有邮箱时按作者名拆分。
+// 成员名称使用其提交中最常用的作者名，不同成员同名时加上邮箱以便区分。
+// 提交数少于 minCommits 的贡献者不计入，结果按提交数从多到少排列
+func Members(commits []git.CommitInfo, from, to time.Time, minCommits int) []Member {
+	byKey := make(map[string][]git.CommitInfo)
+	var keys []string
+	for _, commit := range commits {
+		key := contributorKey(commit)
+		if key == "" {
+			continue
+		}
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], commit)
+	}
+
+	var members []Member
+	for _, key := range keys {
+		list := byKey[key]
+		if len(list) < minCommits {
+			continue
+		}
+		members = append(members, Member{Name: displayName(list), Email: strings.TrimSpace(list[0].Email), Commits: list})
+	}
+
+	// 同名的不同成员加上邮箱，避免画像和报告文件混在一起
+	sameName := make(map[string]int)
+	for _, member := range members {
+		sameName[member.Name]++
+	}
+	for i := range members {
+		if sameName[members[i].Name] > 1 && members[i].Email != "" {
+			members[i].Name = fmt.Sprintf("%s <%s>", members[i].Name, members[i].Email)
+		}
+		members[i].Profile = profile.AnalyzeProfile(members[i].Commits, from, to, members[i].Name)
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		if len(members[i].Commits) != len(members[j].Commits) {
+			return len(members[i].Commits) > len(members[j].Commits)
+		}
+		return members[i].Name < members[j].Name
+	})
+	return members
+}
+
+// contributorKey 返回区分贡献者的键：小写的邮箱，没有邮箱时为作者名
+func contributorKey(commit git.CommitInfo) string {
+	if email := strings.ToLower(strings.TrimSpace(commit.Email)); email != "" {
+		return "email:" + email
+	}
+	if name := strings.TrimSpace(commit.Author); name != "" {
+		return "name:" + name
+	}
+	return ""
+}
+
+// displayName 返回提交中最常用的作者名，次数相同时取先出现的
+func displayName(commits []git.CommitInfo) string {
+	counts := make(map[string]int)
+	best := ""
+	for _, commit := range commits {
+		name := strings.TrimSpace(commit.Author)
+		if name == "" {
+			continue
+		}
+		counts[name]++
+		if best == "" || counts[name] > counts[best] {
+			best = name
+		}
+	}
+	if best == "" {
+		best = strings.TrimSpace(commits[0].Email)
+	}
+	return best
+}
+
+// Summarize 汇总团队成员的代码区域覆盖情况和协作关系
+func Summarize(members []Member, from, to time.Time) Summary {
+	summary := Summary{From: from, To: to, Members: members}
+	multiRepo := len(repoNames(members)) > 1
+
+	areaCommits := make(map[string]map[string]int) // 代码区域 -> 贡献者 -> 提交数
+	fileMembers := make(map[string]map[string]bool)
+	coAuthored := make(map[[2]string]int)
+	byEmail := make(map[string]string) // 小写邮箱 -> 成员名
+	byName := make(map[string]string)  // 作者名 -> 成员名，同名的不同成员不按名称匹配
+	for _, member := range members {
+		if member.Email != "" {
+			byEmail[strings.ToLower(member.Email)] = member.Name
+		}
+		name := displayName(member.Commits)
+		if _, ok := byName[name]; ok {
+			byName[name] = ""
+		} else {
+			byName[name] = member.Name
+		}
+	}
+
+	for _, member := range members {
+		for _, commit := range member.Commits {
+			areas := make(map[string]bool)
+			for _, file := range commit.ChangedFiles {
+				areas[area(commit.RepoPath, file, multiRepo)] = true
+				key := commit.RepoPath + "\x00" + file
+				if fileMembers[key] == nil {
+					fileMembers[key] = make(map[string]bool)
+				}
+				fileMembers[key][member.Name] = true
+			}
+			for name := range areas {
+				if areaCommits[name] == nil {
+					areaCommits[name] = make(map[string]int)
+				}
+				areaCommits[name][member.Name]++
+			}
+			for _, m := range coAuthorPattern.FindAllStringSubmatch(commit.Body, -1) {
+				coAuthor, ok := byEmail[strings.ToLower(strings.TrimSpace(m[2]))]
+				if !ok {
+					coAuthor = byName[m[1]]
+				}
+				if coAuthor != "" && coAuthor != member.Name {
+					coAuthored[pairKey(member.Name, coAuthor)]++
+				}
+			}
+		}
+	}
+
+	for name, counts := range areaCommits {
+		summary.Coverage = append(summary.Coverage, coverage(name, counts))
+	}
+	sort.Slice(summary.Coverage, func(i, j int) bool {
+		if summary.Coverage[i].Commits != summary.Coverage[j].Commits {
+			return summary.Coverage[i].Commits > summary.Coverage[j].Commits
+		}
+		return summary.Coverage[i].Area < summary.Coverage[j].Area
+	})
+
+	shared := make(map[[2]string]int)
+	for _, names := range fileMembers {
+		list := make([]string, 0, len(names))
+		for name := range names {
+			list = append(list, name)
+		}
+		for i := range list {
+			for j := i + 1; j < len(list); j++ {
+				shared[pairKey(list[i], list[j])]++
+			}
+		}
+	}
+	for key, count := range shared {
+		summary.Collaboration = append(summary.Collaboration, Pair{A: key[0], B: key[1], SharedFiles: count, CoAuthored: coAuthored[key]})
+	}
+	for key, count := range coAuthored {
+		if _, ok := shared[key]; !ok {
+			summary.Collaboration = append(summary.Collaboration, Pair{A: key[0], B: key[1], CoAuthored: count})
+		}
+	}
+	sort.Slice(summary.Collaboration, func(i, j int) bool {
+		a, b := summary.Collaboration[i], summary.Collaboration[j]
+		if a.SharedFiles+a.CoAuthored != b.SharedFiles+b.CoAuthored {
+			return a.SharedFiles+a.CoAuthored > b.SharedFiles+b.CoAuthored
+		}
+		return a.A+a.B < b.A+b.B
+	})
+	return summary
+}
+
+// coverage 根据各贡献者的提交数计算代码区域的覆盖情况
+func coverage(name string, counts map[string]int) Coverage {
+	c := Coverage{Area: name}
+	for member, count := range counts {
+		c.Contributors = append(c.Contributors, Share{Name: member, Commits: count})
+		c.Commits += count
+	}
+	sort.Slice(c.Contributors, func(i, j int) bool {
+		if c.Contributors[i].Commits != c.Contributors[j].Commits {
+			return c.Contributors[i].Commits > c.Contributors[j].Commits
+		}
+		return c.Contributors[i].Name < c.Contributors[j].Name
+	})
+
+	covered := 0
+	for _, share := range c.Contributors {
+		covered += share.Commits
+		c.BusFactor++
+		if covered*2 > c.Commits {
+			break
+		}
+	}
+	return c
+}
+
+// area 返回文件所在的代码区域：顶层目录，根目录下的文件为 "/"，有多个仓库时加上仓库名
+func area(repoPath, file string, multiRepo bool) string {
+	name := "/"
+	if dir, _, ok := strings.Cut(file, "/"); ok {
+		name = dir + "/"
+	}
+	if multiRepo {
+		return repoName(repoPath) + ":" + name
+	}
+	return name
+}
+
+// repoNames 返回成员提交所在的仓库
+func repoNames(members []Member) map[string]bool {
+	repos := make(map[string]bool)
+	for _, member := range members {
+		for _, commit := range member.Commits {
+			repos[commit.RepoPath] = true
+		}
+	}
+	return repos
+}
+
+// repoName 返回仓库目录名
+func repoName(repoPath string) string {
+	if abs, err := filepath.Abs(repoPath); err == nil {
+		repoPath = abs
+	}
+	return filepath.Base(repoPath)
+}
+
+// coAuthorPattern 提交正文中的 Co-authored-by 脚注
+var coAuthorPattern = regexp.MustCompile(`(?im)^co-authored-by:\s*([^<\n]+?)\s*(?:<([^>\n]*)>)?\s*$`)
+
+// CoAuthors 返回提交正文中 Co-authored-by 脚注列出的共同作者名
+func CoAuthors(body string) []string {
+	var names []string
+	for _, m := range coAuthorPattern.FindAllStringSubmatch(body, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// pairKey 返回与顺序无关的成员对
+func pairKey(a, b string) [2]string {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
+
+// Link 团队索引中指向成员报告的链接
+type Link struct {
+	Label string
+	Path  string // 相对于索引文件的路径
+}
+
+// Index 返回团队索引的 Markdown：成员列表及其报告链接、专业领域、代码区域覆盖情况、知识地图和协作关系。
+// links 为成员名到报告链接的映射，labels 为表格的标题
+func Index(summary Summary, links map[string][]Link, labels Labels) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", labels.Title)
+	fmt.Fprintf(&b, "%s: %s ~ %s\n", labels.Period, summary.From.Format("2006-01-02"), summary.To.Format("2006-01-02"))
+
+	fmt.Fprintf(&b, "\n## %s\n\n", labels.Members)
+	fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n|---|---:|---:|---|---|---|\n",
+		labels.Name, labels.Commits, labels.Lines, labels.Domain, labels.Languages, labels.Reports)
+	for _, member := range summary.Members {
+		dev := member.Profile
+		var reports []string
+		for _, link := range links[member.Name] {
+			reports = append(reports, fmt.Sprintf("[%s](%s)", link.Label, filepath.ToSlash(link.Path)))
+		}
+		fmt.Fprintf(&b, "| %s | %d | +%d -%d | %s | %s | %s |\n", escape(member.Name), dev.Statistics.TotalCommits,
+			dev.Statistics.LinesAdded, dev.Statistics.LinesDeleted, dev.Expertise.PrimaryDomain,
+			strings.Join(topLanguages(dev.TechStack.Languages, 3), ", "), strings.Join(reports, " · "))
+	}
+
+	fmt.Fprintf(&b, "\n## %s\n\n", labels.Coverage)
+	fmt.Fprintf(&b, "| %s | %s | %s | %s |\n|---|---:|---|---:|\n", labels.Area, labels.Commits, labels.Contributors, labels.BusFactor)
+	for _, c := range summary.Coverage {
+		shares := make([]string, len(c.Contributors))
+		for i, share := range c.Contributors {
+			shares[i] = fmt.Sprintf("%s (%d)", escape(share.Name), share.Commits)
+		}
+		fmt.Fprintf(&b, "| `%s` | %d | %s | %d |\n", c.Area, c.Commits, strings.Join(shares, ", "), c.BusFactor)
+	}
+
+	if len(summary.Knowledge) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", labels.Knowledge)
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | CODEOWNERS |\n|---|---:|---|---:|---|\n", labels.Area, labels.Commits, labels.Experts, labels.BusFactor)
+		for _, area := range summary.Knowledge {
+			declared := escape(strings.Join(area.Declared, ", "))
+			if area.Mismatch {
+				declared += " ⚠️ " + labels.Mismatch
+			}
+			fmt.Fprintf(&b, "| `%s` | %d | %s | %d | %s |\n", area.Path, area.Commits, escape(area.TopExperts(3)), area.BusFactor, declared)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## %s\n\n", labels.Collaboration)
+	if len(summary.Collaboration) == 0 {
+		fmt.Fprintf(&b, "%s\n", labels.NoCollaboration)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "| %s | %s | %s |\n|---|---:|---:|\n", labels.Pair, labels.SharedFiles, labels.CoAuthored)
+	for _, pair := range summary.Collaboration {
+		fmt.Fprintf(&b, "| %s · %s | %d | %d |\n", escape(pair.A), escape(pair.B), pair.SharedFiles, pair.CoAuthored)
+	}
+	return b.String()
+}
+
+// Labels 团队索引中的标题和表头，由调用方按界面语言提供
+type Labels struct {
+	Title, Period, Members, Name, Commits, Lines, Domain, Languages, Reports string
+	Coverage, Area, Contributors, BusFactor                                  string
+	Knowledge, Experts, Mismatch                                             string
+	Collaboration, Pair, SharedFiles, CoAuthored, NoCollaboration            string
+}
+
+// topLanguages 返回文件变更数最多的 n 种语言
+func topLanguages(languages map[string]int, n int) []string {
+	names := make([]string, 0, len(languages))
+	for name := range languages {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if languages[names[i]] != languages[names[j]] {
+			return languages[names[i]] > languages[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > n {
+		names = names[:n]
+	}
+	return names
+}
+
+// escape 转义 Markdown 表格中的竖线
+func escape(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
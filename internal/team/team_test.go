@@ -0,0 +1,124 @@
+package team
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+	"github.com/MyceliumGrid/git-work-profile/internal/ownership"
+)
+
+// testCommits 三个贡献者的提交
+func testCommits() []git.CommitInfo {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	return []git.CommitInfo{
+		{Hash: "a1", Author: "Alice", Date: now, ChangedFiles: []string{"api/handler.go", "README.md"}},
+		{Hash: "a2", Author: "Alice", Date: now, ChangedFiles: []string{"api/store.go"}},
+		{Hash: "a3", Author: "Alice", Date: now, ChangedFiles: []string{"web/app.ts"}, Body: "Co-authored-by: Bob <bob@example.com>"},
+		{Hash: "b1", Author: "Bob", Date: now, ChangedFiles: []string{"web/app.ts", "web/app.css"}},
+		{Hash: "b2", Author: "Bob", Date: now, ChangedFiles: []string{"api/handler.go"}},
+		{Hash: "c1", Author: "Carol", Date: now, ChangedFiles: []string{"docs/guide.md"}},
+	}
+}
+
+// TestMembers 测试按作者拆分和最少提交数
+func TestMembers(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	members := Members(testCommits(), now, now, 2)
+	var got []string
+	for _, member := range members {
+		got = append(got, member.Name)
+	}
+	if want := []string{"Alice", "Bob"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("成员为 %v，应为 %v", got, want)
+	}
+	if members[0].Profile.Statistics.TotalCommits != 3 {
+		t.Errorf("Alice 的画像应包含3个提交: %d", members[0].Profile.Statistics.TotalCommits)
+	}
+}
+
+// TestMembersByEmail 测试按邮箱区分同名贡献者，并合并同一邮箱下的不同署名
+func TestMembersByEmail(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	commits := []git.CommitInfo{
+		{Hash: "a1", Author: "Alice", Email: "alice@a.com", Date: now},
+		{Hash: "a2", Author: "Alice", Email: "alice@a.com", Date: now},
+		{Hash: "x1", Author: "Alice", Email: "alice@b.com", Date: now},
+		{Hash: "b1", Author: "Bob", Email: "bob@example.com", Date: now, Body: "Co-authored-by: Alice <alice@b.com>"},
+		{Hash: "b2", Author: "Bob Smith", Email: "Bob@Example.com", Date: now},
+		{Hash: "b3", Author: "Bob Smith", Email: "bob@example.com", Date: now},
+		{Hash: "b4", Author: "Bob Smith", Email: "bob@example.com", Date: now},
+	}
+	members := Members(commits, now, now, 1)
+	got := make(map[string]int)
+	for _, member := range members {
+		got[member.Name] = len(member.Commits)
+	}
+	want := map[string]int{"Bob Smith": 4, "Alice <alice@a.com>": 2, "Alice <alice@b.com>": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("成员为 %v，应为 %v", got, want)
+	}
+	if members[0].Name != "Bob Smith" || members[0].Profile.Statistics.TotalCommits != 4 {
+		t.Errorf("同一邮箱的提交应合并，并使用最常用的署名: %+v", members[0].Name)
+	}
+
+	summary := Summarize(members, now, now)
+	if len(summary.Collaboration) != 1 || summary.Collaboration[0] != (Pair{A: "Alice <alice@b.com>", B: "Bob Smith", CoAuthored: 1}) {
+		t.Errorf("共同作者应按邮箱对应到成员: %+v", summary.Collaboration)
+	}
+}
+
+// TestSummarize 测试代码区域覆盖、总线系数和协作关系
+func TestSummarize(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	summary := Summarize(Members(testCommits(), now, now, 1), now, now)
+
+	areas := make(map[string]Coverage)
+	for _, c := range summary.Coverage {
+		areas[c.Area] = c
+	}
+	if c := areas["api/"]; c.Commits != 3 || c.BusFactor != 1 || c.Contributors[0].Name != "Alice" {
+		t.Errorf("api/ 的覆盖情况不正确: %+v", c)
+	}
+	if c := areas["web/"]; c.Commits != 2 || c.BusFactor != 2 {
+		t.Errorf("两人各占一半时总线系数应为2: %+v", c)
+	}
+	if c := areas["/"]; c.Commits != 1 {
+		t.Errorf("根目录下的文件应归入 /: %+v", c)
+	}
+
+	if len(summary.Collaboration) != 1 {
+		t.Fatalf("应只有 Alice 和 Bob 的协作: %+v", summary.Collaboration)
+	}
+	if pair := summary.Collaboration[0]; pair != (Pair{A: "Alice", B: "Bob", SharedFiles: 2, CoAuthored: 1}) {
+		t.Errorf("协作关系不正确: %+v", pair)
+	}
+}
+
+// TestCoAuthors 测试解析 Co-authored-by 脚注
+func TestCoAuthors(t *testing.T) {
+	body := "Pair on parser\n\nCo-authored-by: Bob Smith <bob@example.com>\nco-authored-by: 张三\n"
+	if got, want := CoAuthors(body), []string{"Bob Smith", "张三"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("共同作者为 %v，应为 %v", got, want)
+	}
+}
+
+// TestIndex 测试索引中的报告链接
+func TestIndex(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	summary := Summarize(Members(testCommits(), now, now, 1), now, now)
+	summary.Knowledge = ownership.Map(testCommits(), ownership.Options{Depth: 1})
+	links := map[string][]Link{"Alice": {{Label: "markdown", Path: "alice-profile.md"}}}
+	index := Index(summary, links, Labels{Title: "Team", Knowledge: "Knowledge", NoCollaboration: "none"})
+	if !strings.Contains(index, "| Alice | 3 |") || !strings.Contains(index, "[markdown](alice-profile.md)") {
+		t.Errorf("索引缺少成员或报告链接:\n%s", index)
+	}
+	if !strings.Contains(index, "## Knowledge") || !strings.Contains(index, "| `api` | 3 | Alice 67%, Bob 33% | 1 |") {
+		t.Errorf("索引缺少知识地图:\n%s", index)
+	}
+	if !strings.Contains(index, "| Alice · Bob | 2 | 1 |") {
+		t.Errorf("索引缺少协作关系:\n%s", index)
+	}
+}
@@ -0,0 +1,312 @@
+package term
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// ANSI 样式
+const (
+	reset        = "\x1b[0m"
+	styleBold    = "1"
+	styleDim     = "2"
+	styleItalic  = "3"
+	styleH1      = "1;35"
+	styleH2      = "1;36"
+	styleH3      = "1;34"
+	styleCode    = "36"
+	styleLink    = "4;34"
+	styleBullet  = "36"
+	styleCodeBox = "33"
+)
+
+// Markdown 语法的匹配规则，与 HTML 报告相同，只覆盖报告和AI分析结果中常见的写法
+var (
+	headingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	listItemPattern  = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	rulePattern      = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,}|={3,})$`)
+	tableRulePattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	boldPattern      = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	italicPattern    = regexp.MustCompile(`(^|[^*\w])\*([^*\s][^*]*?)\*`)
+	linkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	fencePattern     = regexp.MustCompile("^\\s*(```|~~~)")
+	quotePattern     = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	ansiPattern      = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	sparklinePattern = regexp.MustCompile(`[▁▂▃▄▅▆▇█]+`)
+)
+
+// Options 终端渲染选项
+type Options struct {
+	Width int  // 终端宽度，用于分隔线，为 0 时使用 80
+	Color bool // 是否输出 ANSI 颜色和样式
+}
+
+// renderer 渲染状态
+type renderer struct {
+	opts  Options
+	out   strings.Builder
+	table []string // 尚未输出的表格行
+	code  bool     // 是否在代码块中
+}
+
+// Render 将 Markdown 渲染为适合终端显示的文本：标题、列表、表格、代码和行内样式，
+// 迷你图字符按高度着色。关闭颜色时只调整版式
+func Render(markdown string, opts Options) string {
+	if opts.Width <= 0 {
+		opts.Width = defaultWidth
+	}
+	r := &renderer{opts: opts}
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		r.line(line)
+	}
+	r.flushTable()
+	return strings.TrimRight(r.out.String(), "\n") + "\n"
+}
+
+// line 渲染一行
+func (r *renderer) line(line string) {
+	if fencePattern.MatchString(line) {
+		r.flushTable()
+		r.code = !r.code
+		return
+	}
+	if r.code {
+		r.writeln(r.style(styleDim, "│ ") + r.style(styleCodeBox, line))
+		return
+	}
+
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "|") {
+		r.table = append(r.table, trimmed)
+		return
+	}
+	r.flushTable()
+
+	if m := headingPattern.FindStringSubmatch(line); m != nil {
+		r.heading(len(m[1]), m[2])
+		return
+	}
+	if rulePattern.MatchString(line) {
+		r.writeln(r.style(styleDim, strings.Repeat("─", r.ruleWidth())))
+		return
+	}
+	if m := quotePattern.FindStringSubmatch(line); m != nil {
+		r.writeln(r.style(styleDim, "│ ") + r.style(styleItalic, r.inline(m[1])))
+		return
+	}
+	if m := listItemPattern.FindStringSubmatch(line); m != nil {
+		indent := len(strings.ReplaceAll(m[1], "\t", "  "))
+		marker := m[2]
+		if !unicode.IsDigit(rune(marker[0])) {
+			marker = []string{"•", "◦", "▪"}[(indent/2)%3]
+		}
+		r.writeln(strings.Repeat(" ", indent) + r.style(styleBullet, marker) + " " + r.inline(m[3]))
+		return
+	}
+	r.writeln(r.inline(line))
+}
+
+// heading 渲染标题，一级和二级标题下方加分隔线
+func (r *renderer) heading(level int, text string) {
+	text = r.inline(text)
+	switch level {
+	case 1:
+		r.writeln(r.style(styleH1, text))
+		r.writeln(r.style(styleH1, strings.Repeat("═", min(DisplayWidth(text), r.ruleWidth()))))
+	case 2:
+		r.writeln(r.style(styleH2, text))
+		r.writeln(r.style(styleDim, strings.Repeat("─", min(DisplayWidth(text), r.ruleWidth()))))
+	default:
+		r.writeln(r.style(styleH3, text))
+	}
+}
+
+// flushTable 输出缓存的表格，列宽按显示宽度对齐，第二行为分隔行时第一行作为表头
+func (r *renderer) flushTable() {
+	if len(r.table) == 0 {
+		return
+	}
+	lines := r.table
+	r.table = nil
+
+	var header []string
+	var aligns []byte
+	if len(lines) > 1 && tableRulePattern.MatchString(lines[1]) {
+		header = r.cells(lines[0])
+		for _, cell := range splitRow(lines[1]) {
+			switch {
+			case strings.HasSuffix(cell, ":") && strings.HasPrefix(cell, ":"):
+				aligns = append(aligns, 'c')
+			case strings.HasSuffix(cell, ":"):
+				aligns = append(aligns, 'r')
+			default:
+				aligns = append(aligns, 'l')
+			}
+		}
+		lines = lines[2:]
+	}
+	var rows [][]string
+	for _, line := range lines {
+		rows = append(rows, r.cells(line))
+	}
+
+	// 计算列宽
+	var widths []int
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], DisplayWidth(cell))
+		}
+	}
+
+	border := func(left, middle, right string) string {
+		parts := make([]string, len(widths))
+		for i, w := range widths {
+			parts[i] = strings.Repeat("─", w+2)
+		}
+		return r.style(styleDim, left+strings.Join(parts, middle)+right)
+	}
+	row := func(cells []string, bold bool) string {
+		var b strings.Builder
+		b.WriteString(r.style(styleDim, "│"))
+		for i, w := range widths {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			align := byte('l')
+			if i < len(aligns) {
+				align = aligns[i]
+			}
+			if bold {
+				cell = r.style(styleBold, cell)
+			}
+			b.WriteString(" " + pad(cell, w, align) + " " + r.style(styleDim, "│"))
+		}
+		return b.String()
+	}
+
+	r.writeln(border("┌", "┬", "┐"))
+	if header != nil {
+		r.writeln(row(header, true))
+		r.writeln(border("├", "┼", "┤"))
+	}
+	for _, cells := range rows {
+		r.writeln(row(cells, false))
+	}
+	r.writeln(border("└", "┴", "┘"))
+}
+
+// cells 拆分并渲染表格行中的单元格
+func (r *renderer) cells(line string) []string {
+	cells := splitRow(line)
+	for i, cell := range cells {
+		cells[i] = r.inline(cell)
+	}
+	return cells
+}
+
+// splitRow 拆分表格行，去掉首尾的 |
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+	cells := strings.Split(line, "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return cells
+}
+
+// inline 渲染行内代码、链接、粗体、斜体和迷你图，代码中的内容保持原样
+func (r *renderer) inline(text string) string {
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		if i%2 == 1 && i < len(parts)-1 {
+			parts[i] = r.style(styleCode, part)
+			continue
+		}
+		part = linkPattern.ReplaceAllStringFunc(part, func(s string) string {
+			m := linkPattern.FindStringSubmatch(s)
+			if m[1] == m[2] {
+				return r.style(styleLink, m[1])
+			}
+			return r.style(styleLink, m[1]) + r.style(styleDim, " ("+m[2]+")")
+		})
+		part = boldPattern.ReplaceAllStringFunc(part, func(s string) string {
+			m := boldPattern.FindStringSubmatch(s)
+			return r.style(styleBold, m[1]+m[2])
+		})
+		part = italicPattern.ReplaceAllStringFunc(part, func(s string) string {
+			m := italicPattern.FindStringSubmatch(s)
+			return m[1] + r.style(styleItalic, m[2])
+		})
+		if r.opts.Color {
+			part = sparklinePattern.ReplaceAllStringFunc(part, colorSparkline)
+		}
+		parts[i] = part
+	}
+	// 未闭合的反引号按原样保留
+	if len(parts)%2 == 0 {
+		last := len(parts) - 1
+		return strings.Join(parts[:last], "") + "`" + parts[last]
+	}
+	return strings.Join(parts, "")
+}
+
+// style 为文本加上 ANSI 样式，嵌套的样式结束后恢复外层样式
+func (r *renderer) style(codes, text string) string {
+	if !r.opts.Color || text == "" {
+		return text
+	}
+	start := "\x1b[" + codes + "m"
+	return start + strings.ReplaceAll(text, reset, reset+start) + reset
+}
+
+// writeln 输出一行
+func (r *renderer) writeln(line string) {
+	r.out.WriteString(line)
+	r.out.WriteByte('\n')
+}
+
+// ruleWidth 分隔线的宽度，最多 80 列
+func (r *renderer) ruleWidth() int {
+	return min(r.opts.Width, defaultWidth)
+}
+
+// pad 按显示宽度填充单元格
+func pad(text string, w int, align byte) string {
+	gap := w - DisplayWidth(text)
+	if gap <= 0 {
+		return text
+	}
+	switch align {
+	case 'r':
+		return strings.Repeat(" ", gap) + text
+	case 'c':
+		return strings.Repeat(" ", gap/2) + text + strings.Repeat(" ", gap-gap/2)
+	default:
+		return text + strings.Repeat(" ", gap)
+	}
+}
+
+// DisplayWidth 返回文本在终端中占用的列数，忽略 ANSI 样式，中日韩文字和全角字符占两列
+func DisplayWidth(text string) int {
+	total := 0
+	for _, r := range ansiPattern.ReplaceAllString(text, "") {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+			// 组合字符和变体选择符不占宽度
+		case width.LookupRune(r).Kind() == width.EastAsianWide || width.LookupRune(r).Kind() == width.EastAsianFullwidth:
+			total += 2
+		default:
+			total++
+		}
+	}
+	return total
+}
@@ -0,0 +1,108 @@
+package term
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRenderPlain 测试关闭颜色时的版式：标题、列表、表格和代码块
+func TestRenderPlain(t *testing.T) {
+	markdown := strings.Join([]string{
+		"# 开发者画像",
+		"",
+		"## Stats",
+		"- **Commits**: 12",
+		"  - `main.go`: 3",
+		"1. first",
+		"",
+		"| 意图 | Commits |",
+		"|---|---:|",
+		"| feature | 8 |",
+		"| 修复 | 12 |",
+		"",
+		"```go",
+		"**not bold**",
+		"```",
+		"> see [docs](https://example.com)",
+		"---",
+	}, "\n")
+
+	got := Render(markdown, Options{Width: 40})
+	want := strings.Join([]string{
+		"开发者画像",
+		"══════════",
+		"",
+		"Stats",
+		"─────",
+		"• Commits: 12",
+		"  ◦ main.go: 3",
+		"1. first",
+		"",
+		"┌─────────┬─────────┐",
+		"│ 意图    │ Commits │",
+		"├─────────┼─────────┤",
+		"│ feature │       8 │",
+		"│ 修复    │      12 │",
+		"└─────────┴─────────┘",
+		"",
+		"│ **not bold**",
+		"│ see docs (https://example.com)",
+		strings.Repeat("─", 40),
+		"",
+	}, "\n")
+	if got != want {
+		t.Errorf("渲染结果不正确:\n%s\n期望:\n%s", got, want)
+	}
+}
+
+// TestRenderColor 测试开启颜色时输出 ANSI 样式，嵌套样式结束后恢复外层样式
+func TestRenderColor(t *testing.T) {
+	got := Render("## Hello `code` world\nActivity: ▁█", Options{Color: true})
+
+	if !strings.Contains(got, "\x1b[1;36mHello \x1b[36mcode\x1b[0m\x1b[1;36m world\x1b[0m") {
+		t.Errorf("标题中的行内代码样式不正确: %q", got)
+	}
+	if !strings.Contains(got, "\x1b[38;5;240m▁\x1b[38;5;118m█\x1b[0m") {
+		t.Errorf("迷你图应按高度着色: %q", got)
+	}
+	if DisplayWidth(got[:strings.Index(got, "\n")]) != len("Hello code world") {
+		t.Errorf("显示宽度应忽略 ANSI 样式: %q", got)
+	}
+}
+
+// TestDisplayWidth 测试中文、全角字符和组合字符的显示宽度
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"abc", 3},
+		{"提交", 4},
+		{"ＡＢ", 4},
+		{"é", 1},
+		{"\x1b[1mbold\x1b[0m", 4},
+	}
+	for _, tt := range tests {
+		if got := DisplayWidth(tt.text); got != tt.want {
+			t.Errorf("DisplayWidth(%q) = %d, 期望: %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+// TestSparkline 测试迷你图的高度映射
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   string
+	}{
+		{[]int{0, 1, 7, 14}, "▁▂▅█"},
+		{[]int{0, 0}, "▁▁"},
+		{[]int{1, 100}, "▂█"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := Sparkline(tt.values); got != tt.want {
+			t.Errorf("Sparkline(%v) = %s, 期望: %s", tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package term
+
+import "os"
+
+// terminalSize 在不支持 ioctl 的平台上返回 0，由调用方使用环境变量或默认值
+func terminalSize(_ *os.File) (int, int) {
+	return 0, 0
+}
@@ -0,0 +1,42 @@
+package term
+
+import (
+	"slices"
+	"strings"
+)
+
+// sparkBlocks 迷你图从低到高的字符
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkColors 迷你图各高度的 256 色颜色代码，最低一级为灰色表示没有数据
+var sparkColors = []string{"38;5;240", "38;5;22", "38;5;28", "38;5;34", "38;5;40", "38;5;46", "38;5;82", "38;5;118"}
+
+// Sparkline 将数值序列转换为迷你图，0 使用最低的字符，其余按最大值等比映射到更高的字符
+func Sparkline(values []int) string {
+	maxValue := 0
+	for _, v := range values {
+		maxValue = max(maxValue, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if v > 0 && maxValue > 0 {
+			// 有数据时至少为第二级，与没有数据区分
+			level = max(1, (v*(len(sparkBlocks)-1)+maxValue-1)/maxValue)
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// colorSparkline 按高度为迷你图的每个字符着色
+func colorSparkline(spark string) string {
+	var b strings.Builder
+	for _, r := range spark {
+		level := max(0, slices.Index(sparkBlocks, r))
+		b.WriteString("\x1b[" + sparkColors[level] + "m" + string(r))
+	}
+	b.WriteString(reset)
+	return b.String()
+}
@@ -0,0 +1,78 @@
+// Package term 在终端中显示报告：检测终端、渲染带样式的 Markdown，并在内容超过一屏时使用分页程序
+package term
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// 默认的终端大小
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// IsTerminal 判断文件是否为终端，输出被重定向到文件或管道时返回 false
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// ColorEnabled 判断是否输出颜色，遵循 NO_COLOR 约定，TERM=dumb 时同样不输出颜色
+func ColorEnabled() bool {
+	return os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+}
+
+// Size 返回终端的列数和行数，无法获取时使用 COLUMNS 和 LINES 环境变量，默认 80×24
+func Size(f *os.File) (int, int) {
+	width, height := terminalSize(f)
+	if width <= 0 {
+		width = envInt("COLUMNS", defaultWidth)
+	}
+	if height <= 0 {
+		height = envInt("LINES", defaultHeight)
+	}
+	return width, height
+}
+
+// Page 将内容输出到终端，超过一屏时通过分页程序显示。
+// 分页程序由 PAGER 环境变量指定，默认为 less；无法启动时直接输出
+func Page(content string, out *os.File) error {
+	_, height := Size(out)
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less"}
+	}
+	if strings.Count(content, "\n") < height-1 || pager[0] == "cat" {
+		_, err := io.WriteString(out, content)
+		return err
+	}
+
+	cmd := exec.Command(pager[0], pager[1:]...) //nolint:gosec // 分页程序由用户通过环境变量指定
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	// 与 git 相同：保留颜色，内容不足一屏时直接退出
+	if os.Getenv("LESS") == "" {
+		cmd.Env = append(os.Environ(), "LESS=FRX")
+	}
+	if err := cmd.Start(); err != nil {
+		_, err = io.WriteString(out, content)
+		return err
+	}
+	return cmd.Wait()
+}
+
+// envInt 读取正整数环境变量，无效时返回默认值
+func envInt(name string, fallback int) int {
+	if value, err := strconv.Atoi(os.Getenv(name)); err == nil && value > 0 {
+		return value
+	}
+	return fallback
+}
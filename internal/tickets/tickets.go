@@ -0,0 +1,276 @@
+package tickets
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// 内置的工单系统名称
+const (
+	// TrackerJira Jira 风格的工单号，如 PAY-1234
+	TrackerJira = "jira"
+	// TrackerGitHub GitHub 风格的 issue 编号，如 #567
+	TrackerGitHub = "github"
+)
+
+// Pattern 工单引用的匹配规则
+type Pattern struct {
+	Name        string         // 工单系统名称
+	Regexp      *regexp.Regexp // 匹配规则，第一个捕获组为工单号
+	URLTemplate string         // 链接模板，{id} 替换为工单号
+}
+
+// Reference 提交中的一个工单引用
+type Reference struct {
+	ID      string `json:"id"`
+	Tracker string `json:"tracker"`
+	URL     string `json:"url,omitempty"`
+	Closes  bool   `json:"closes"` // 是否通过 fixes/closes 等关键字关闭工单
+}
+
+// Ticket 按工单分组的提交
+type Ticket struct {
+	ID       string    `json:"id"`
+	Tracker  string    `json:"tracker"`
+	URL      string    `json:"url,omitempty"`
+	Closed   bool      `json:"closed"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Repos    []string  `json:"repos"`
+	Hashes   []string  `json:"hashes"`
+	Subjects []string  `json:"subjects"`
+}
+
+// 默认的匹配规则
+var (
+	jiraPattern   = regexp.MustCompile(`\b([A-Z][A-Z0-9]{1,9}-[1-9]\d*)\b`)
+	githubPattern = regexp.MustCompile(`(?:^|[^\w&/])#([1-9]\d*)\b`)
+	closesPattern = regexp.MustCompile(`(?i)\b(?:fix(?:e[sd])?|close[sd]?|resolve[sd]?)\s*:?\s*#?$`)
+)
+
+// jiraExcludes 形如工单号但实际不是工单的常见写法
+var jiraExcludes = map[string]bool{
+	"UTF": true, "SHA": true, "ISO": true, "RFC": true, "CVE": true, "HTTP": true,
+	"TLS": true, "SSL": true, "MD": true, "WIN": true, "GPT": true, "ES": true,
+}
+
+// DefaultPatterns 返回内置的匹配规则
+func DefaultPatterns() []Pattern {
+	return []Pattern{
+		{Name: TrackerJira, Regexp: jiraPattern},
+		{Name: TrackerGitHub, Regexp: githubPattern},
+	}
+}
+
+// ParsePattern 解析 "名称=正则表达式" 形式的自定义规则
+func ParsePattern(spec string) (Pattern, error) {
+	name, expr, ok := strings.Cut(spec, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || expr == "" {
+		return Pattern{}, fmt.Errorf("invalid issue pattern %q, expected name=regex", spec)
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return Pattern{}, fmt.Errorf("invalid issue pattern %q: %w", spec, err)
+	}
+	return Pattern{Name: name, Regexp: re}, nil
+}
+
+// Extractor 从提交中提取工单引用
+type Extractor struct {
+	patterns []Pattern
+	remotes  map[string]string // 仓库路径到远程仓库网页地址的映射，用于生成仓库内 issue 的链接
+}
+
+// NewExtractor 创建工单引用提取器。patterns 为自定义规则，会追加在内置规则之后，
+// 同名规则覆盖内置规则；urlTemplates 为工单系统名称到链接模板的映射
+func NewExtractor(patterns []Pattern, urlTemplates map[string]string) *Extractor {
+	merged := DefaultPatterns()
+	for _, custom := range patterns {
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == custom.Name {
+				merged[i] = custom
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, custom)
+		}
+	}
+
+	for i := range merged {
+		if tmpl, ok := urlTemplates[merged[i].Name]; ok {
+			merged[i].URLTemplate = tmpl
+		}
+	}
+
+	return &Extractor{patterns: merged}
+}
+
+// SetRemotes 设置仓库路径到远程仓库网页地址（如 https://github.com/org/repo）的映射。
+// #N 形式的 issue 编号只在所属仓库内有效，有远程地址时链接到该仓库的 issue，否则使用链接模板
+func (e *Extractor) SetRemotes(remotes map[string]string) {
+	e.remotes = remotes
+}
+
+// repoLocal 判断工单系统的编号是否只在单个仓库内有效
+func repoLocal(tracker string) bool {
+	return tracker == TrackerGitHub
+}
+
+// Extract 从提交标题、正文和分支名中提取工单引用，结果按首次出现的顺序去重
+func (e *Extractor) Extract(commit git.CommitInfo) []Reference {
+	var refs []Reference
+	index := make(map[string]int)
+
+	sources := append([]string{commit.Message, commit.Body}, commit.Branches...)
+	for _, text := range sources {
+		for _, pattern := range e.patterns {
+			for _, match := range pattern.Regexp.FindAllStringSubmatchIndex(text, -1) {
+				id, start := matchedID(text, match)
+				if pattern.Name == TrackerJira && jiraExcludes[strings.SplitN(id, "-", 2)[0]] {
+					continue
+				}
+				if pattern.Name == TrackerGitHub {
+					id = "#" + id
+				}
+
+				closes := closesPattern.MatchString(lastWords(text[:start]))
+				key := pattern.Name + ":" + id
+				if i, ok := index[key]; ok {
+					refs[i].Closes = refs[i].Closes || closes
+					continue
+				}
+
+				url := expandURL(pattern.URLTemplate, id)
+				if remote := e.remotes[commit.RepoPath]; repoLocal(pattern.Name) && remote != "" {
+					url = issueURL(remote, id)
+				}
+				index[key] = len(refs)
+				refs = append(refs, Reference{
+					ID:      id,
+					Tracker: pattern.Name,
+					URL:     url,
+					Closes:  closes,
+				})
+			}
+		}
+	}
+
+	return refs
+}
+
+// matchedID 返回匹配到的工单号及其起始位置，有捕获组时使用第一个捕获组
+func matchedID(text string, match []int) (string, int) {
+	if len(match) >= 4 && match[2] >= 0 {
+		return text[match[2]:match[3]], match[2]
+	}
+	return text[match[0]:match[1]], match[0]
+}
+
+// lastWords 返回文本末尾的一小段，用于判断工单号前是否有关闭关键字
+func lastWords(text string) string {
+	const window = 12
+	if len(text) > window {
+		text = text[len(text)-window:]
+	}
+	return text
+}
+
+// expandURL 根据链接模板生成工单链接
+func expandURL(tmpl, id string) string {
+	if tmpl == "" {
+		return ""
+	}
+	url := strings.ReplaceAll(tmpl, "{id}", id)
+	return strings.ReplaceAll(url, "{number}", strings.TrimPrefix(id, "#"))
+}
+
+// issueURL 根据远程仓库网页地址生成 issue 链接，GitLab 的 issue 位于 /-/issues 下
+func issueURL(remote, id string) string {
+	number := strings.TrimPrefix(id, "#")
+	if strings.Contains(strings.ToLower(remote), "gitlab") {
+		return remote + "/-/issues/" + number
+	}
+	return remote + "/issues/" + number
+}
+
+// Group 按工单对提交进行分组，结果按提交数量从多到少排列。
+// 只在仓库内有效的编号（如 #12）按仓库分别分组，不同仓库的同号 issue 是不同的工单
+func Group(commits []git.CommitInfo, extractor *Extractor) []Ticket {
+	tickets := make(map[string]*Ticket)
+	var order []string
+
+	for _, commit := range commits {
+		for _, ref := range extractor.Extract(commit) {
+			key := ref.Tracker + ":" + ref.ID
+			if repoLocal(ref.Tracker) {
+				key = ref.Tracker + ":" + commit.RepoPath + ":" + ref.ID
+			}
+			ticket, ok := tickets[key]
+			if !ok {
+				ticket = &Ticket{ID: ref.ID, Tracker: ref.Tracker, URL: ref.URL, From: commit.Date, To: commit.Date}
+				tickets[key] = ticket
+				order = append(order, key)
+			}
+
+			ticket.Closed = ticket.Closed || ref.Closes
+			ticket.Hashes = append(ticket.Hashes, commit.Hash)
+			ticket.Subjects = append(ticket.Subjects, commit.Message)
+			if commit.Date.Before(ticket.From) {
+				ticket.From = commit.Date
+			}
+			if commit.Date.After(ticket.To) {
+				ticket.To = commit.Date
+			}
+			if commit.RepoPath != "" {
+				repo := filepath.Base(commit.RepoPath)
+				if !containsString(ticket.Repos, repo) {
+					ticket.Repos = append(ticket.Repos, repo)
+				}
+			}
+		}
+	}
+
+	result := make([]Ticket, 0, len(order))
+	for _, key := range order {
+		result = append(result, *tickets[key])
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if len(result[i].Hashes) != len(result[j].Hashes) {
+			return len(result[i].Hashes) > len(result[j].Hashes)
+		}
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+// ByCommit 返回提交哈希到工单号列表的映射
+func ByCommit(tickets []Ticket) map[string][]string {
+	refs := make(map[string][]string)
+	for _, ticket := range tickets {
+		for _, hash := range ticket.Hashes {
+			refs[hash] = append(refs[hash], ticket.ID)
+		}
+	}
+	return refs
+}
+
+// containsString 判断切片是否包含指定字符串
+func containsString(items []string, item string) bool {
+	for _, s := range items {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
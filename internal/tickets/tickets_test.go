@@ -0,0 +1,124 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// TestExtract 测试从提交中提取工单引用
+func TestExtract(t *testing.T) {
+	extractor := NewExtractor(nil, map[string]string{
+		TrackerJira:   "https://jira.example.com/browse/{id}",
+		TrackerGitHub: "https://github.com/acme/app/issues/{number}",
+	})
+
+	commit := git.CommitInfo{
+		Message:  "PAY-1234: retry failed payouts, fixes #12",
+		Body:     "Follow-up of #7. Uses UTF-8 and SHA-256 checksums.\nRefs PAY-1234",
+		Branches: []string{"feature/PAY-1300-payout-retry"},
+	}
+
+	refs := extractor.Extract(commit)
+	expected := []Reference{
+		{ID: "PAY-1234", Tracker: TrackerJira, URL: "https://jira.example.com/browse/PAY-1234"},
+		{ID: "#12", Tracker: TrackerGitHub, URL: "https://github.com/acme/app/issues/12", Closes: true},
+		{ID: "#7", Tracker: TrackerGitHub, URL: "https://github.com/acme/app/issues/7"},
+		{ID: "PAY-1300", Tracker: TrackerJira, URL: "https://jira.example.com/browse/PAY-1300"},
+	}
+
+	if len(refs) != len(expected) {
+		t.Fatalf("应提取出 %d 个引用, 得到: %+v", len(expected), refs)
+	}
+	for i := range expected {
+		if refs[i] != expected[i] {
+			t.Errorf("第 %d 个引用应为 %+v, 得到: %+v", i, expected[i], refs[i])
+		}
+	}
+}
+
+// TestCustomPattern 测试自定义匹配规则
+func TestCustomPattern(t *testing.T) {
+	pattern, err := ParsePattern(`linear=\b(ENG-\d+)\b`)
+	if err != nil {
+		t.Fatalf("解析规则失败: %v", err)
+	}
+	if _, err := ParsePattern("missing-regex"); err == nil {
+		t.Error("缺少正则表达式时应返回错误")
+	}
+
+	extractor := NewExtractor([]Pattern{pattern}, map[string]string{"linear": "https://linear.app/acme/issue/{id}"})
+	refs := extractor.Extract(git.CommitInfo{Message: "ENG-42 tidy up"})
+
+	found := false
+	for _, ref := range refs {
+		if ref.Tracker == "linear" && ref.ID == "ENG-42" && ref.URL == "https://linear.app/acme/issue/ENG-42" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("应通过自定义规则提取出 ENG-42, 得到: %+v", refs)
+	}
+}
+
+// TestGroup 测试按工单分组提交
+func TestGroup(t *testing.T) {
+	day := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	commits := []git.CommitInfo{
+		{Hash: "a1", Message: "PAY-1 start", Date: day, RepoPath: "/work/pay"},
+		{Hash: "a2", Message: "PAY-1 finish, closes #3", Date: day.AddDate(0, 0, 2), RepoPath: "/work/pay"},
+		{Hash: "a3", Message: "PAY-2 docs", Date: day.AddDate(0, 0, 1), RepoPath: "/work/docs"},
+	}
+
+	tickets := Group(commits, NewExtractor(nil, nil))
+	if len(tickets) != 3 {
+		t.Fatalf("应分组出3个工单, 得到: %d", len(tickets))
+	}
+
+	first := tickets[0]
+	if first.ID != "PAY-1" || len(first.Hashes) != 2 || !first.To.Equal(day.AddDate(0, 0, 2)) {
+		t.Errorf("第一个工单应为包含2个提交的 PAY-1, 得到: %+v", first)
+	}
+	if refs := ByCommit(tickets)["a2"]; len(refs) != 2 {
+		t.Errorf("提交 a2 应关联2个工单, 得到: %v", refs)
+	}
+}
+
+// TestGroupRepoLocal 测试不同仓库中同号的 issue 分别分组，并链接到各自仓库
+func TestGroupRepoLocal(t *testing.T) {
+	day := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	commits := []git.CommitInfo{
+		{Hash: "a1", Message: "fixes #12", Date: day, RepoPath: "/work/api"},
+		{Hash: "b1", Message: "closes #12", Date: day, RepoPath: "/work/web"},
+		{Hash: "b2", Message: "follow up on #12", Date: day, RepoPath: "/work/web"},
+		{Hash: "c1", Message: "see #7", Date: day, RepoPath: "/work/legacy"},
+	}
+	extractor := NewExtractor(nil, map[string]string{TrackerGitHub: "https://tracker.example.com/{number}"})
+	extractor.SetRemotes(map[string]string{
+		"/work/api": "https://github.com/acme/api",
+		"/work/web": "https://gitlab.com/acme/web",
+	})
+
+	urls := make(map[string]string)
+	for _, ticket := range Group(commits, extractor) {
+		if len(ticket.Repos) != 1 {
+			t.Errorf("仓库内的 issue 不应跨仓库合并: %+v", ticket)
+			continue
+		}
+		urls[ticket.Repos[0]+ticket.ID] = ticket.URL
+	}
+	want := map[string]string{
+		"api#12":   "https://github.com/acme/api/issues/12",
+		"web#12":   "https://gitlab.com/acme/web/-/issues/12",
+		"legacy#7": "https://tracker.example.com/7",
+	}
+	for key, url := range want {
+		if urls[key] != url {
+			t.Errorf("%s 的链接应为 %s, 得到 %q", key, url, urls[key])
+		}
+	}
+	if len(urls) != len(want) {
+		t.Errorf("应分组出%d个工单, 得到: %v", len(want), urls)
+	}
+}
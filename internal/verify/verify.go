@@ -0,0 +1,480 @@
+package verify
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// ClaimKind 表示声明的类型
+type ClaimKind string
+
+const (
+	// ClaimTechnology 技术、框架或工具
+	ClaimTechnology ClaimKind = "technology"
+	// ClaimRepo 项目或仓库
+	ClaimRepo ClaimKind = "repo"
+	// ClaimNumber 数字指标，如百分比、倍数
+	ClaimNumber ClaimKind = "number"
+	// ClaimDate 日期或年份
+	ClaimDate ClaimKind = "date"
+)
+
+// maxEvidence 每条声明最多记录的证据提交数
+const maxEvidence = 10
+
+// Claim 从AI分析结果中提取的一条声明
+type Claim struct {
+	Kind      ClaimKind `json:"kind"`
+	Text      string    `json:"text"`      // 分析结果中的原文
+	Supported bool      `json:"supported"` // 是否能在收集的数据中找到依据
+	Evidence  []string  `json:"evidence"`  // 支持该声明的提交哈希
+	Sources   []string  `json:"sources"`   // 其他依据来源，如依赖清单文件
+	offset    int       // 在分析结果中首次出现的位置
+}
+
+// Result 核查结果
+type Result struct {
+	Claims []Claim `json:"claims"`
+}
+
+// Unsupported 返回没有依据的声明
+func (r *Result) Unsupported() []Claim {
+	if r == nil {
+		return nil
+	}
+	var claims []Claim
+	for _, claim := range r.Claims {
+		if !claim.Supported {
+			claims = append(claims, claim)
+		}
+	}
+	return claims
+}
+
+// Evidence 用于核查的已收集数据
+type Evidence struct {
+	Commits   []git.CommitInfo
+	Manifests map[string]map[string]string // 仓库路径 -> 清单文件名 -> 内容
+}
+
+// Verify 提取分析结果中的技术、仓库、数字和日期声明，并与收集到的数据进行交叉核对
+func Verify(analysis string, evidence Evidence) *Result {
+	index := newEvidenceIndex(evidence)
+
+	var claims []Claim
+	claims = append(claims, verifyTechnologies(analysis, index)...)
+	claims = append(claims, verifyRepos(analysis, index)...)
+	claims = append(claims, verifyNumbers(analysis, index)...)
+	claims = append(claims, verifyDates(analysis, index)...)
+
+	// 按在原文中出现的顺序排列，保证输出稳定
+	sort.SliceStable(claims, func(i, j int) bool {
+		return claims[i].offset < claims[j].offset
+	})
+
+	return &Result{Claims: claims}
+}
+
+// Annotate 在分析结果中每条无依据声明首次出现的位置后插入标记
+func Annotate(analysis string, result *Result, marker string) string {
+	unsupported := result.Unsupported()
+	if len(unsupported) == 0 {
+		return analysis
+	}
+
+	// 从后往前插入，避免位置偏移
+	sort.SliceStable(unsupported, func(i, j int) bool {
+		return unsupported[i].offset > unsupported[j].offset
+	})
+
+	for _, claim := range unsupported {
+		end := claim.offset + len(claim.Text)
+		if end > len(analysis) || analysis[claim.offset:end] != claim.Text {
+			continue
+		}
+		analysis = analysis[:end] + " " + marker + analysis[end:]
+	}
+
+	return analysis
+}
+
+// evidenceIndex 预处理后的核查数据
+type evidenceIndex struct {
+	commits   []commitText
+	manifests []manifestText
+	repos     []string
+	from, to  time.Time
+}
+
+// commitText 单个提交的可检索文本
+type commitText struct {
+	hash  string
+	date  time.Time
+	text  string   // 小写的消息和分支名
+	files []string // 小写的变更文件路径
+}
+
+// manifestText 单个依赖清单的可检索文本
+type manifestText struct {
+	source string
+	text   string
+}
+
+// newEvidenceIndex 构建核查数据的索引
+func newEvidenceIndex(evidence Evidence) *evidenceIndex {
+	index := &evidenceIndex{}
+	repoSet := make(map[string]bool)
+
+	for _, commit := range evidence.Commits {
+		files := make([]string, 0, len(commit.ChangedFiles))
+		for _, file := range commit.ChangedFiles {
+			files = append(files, strings.ToLower(file))
+		}
+		index.commits = append(index.commits, commitText{
+			hash:  commit.Hash,
+			date:  commit.Date,
+			text:  strings.ToLower(commit.Message + " " + strings.Join(commit.Branches, " ")),
+			files: files,
+		})
+
+		if commit.RepoPath != "" && !repoSet[commit.RepoPath] {
+			repoSet[commit.RepoPath] = true
+			index.repos = append(index.repos, commit.RepoPath)
+		}
+
+		if index.from.IsZero() || commit.Date.Before(index.from) {
+			index.from = commit.Date
+		}
+		if commit.Date.After(index.to) {
+			index.to = commit.Date
+		}
+	}
+
+	// 按仓库和文件名排序，保证证据顺序稳定
+	repoPaths := make([]string, 0, len(evidence.Manifests))
+	for repoPath := range evidence.Manifests {
+		repoPaths = append(repoPaths, repoPath)
+	}
+	sort.Strings(repoPaths)
+	for _, repoPath := range repoPaths {
+		names := make([]string, 0, len(evidence.Manifests[repoPath]))
+		for name := range evidence.Manifests[repoPath] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			index.manifests = append(index.manifests, manifestText{
+				source: filepath.Base(repoPath) + "/" + name,
+				text:   strings.ToLower(evidence.Manifests[repoPath][name]),
+			})
+		}
+	}
+
+	return index
+}
+
+// technology 可识别的技术及其在代码库中的痕迹
+type technology struct {
+	pattern  *regexp.Regexp
+	keywords []string // 以 "." 开头的关键字匹配文件扩展名，其余匹配文本内容
+}
+
+// technologies 可识别的技术列表
+var technologies = []technology{
+	{regexp.MustCompile(`\bGo(lang)?\b|\bgolang\b`), []string{".go", "go.mod", "golang"}},
+	{regexp.MustCompile(`(?i)\bpython\b`), []string{".py", "requirements.txt", "pyproject.toml", "python"}},
+	{regexp.MustCompile(`\bJava\b`), []string{".java", "pom.xml", "build.gradle"}},
+	{regexp.MustCompile(`(?i)\bjavascript\b`), []string{".js", ".mjs", ".jsx", "package.json"}},
+	{regexp.MustCompile(`(?i)\btypescript\b`), []string{".ts", ".tsx", "tsconfig.json", "typescript"}},
+	{regexp.MustCompile(`\bRust\b`), []string{".rs", "cargo.toml"}},
+	{regexp.MustCompile(`\bRuby\b`), []string{".rb", "gemfile"}},
+	{regexp.MustCompile(`\bPHP\b`), []string{".php", "composer.json"}},
+	{regexp.MustCompile(`(?i)\bkotlin\b`), []string{".kt", ".kts", "kotlin"}},
+	{regexp.MustCompile(`\bSwift\b`), []string{".swift"}},
+	{regexp.MustCompile(`C#`), []string{".cs", ".csproj"}},
+	{regexp.MustCompile(`C\+\+`), []string{".cpp", ".cc", ".hpp", "cmakelists.txt"}},
+	{regexp.MustCompile(`(?i)\breact\b`), []string{".jsx", ".tsx", "react"}},
+	{regexp.MustCompile(`(?i)\bvue(\.js)?\b`), []string{".vue", "vue"}},
+	{regexp.MustCompile(`(?i)\bangular\b`), []string{"angular"}},
+	{regexp.MustCompile(`(?i)\bnext\.js\b|\bnextjs\b`), []string{"next.config", "\"next\""}},
+	{regexp.MustCompile(`(?i)\bnode(\.js|js)\b`), []string{"package.json", "node"}},
+	{regexp.MustCompile(`(?i)\bdjango\b`), []string{"django", "manage.py"}},
+	{regexp.MustCompile(`(?i)\bflask\b`), []string{"flask"}},
+	{regexp.MustCompile(`(?i)\bfastapi\b`), []string{"fastapi"}},
+	{regexp.MustCompile(`(?i)\bspring( boot)?\b`), []string{"spring"}},
+	{regexp.MustCompile(`\bGin\b`), []string{"gin-gonic", "gin"}},
+	{regexp.MustCompile(`\bExpress(\.js)?\b`), []string{"express"}},
+	{regexp.MustCompile(`(?i)\bgrpc\b`), []string{"grpc", ".proto"}},
+	{regexp.MustCompile(`(?i)\bgraphql\b`), []string{"graphql", ".graphql", ".gql"}},
+	{regexp.MustCompile(`(?i)\bkafka\b`), []string{"kafka"}},
+	{regexp.MustCompile(`(?i)\brabbitmq\b`), []string{"rabbitmq", "amqp"}},
+	{regexp.MustCompile(`(?i)\bredis\b`), []string{"redis"}},
+	{regexp.MustCompile(`(?i)\bpostgres(ql)?\b`), []string{"postgres", "pgx", "psycopg"}},
+	{regexp.MustCompile(`(?i)\bmysql\b`), []string{"mysql"}},
+	{regexp.MustCompile(`(?i)\bmongo(db)?\b`), []string{"mongo"}},
+	{regexp.MustCompile(`(?i)\belastic ?search\b`), []string{"elastic"}},
+	{regexp.MustCompile(`(?i)\bdocker\b`), []string{"docker"}},
+	{regexp.MustCompile(`(?i)\bkubernetes\b|\bk8s\b`), []string{"kubernetes", "k8s", "kubectl", "helm", "kustomization"}},
+	{regexp.MustCompile(`(?i)\bhelm\b`), []string{"helm", "chart.yaml"}},
+	{regexp.MustCompile(`(?i)\bterraform\b`), []string{".tf", "terraform"}},
+	{regexp.MustCompile(`\bAWS\b`), []string{"aws"}},
+	{regexp.MustCompile(`\bGCP\b|Google Cloud`), []string{"gcp", "gcloud", "google-cloud", "cloud.google.com"}},
+	{regexp.MustCompile(`\bAzure\b`), []string{"azure"}},
+	{regexp.MustCompile(`(?i)\bprometheus\b`), []string{"prometheus"}},
+	{regexp.MustCompile(`(?i)\bgrafana\b`), []string{"grafana"}},
+	{regexp.MustCompile(`(?i)\bwebpack\b`), []string{"webpack"}},
+	{regexp.MustCompile(`(?i)\bvite\b`), []string{"vite"}},
+	{regexp.MustCompile(`(?i)\btailwind(css)?\b`), []string{"tailwind"}},
+	{regexp.MustCompile(`(?i)\bjenkins\b`), []string{"jenkins"}},
+	{regexp.MustCompile(`(?i)\bgithub actions\b`), []string{".github/workflows"}},
+	{regexp.MustCompile(`(?i)\bgemini\b`), []string{"gemini", "generative-ai"}},
+}
+
+// verifyTechnologies 核查分析结果中提到的技术
+func verifyTechnologies(analysis string, index *evidenceIndex) []Claim {
+	var claims []Claim
+	for _, tech := range technologies {
+		loc := tech.pattern.FindStringIndex(analysis)
+		if loc == nil {
+			continue
+		}
+
+		claim := Claim{Kind: ClaimTechnology, Text: analysis[loc[0]:loc[1]], offset: loc[0]}
+		for _, commit := range index.commits {
+			if matchesKeywords(commit, tech.keywords) {
+				claim.Evidence = appendEvidence(claim.Evidence, commit.hash)
+			}
+		}
+		for _, manifest := range index.manifests {
+			for _, keyword := range tech.keywords {
+				if !strings.HasPrefix(keyword, ".") && keywordPatterns[keyword].MatchString(manifest.text) {
+					claim.Sources = append(claim.Sources, manifest.source)
+					break
+				}
+			}
+		}
+		claim.Supported = len(claim.Evidence) > 0 || len(claim.Sources) > 0
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
+// matchesKeywords 判断提交是否包含技术的痕迹
+func matchesKeywords(commit commitText, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.HasPrefix(keyword, ".") {
+			// 扩展名只匹配文件路径后缀，目录形式的关键字匹配路径中的任意位置
+			for _, file := range commit.files {
+				if strings.HasSuffix(file, keyword) || strings.Contains(file, keyword+"/") {
+					return true
+				}
+			}
+			continue
+		}
+		pattern := keywordPatterns[keyword]
+		if pattern.MatchString(commit.text) {
+			return true
+		}
+		for _, file := range commit.files {
+			if pattern.MatchString(file) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keywordPatterns 技术关键字到匹配规则的映射。关键字只在单词或路径分段的边界上匹配，
+// 避免 gin 匹配 login、vite 匹配 invite
+var keywordPatterns = func() map[string]*regexp.Regexp {
+	patterns := make(map[string]*regexp.Regexp)
+	for _, tech := range technologies {
+		for _, keyword := range tech.keywords {
+			if strings.HasPrefix(keyword, ".") || patterns[keyword] != nil {
+				continue
+			}
+			expr := regexp.QuoteMeta(keyword)
+			// 以非单词字符开头或结尾的关键字（如 "next"）本身带有边界
+			if isWordByte(keyword[0]) {
+				expr = `\b` + expr
+			}
+			if isWordByte(keyword[len(keyword)-1]) {
+				expr += `\b`
+			}
+			patterns[keyword] = regexp.MustCompile(expr)
+		}
+	}
+	return patterns
+}()
+
+// isWordByte 判断字节是否为正则表达式中的单词字符
+func isWordByte(b byte) bool {
+	return isDigit(b) || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b == '_'
+}
+
+// projectHeadingPattern 匹配分析结果中的项目标题，如 "### 项目1: 支付网关"
+var projectHeadingPattern = regexp.MustCompile(`(?im)^#{2,4}\s*(?:项目\s*\d*|project\s*\d*)\s*[:：]\s*(.+?)\s*$`)
+
+// verifyRepos 核查分析结果中提到的项目是否对应收集到的仓库
+func verifyRepos(analysis string, index *evidenceIndex) []Claim {
+	var claims []Claim
+	for _, match := range projectHeadingPattern.FindAllStringSubmatchIndex(analysis, -1) {
+		name := strings.Trim(analysis[match[2]:match[3]], "[]*` ")
+		if name == "" {
+			continue
+		}
+		start := strings.Index(analysis[match[2]:match[3]], name) + match[2]
+
+		claim := Claim{Kind: ClaimRepo, Text: name, offset: start}
+		lowerName := strings.ToLower(name)
+		for _, repo := range index.repos {
+			base := strings.ToLower(filepath.Base(repo))
+			if strings.Contains(lowerName, base) || strings.Contains(base, lowerName) {
+				claim.Sources = append(claim.Sources, filepath.Base(repo))
+			}
+		}
+		for _, commit := range index.commits {
+			if strings.Contains(commit.text, lowerName) {
+				claim.Evidence = appendEvidence(claim.Evidence, commit.hash)
+			}
+		}
+		claim.Supported = len(claim.Evidence) > 0 || len(claim.Sources) > 0
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
+// numberPattern 匹配带单位的数字指标，如 40%、3倍、200ms
+var numberPattern = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(%|％|倍|x\b|ms\b|毫秒|qps\b|tps\b|万|k\b)`)
+
+// verifyNumbers 核查分析结果中的数字指标，只有在提交消息中出现过才视为有依据
+func verifyNumbers(analysis string, index *evidenceIndex) []Claim {
+	var claims []Claim
+	seen := make(map[string]bool)
+	for _, match := range numberPattern.FindAllStringSubmatchIndex(analysis, -1) {
+		text := analysis[match[0]:match[1]]
+		if seen[text] {
+			continue
+		}
+		seen[text] = true
+
+		number := analysis[match[2]:match[3]]
+		claim := Claim{Kind: ClaimNumber, Text: text, offset: match[0]}
+		for _, commit := range index.commits {
+			if containsNumber(commit.text, number) {
+				claim.Evidence = appendEvidence(claim.Evidence, commit.hash)
+			}
+		}
+		claim.Supported = len(claim.Evidence) > 0
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
+// containsNumber 判断文本中是否包含完整的数字（不是其他数字的一部分）
+func containsNumber(text, number string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], number)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(number)
+		before := start == 0 || !isDigit(text[start-1])
+		after := end == len(text) || !isDigit(text[end])
+		if before && after {
+			return true
+		}
+		offset = end
+	}
+}
+
+// isDigit 判断字节是否为数字
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// 日期相关的匹配规则
+var (
+	yearMonthPattern = regexp.MustCompile(`\b(20\d{2})\s*[-/.年]\s*(\d{1,2})(?:月|\b)`)
+	yearPattern      = regexp.MustCompile(`20\d{2}`) // 前后不能紧邻数字，在 verifyDates 中检查
+)
+
+// verifyDates 核查分析结果中的日期是否落在提交的时间范围内
+func verifyDates(analysis string, index *evidenceIndex) []Claim {
+	if index.from.IsZero() {
+		return nil
+	}
+
+	var claims []Claim
+	seen := make(map[string]bool)
+	covered := make(map[int]bool) // 已作为年月匹配的年份位置
+
+	for _, match := range yearMonthPattern.FindAllStringSubmatchIndex(analysis, -1) {
+		year, _ := strconv.Atoi(analysis[match[2]:match[3]])
+		month, _ := strconv.Atoi(analysis[match[4]:match[5]])
+		covered[match[2]] = true
+		if month < 1 || month > 12 {
+			continue
+		}
+
+		text := analysis[match[0]:match[1]]
+		if seen[text] {
+			continue
+		}
+		seen[text] = true
+
+		start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		claims = append(claims, index.dateClaim(text, match[0], start, start.AddDate(0, 1, 0)))
+	}
+
+	// 不消耗前后的字符，"2023-2024" 中的两个年份都会被核查
+	for _, match := range yearPattern.FindAllStringIndex(analysis, -1) {
+		if covered[match[0]] || match[0] > 0 && isDigit(analysis[match[0]-1]) ||
+			match[1] < len(analysis) && isDigit(analysis[match[1]]) {
+			continue
+		}
+		text := analysis[match[0]:match[1]]
+		if seen[text] {
+			continue
+		}
+		seen[text] = true
+
+		year, _ := strconv.Atoi(text)
+		start := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+		claims = append(claims, index.dateClaim(text, match[0], start, start.AddDate(1, 0, 0)))
+	}
+
+	return claims
+}
+
+// dateClaim 判断时间段 [start, end) 内是否有提交
+func (index *evidenceIndex) dateClaim(text string, offset int, start, end time.Time) Claim {
+	claim := Claim{Kind: ClaimDate, Text: text, offset: offset}
+	for _, commit := range index.commits {
+		if !commit.date.Before(start) && commit.date.Before(end) {
+			claim.Evidence = appendEvidence(claim.Evidence, commit.hash)
+		}
+	}
+	// 时间段与提交时间范围重叠也视为有依据（如描述项目起止时间）
+	if index.from.Before(end) && !index.to.Before(start) {
+		claim.Sources = append(claim.Sources, fmt.Sprintf("%s ~ %s", index.from.Format("2006-01-02"), index.to.Format("2006-01-02")))
+	}
+	claim.Supported = len(claim.Evidence) > 0 || len(claim.Sources) > 0
+	return claim
+}
+
+// appendEvidence 追加证据提交，超过上限时忽略
+func appendEvidence(evidence []string, hash string) []string {
+	if len(evidence) >= maxEvidence {
+		return evidence
+	}
+	return append(evidence, hash)
+}
@@ -0,0 +1,139 @@
+package verify
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MyceliumGrid/git-work-profile/internal/git"
+)
+
+// testEvidence 构造测试用的核查数据
+func testEvidence() Evidence {
+	return Evidence{
+		Commits: []git.CommitInfo{
+			{
+				Hash:         "aaaa1111",
+				Date:         time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC),
+				Message:      "feat: add redis cache, latency down 30%",
+				ChangedFiles: []string{"internal/cache/redis.go"},
+				RepoPath:     "/work/payment-api",
+			},
+			{
+				Hash:         "bbbb2222",
+				Date:         time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC),
+				Message:      "fix bug",
+				ChangedFiles: []string{"web/src/App.tsx"},
+				RepoPath:     "/work/dashboard",
+			},
+		},
+		Manifests: map[string]map[string]string{
+			"/work/payment-api": {"go.mod": "module example.com/pay\nrequire google.golang.org/grpc v1.60.0\n"},
+		},
+	}
+}
+
+// findClaim 按原文查找声明
+func findClaim(result *Result, text string) (Claim, bool) {
+	for _, claim := range result.Claims {
+		if claim.Text == text {
+			return claim, true
+		}
+	}
+	return Claim{}, false
+}
+
+// TestVerify 测试声明提取和核查
+func TestVerify(t *testing.T) {
+	analysis := "### 项目1: payment-api\n" +
+		"使用 Go 和 gRPC 构建服务，引入 Redis 缓存使延迟降低 30%，吞吐提升 3倍。\n" +
+		"前端使用 React，部署在 Kubernetes 上。\n" +
+		"2024年3月 完成缓存改造，2019 年参与早期设计。"
+
+	result := Verify(analysis, testEvidence())
+
+	tests := []struct {
+		text      string
+		supported bool
+	}{
+		{"payment-api", true},
+		{"Go", true},
+		{"gRPC", true},
+		{"Redis", true},
+		{"React", true},
+		{"Kubernetes", false},
+		{"30%", true},
+		{"3倍", false},
+		{"2024年3月", true},
+		{"2019", false},
+	}
+
+	for _, test := range tests {
+		claim, ok := findClaim(result, test.text)
+		if !ok {
+			t.Errorf("应提取出声明 %q", test.text)
+			continue
+		}
+		if claim.Supported != test.supported {
+			t.Errorf("声明 %q 的核查结果应为 %v, 得到 %v", test.text, test.supported, claim.Supported)
+		}
+	}
+
+	if claim, _ := findClaim(result, "Redis"); len(claim.Evidence) != 1 || claim.Evidence[0] != "aaaa1111" {
+		t.Errorf("Redis 的证据应为 aaaa1111, 得到 %v", claim.Evidence)
+	}
+	if claim, _ := findClaim(result, "gRPC"); len(claim.Sources) == 0 {
+		t.Error("gRPC 应以依赖清单作为依据")
+	}
+}
+
+// TestAnnotate 测试无依据声明的标注
+func TestAnnotate(t *testing.T) {
+	analysis := "部署在 Kubernetes 上，性能提升 3倍。"
+	result := Verify(analysis, testEvidence())
+	annotated := Annotate(analysis, result, "[?]")
+
+	if !strings.Contains(annotated, "Kubernetes [?]") || !strings.Contains(annotated, "3倍 [?]") {
+		t.Errorf("无依据的声明应被标注, 得到: %s", annotated)
+	}
+}
+
+// TestVerifyKeywordBoundaries 测试技术关键字只在单词或路径分段的边界上匹配
+func TestVerifyKeywordBoundaries(t *testing.T) {
+	evidence := Evidence{
+		Commits: []git.CommitInfo{
+			{
+				Hash:         "cccc3333",
+				Date:         time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+				Message:      "invite users from the login page, parse filter expression",
+				ChangedFiles: []string{"internal/auth/login.go", "plugins/engine.go"},
+			},
+		},
+	}
+	result := Verify("使用 Gin、Vite 和 Express 开发。", evidence)
+	for _, text := range []string{"Gin", "Vite", "Express"} {
+		if claim, ok := findClaim(result, text); !ok || claim.Supported {
+			t.Errorf("login.go、invite 和 expression 不应支持 %s: %+v", text, claim)
+		}
+	}
+
+	evidence.Manifests = map[string]map[string]string{
+		"/work/api": {"go.mod": "require github.com/gin-gonic/gin v1.9.1\n"},
+	}
+	if claim, _ := findClaim(Verify("使用 Gin 开发。", evidence), "Gin"); !claim.Supported {
+		t.Error("依赖清单中的 gin-gonic 应支持 Gin")
+	}
+}
+
+// TestVerifyYearRange 测试年份范围中的每个年份都会被核查
+func TestVerifyYearRange(t *testing.T) {
+	for _, analysis := range []string{"2023-2024 负责缓存改造", "2023/2024 负责缓存改造"} {
+		result := Verify(analysis, testEvidence())
+		if claim, ok := findClaim(result, "2023"); !ok || claim.Supported {
+			t.Errorf("%q: 2023 应被提取且没有依据: %+v", analysis, claim)
+		}
+		if claim, ok := findClaim(result, "2024"); !ok || !claim.Supported {
+			t.Errorf("%q: 2024 应被提取且有依据: %+v", analysis, claim)
+		}
+	}
+}